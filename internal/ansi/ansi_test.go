@@ -0,0 +1,61 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ansi_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/homeport/termshot/internal/ansi"
+)
+
+var _ = Describe("ANSI sequences", func() {
+	Context("tokenizing input", func() {
+		It("should split text, control characters, and escape sequences", func() {
+			tokens := Tokenize([]byte("a\x1b[1;31mb\n\x1b]0;title\x07\x1b(B"))
+			Expect(tokens).To(HaveLen(6))
+
+			Expect(tokens[0].Kind).To(Equal(Text))
+			Expect(tokens[0].Rune).To(Equal('a'))
+
+			Expect(tokens[1].Kind).To(Equal(CSI))
+			Expect(tokens[1].Offset).To(Equal(1))
+			Expect(tokens[1].Params).To(Equal("1;31"))
+			Expect(tokens[1].Final).To(Equal(byte('m')))
+
+			Expect(tokens[3].Kind).To(Equal(Control))
+			Expect(tokens[3].Rune).To(Equal('\n'))
+
+			Expect(tokens[4].Kind).To(Equal(OSC))
+			Expect(tokens[4].Params).To(Equal("0;title"))
+
+			Expect(tokens[5].Kind).To(Equal(Escape))
+			Expect(tokens[5].Intermediate).To(Equal("("))
+			Expect(tokens[5].Final).To(Equal(byte('B')))
+		})
+
+		It("should flag unterminated sequences", func() {
+			tokens := Tokenize([]byte("\x1b[1"))
+			Expect(tokens).To(HaveLen(1))
+			Expect(tokens[0].Unterminated).To(BeTrue())
+		})
+	})
+})
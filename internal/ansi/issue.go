@@ -0,0 +1,42 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ansi
+
+import (
+	"fmt"
+)
+
+// Issue describes a part of the input that is not fully supported when
+// rendering the content
+type Issue struct {
+	// Offset is the byte offset of the sequence in the input
+	Offset int
+
+	// Sequence contains the raw bytes of the sequence
+	Sequence string
+
+	// Message explains how the sequence is handled
+	Message string
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("offset %d: %q %s", i.Offset, i.Sequence, i.Message)
+}
@@ -0,0 +1,202 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package ansi scans terminal output into text, control characters, and
+// escape sequences
+package ansi
+
+import (
+	"unicode/utf8"
+)
+
+// Kind is the type of a token
+type Kind int
+
+const (
+	// Text is a printable character
+	Text Kind = iota
+
+	// Control is a C0 control character, for example a line feed
+	Control
+
+	// CSI is a control sequence, for example to set the text color
+	CSI
+
+	// OSC is an operating system command, for example to set the window title
+	OSC
+
+	// String is a device control, privacy message, application program
+	// command, or start of string sequence
+	String
+
+	// Escape is any other escape sequence, for example a charset designation
+	Escape
+
+	// Invalid is a byte that is not valid UTF-8
+	Invalid
+)
+
+const (
+	esc = 0x1b
+	bel = 0x07
+)
+
+// Token is one unit of terminal output
+type Token struct {
+	Kind Kind
+
+	// Offset is the byte offset of the token in the input
+	Offset int
+
+	// Raw contains all bytes of the token
+	Raw []byte
+
+	// Rune is the character of text and control tokens
+	Rune rune
+
+	// Params contains the parameter bytes of a control sequence, or the
+	// payload of operating system commands and string sequences
+	Params string
+
+	// Intermediate contains the intermediate bytes of a control or escape sequence
+	Intermediate string
+
+	// Final is the final byte of a control or escape sequence
+	Final byte
+
+	// Unterminated is true in case the input ended before the sequence was complete
+	Unterminated bool
+}
+
+// Tokenize splits the input into tokens
+func Tokenize(data []byte) []Token {
+	var tokens []Token
+	for offset := 0; offset < len(data); {
+		token := next(data, offset)
+		tokens = append(tokens, token)
+		offset += len(token.Raw)
+	}
+
+	return tokens
+}
+
+func next(data []byte, offset int) Token {
+	b := data[offset]
+
+	switch {
+	case b == esc:
+		return escapeSequence(data, offset)
+
+	case b < 0x20 || b == 0x7f:
+		return Token{Kind: Control, Offset: offset, Raw: data[offset : offset+1], Rune: rune(b)}
+	}
+
+	r, size := utf8.DecodeRune(data[offset:])
+	if r == utf8.RuneError && size <= 1 {
+		return Token{Kind: Invalid, Offset: offset, Raw: data[offset : offset+1], Rune: utf8.RuneError}
+	}
+
+	return Token{Kind: Text, Offset: offset, Raw: data[offset : offset+size], Rune: r}
+}
+
+func escapeSequence(data []byte, offset int) Token {
+	var token = Token{Kind: Escape, Offset: offset}
+	var i = offset + 1
+
+	var done = func() Token {
+		token.Raw = data[offset:i]
+		return token
+	}
+
+	if i >= len(data) {
+		token.Unterminated = true
+		return done()
+	}
+
+	switch data[i] {
+	case '[':
+		token.Kind = CSI
+		i++
+
+		start := i
+		for i < len(data) && data[i] >= 0x30 && data[i] <= 0x3f {
+			i++
+		}
+		token.Params = string(data[start:i])
+
+		start = i
+		for i < len(data) && data[i] >= 0x20 && data[i] <= 0x2f {
+			i++
+		}
+		token.Intermediate = string(data[start:i])
+
+		if i < len(data) && data[i] >= 0x40 && data[i] <= 0x7e {
+			token.Final = data[i]
+			i++
+		} else {
+			token.Unterminated = true
+		}
+
+		return done()
+
+	case ']', 'P', 'X', '^', '_':
+		token.Kind = String
+		if data[i] == ']' {
+			token.Kind = OSC
+		}
+
+		token.Final = data[i]
+		i++
+
+		start := i
+		for ; i < len(data); i++ {
+			switch {
+			case token.Kind == OSC && data[i] == bel:
+				token.Params = string(data[start:i])
+				i++
+				return done()
+
+			case data[i] == esc && i+1 < len(data) && data[i+1] == '\\':
+				token.Params = string(data[start:i])
+				i += 2
+				return done()
+			}
+		}
+
+		token.Params = string(data[start:i])
+		token.Unterminated = true
+		return done()
+	}
+
+	start := i
+	for i < len(data) && data[i] >= 0x20 && data[i] <= 0x2f {
+		i++
+	}
+	token.Intermediate = string(data[start:i])
+
+	if i < len(data) && data[i] >= 0x30 && data[i] <= 0x7e {
+		token.Final = data[i]
+		i++
+	} else {
+		token.Unterminated = true
+	}
+
+	return done()
+}
@@ -0,0 +1,206 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package cache implements an on-disk cache for rendered screenshots, where
+// the least recently used entries are removed once the cache exceeds its
+// maximum size, and entries expire after a configured time-to-live
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const suffix = ".cache"
+
+// Cache is an on-disk least recently used cache
+type Cache struct {
+	dir     string
+	maxSize int64
+	ttl     time.Duration
+}
+
+// New creates a cache in the given directory, a maximum size or time-to-live
+// of zero means that there is no limit
+func New(dir string, maxSize int64, ttl time.Duration) (*Cache, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	return &Cache{
+		dir:     dir,
+		maxSize: maxSize,
+		ttl:     ttl,
+	}, nil
+}
+
+// Key creates a cache key based on all provided parts
+func Key(parts ...[]byte) string {
+	hash := sha256.New()
+	for _, part := range parts {
+		_ = binary.Write(hash, binary.BigEndian, uint64(len(part)))
+		_, _ = hash.Write(part)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// Get returns the data stored for the key, if it exists and is not expired
+func (c *Cache) Get(key string) ([]byte, bool) {
+	path := c.path(key)
+
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil || len(data) < 8 {
+		return nil, false
+	}
+
+	created := time.Unix(0, int64(binary.BigEndian.Uint64(data[:8]))) // #nosec G115
+	if c.expired(created) {
+		_ = os.Remove(path)
+		return nil, false
+	}
+
+	// Use the modification time to keep track of the last usage
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+
+	return data[8:], true
+}
+
+// Put stores the data for the key and removes expired entries, as well as
+// the least recently used entries in case the cache exceeds its maximum size
+func (c *Cache) Put(key string, data []byte) error {
+	tmp, err := os.CreateTemp(c.dir, "tmp-")
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	var header [8]byte
+	binary.BigEndian.PutUint64(header[:], uint64(time.Now().UnixNano())) // #nosec G115
+
+	if _, err := tmp.Write(append(header[:], data...)); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp.Name(), c.path(key)); err != nil {
+		return err
+	}
+
+	return c.evict()
+}
+
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, key+suffix)
+}
+
+func (c *Cache) expired(created time.Time) bool {
+	return c.ttl > 0 && time.Now().Sub(created) > c.ttl
+}
+
+func (c *Cache) evict() error {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return err
+	}
+
+	type entry struct {
+		path     string
+		size     int64
+		lastUsed time.Time
+	}
+
+	var total int64
+	var list []entry
+	for _, dirEntry := range entries {
+		if dirEntry.IsDir() || !strings.HasSuffix(dirEntry.Name(), suffix) {
+			continue
+		}
+
+		info, err := dirEntry.Info()
+		if err != nil {
+			continue
+		}
+
+		path := filepath.Join(c.dir, dirEntry.Name())
+		if c.expired(c.created(path)) {
+			if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+
+			continue
+		}
+
+		total += info.Size()
+		list = append(list, entry{path: path, size: info.Size(), lastUsed: info.ModTime()})
+	}
+
+	if c.maxSize <= 0 || total <= c.maxSize {
+		return nil
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].lastUsed.Before(list[j].lastUsed)
+	})
+
+	for _, entry := range list {
+		if total <= c.maxSize {
+			break
+		}
+
+		if err := os.Remove(entry.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+
+		total -= entry.size
+	}
+
+	return nil
+}
+
+func (c *Cache) created(path string) time.Time {
+	file, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return time.Time{}
+	}
+
+	defer func() { _ = file.Close() }()
+
+	var header [8]byte
+	if _, err := file.Read(header[:]); err != nil {
+		return time.Time{}
+	}
+
+	return time.Unix(0, int64(binary.BigEndian.Uint64(header[:]))) // #nosec G115
+}
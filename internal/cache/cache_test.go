@@ -0,0 +1,91 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cache_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/homeport/termshot/internal/cache"
+)
+
+var _ = Describe("Cache", func() {
+	Context("creating keys", func() {
+		It("should create the same key for the same parts", func() {
+			Expect(Key([]byte("foo"), []byte("bar"))).To(Equal(Key([]byte("foo"), []byte("bar"))))
+		})
+
+		It("should not mix up the boundaries of the parts", func() {
+			Expect(Key([]byte("foo"), []byte("bar"))).ToNot(Equal(Key([]byte("foob"), []byte("ar"))))
+		})
+	})
+
+	Context("storing entries", func() {
+		It("should return stored entries", func() {
+			cache, err := New(GinkgoT().TempDir(), 0, 0)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(cache.Put("foo", []byte("bar"))).To(Succeed())
+
+			data, ok := cache.Get("foo")
+			Expect(ok).To(BeTrue())
+			Expect(string(data)).To(Equal("bar"))
+
+			_, ok = cache.Get("unknown")
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should not return expired entries", func() {
+			cache, err := New(GinkgoT().TempDir(), 0, time.Millisecond)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(cache.Put("foo", []byte("bar"))).To(Succeed())
+			time.Sleep(10 * time.Millisecond)
+
+			_, ok := cache.Get("foo")
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should remove the least recently used entries when exceeding the maximum size", func() {
+			cache, err := New(GinkgoT().TempDir(), 40, 0)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(cache.Put("a", []byte("0123456789"))).To(Succeed())
+			Expect(cache.Put("b", []byte("0123456789"))).To(Succeed())
+
+			_, ok := cache.Get("a")
+			Expect(ok).To(BeTrue())
+
+			Expect(cache.Put("c", []byte("0123456789"))).To(Succeed())
+
+			_, ok = cache.Get("a")
+			Expect(ok).To(BeTrue())
+
+			_, ok = cache.Get("b")
+			Expect(ok).To(BeFalse())
+
+			_, ok = cache.Get("c")
+			Expect(ok).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,145 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package cast reads terminal session recordings in the asciinema cast
+// file format (version 2 and 3)
+package cast
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Cast is a recorded terminal session
+type Cast struct {
+	Width  int
+	Height int
+	Title  string
+
+	// IdleTimeLimit is the maximum time between two events as configured
+	// in the recording, or zero if there is no limit
+	IdleTimeLimit time.Duration
+
+	// Events are the output events of the recording, events of other
+	// types like input or resize events are ignored
+	Events []Event
+}
+
+// Event is output of the recorded terminal session
+type Event struct {
+	// Time is the time of the event relative to the start of the recording
+	Time time.Duration
+
+	Data string
+}
+
+type header struct {
+	Version       int     `json:"version"`
+	Width         int     `json:"width"`
+	Height        int     `json:"height"`
+	Title         string  `json:"title"`
+	IdleTimeLimit float64 `json:"idle_time_limit"`
+	Term          struct {
+		Cols int `json:"cols"`
+		Rows int `json:"rows"`
+	} `json:"term"`
+}
+
+// Read reads a cast file, where the event times of version 2 files are
+// absolute and those of version 3 files are relative to the previous event
+func Read(r io.Reader) (*Cast, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("failed to read cast header: empty input")
+	}
+
+	var h header
+	if err := json.Unmarshal(scanner.Bytes(), &h); err != nil {
+		return nil, fmt.Errorf("failed to parse cast header: %w", err)
+	}
+
+	cast := Cast{
+		Title:         h.Title,
+		IdleTimeLimit: seconds(h.IdleTimeLimit),
+	}
+
+	switch h.Version {
+	case 2:
+		cast.Width, cast.Height = h.Width, h.Height
+
+	case 3:
+		cast.Width, cast.Height = h.Term.Cols, h.Term.Rows
+
+	default:
+		return nil, fmt.Errorf("unsupported cast file version %d", h.Version)
+	}
+
+	var last time.Duration
+	for line := 2; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 || scanner.Bytes()[0] == '#' {
+			continue
+		}
+
+		var event []interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return nil, fmt.Errorf("failed to parse event in line %d: %w", line, err)
+		}
+
+		if len(event) != 3 {
+			return nil, fmt.Errorf("failed to parse event in line %d: expected three elements", line)
+		}
+
+		timestamp, ok1 := event[0].(float64)
+		code, ok2 := event[1].(string)
+		data, ok3 := event[2].(string)
+		if !ok1 || !ok2 || !ok3 {
+			return nil, fmt.Errorf("failed to parse event in line %d: expected time, code, and data", line)
+		}
+
+		if h.Version == 3 {
+			last += seconds(timestamp)
+		} else {
+			last = seconds(timestamp)
+		}
+
+		if code == "o" {
+			cast.Events = append(cast.Events, Event{Time: last, Data: data})
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return &cast, nil
+}
+
+func seconds(value float64) time.Duration {
+	return time.Duration(value * float64(time.Second))
+}
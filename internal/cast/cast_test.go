@@ -0,0 +1,78 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cast_test
+
+import (
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/homeport/termshot/internal/cast"
+)
+
+var _ = Describe("Reading cast files", func() {
+	It("should read version 2 cast files", func() {
+		cast, err := Read(strings.NewReader(`{"version": 2, "width": 80, "height": 24, "idle_time_limit": 2.5, "title": "demo"}
+[0.5, "o", "$ ls\r\n"]
+[0.75, "i", "x"]
+[1.25, "o", "foo  bar\r\n"]
+`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*cast).To(Equal(Cast{
+			Width:         80,
+			Height:        24,
+			Title:         "demo",
+			IdleTimeLimit: 2500 * time.Millisecond,
+			Events: []Event{
+				{Time: 500 * time.Millisecond, Data: "$ ls\r\n"},
+				{Time: 1250 * time.Millisecond, Data: "foo  bar\r\n"},
+			},
+		}))
+	})
+
+	It("should read version 3 cast files with relative event times", func() {
+		cast, err := Read(strings.NewReader(`{"version": 3, "term": {"cols": 100, "rows": 30}}
+# comment
+[0.5, "o", "foo"]
+[0.25, "m", "marker"]
+[0.25, "o", "bar"]
+`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cast.Width).To(Equal(100))
+		Expect(cast.Height).To(Equal(30))
+		Expect(cast.Events).To(Equal([]Event{
+			{Time: 500 * time.Millisecond, Data: "foo"},
+			{Time: time.Second, Data: "bar"},
+		}))
+	})
+
+	It("should fail for unsupported versions", func() {
+		_, err := Read(strings.NewReader(`{"version": 1}`))
+		Expect(err).To(MatchError("unsupported cast file version 1"))
+	})
+
+	It("should fail for invalid events", func() {
+		_, err := Read(strings.NewReader("{\"version\": 2}\n[0.5, \"o\"]\n"))
+		Expect(err).To(MatchError(ContainSubstring("line 2")))
+	})
+})
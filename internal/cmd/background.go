@@ -0,0 +1,104 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/jpeg" // register JPEG decoder for background images
+	_ "image/png"  // register PNG decoder for background images
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/homeport/termshot/pkg/img"
+)
+
+// defaultGradientAngle is the angle of linear gradients from the top left to
+// the bottom right corner
+const defaultGradientAngle = 135
+
+// parseBackground parses the background setting, which is either a color,
+// a list of colors for a gradient, optionally prefixed with the angle of the
+// linear gradient or radial for a radial gradient, or an image file
+func parseBackground(value string) (img.Background, error) {
+	if isBackgroundImage(value) {
+		return loadBackgroundImage(value)
+	}
+
+	kind, list, found := strings.Cut(value, ":")
+	if !found {
+		kind, list = "", value
+	}
+
+	var colors []color.Color
+	for _, hex := range strings.Split(list, ",") {
+		c, err := img.ParseHexColor(strings.TrimSpace(hex))
+		if err != nil {
+			return nil, fmt.Errorf("invalid background color %q: %w", hex, err)
+		}
+
+		colors = append(colors, c)
+	}
+
+	switch {
+	case kind == "radial":
+		return img.RadialGradientBackground(colors...), nil
+
+	case kind != "":
+		angle, err := strconv.ParseFloat(kind, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid background gradient %q, expected an angle or radial", kind)
+		}
+
+		return img.LinearGradientBackground(angle, colors...), nil
+
+	case len(colors) == 1:
+		return img.SolidBackground(colors[0]), nil
+
+	default:
+		return img.LinearGradientBackground(defaultGradientAngle, colors...), nil
+	}
+}
+
+// isBackgroundImage returns whether the background setting refers to an image
+// file instead of colors
+func isBackgroundImage(value string) bool {
+	return !strings.HasPrefix(value, "#") && !strings.Contains(value, ":#")
+}
+
+func loadBackgroundImage(filename string) (img.Background, error) {
+	file, err := os.Open(filepath.Clean(filename))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open background image: %w", err)
+	}
+
+	defer func() { _ = file.Close() }()
+
+	backdrop, _, err := image.Decode(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode background image: %w", err)
+	}
+
+	return img.ImageBackground(backdrop), nil
+}
@@ -0,0 +1,312 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+
+	"github.com/homeport/termshot/internal/ptexec"
+	"github.com/homeport/termshot/pkg/img"
+)
+
+// batchJob is one screenshot of a batch manifest, or of a JSON job, where
+// the settings are called options and an identifier can be set to match the
+// result records with the jobs
+type batchJob struct {
+	ID       any            `yaml:"-" json:"id,omitempty"`
+	Command  string         `yaml:"command" json:"command"`
+	Input    string         `yaml:"input" json:"input"`
+	Content  string         `yaml:"content" json:"content"`
+	Output   string         `yaml:"output" json:"output"`
+	Theme    string         `yaml:"theme" json:"theme"`
+	Settings map[string]any `yaml:"settings" json:"options"`
+}
+
+// jobResult is the record written for each JSON job
+type jobResult struct {
+	ID     any    `json:"id,omitempty"`
+	Output string `json:"output,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+var batchCmd = &cobra.Command{
+	Use:   "batch [flags] manifest",
+	Short: "Creates multiple screenshots described in a manifest file",
+	Long: `Reads a YAML manifest with a list of jobs, each with either a command to
+run, an input file to read, or the content itself, the output file of the
+screenshot, and optionally a theme and further settings, which are the same
+as the flags to control the look. The flags of this command apply to all
+jobs, unless a job overrides them. The jobs are rendered concurrently.
+
+  jobs:
+  - command: ls -a
+    output: docs/ls.png
+    theme: nord
+    settings:
+      show-cmd: true
+      columns: 80
+  - input: session.txt
+    output: docs/session.svg
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := readFile(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to read manifest: %w", err)
+		}
+
+		var manifest struct {
+			Jobs []batchJob `yaml:"jobs"`
+		}
+
+		if err := yaml.Unmarshal(data, &manifest); err != nil {
+			return fmt.Errorf("failed to parse manifest %s: %w", args[0], err)
+		}
+
+		parallel, _ := cmd.Flags().GetInt("parallel")
+		if parallel < 1 {
+			return fmt.Errorf("invalid number of parallel jobs %d, it has to be one or more", parallel)
+		}
+
+		// Jobs are taken from the queue by a fixed number of workers, the
+		// errors are kept in the order of the jobs
+		queue := make(chan int)
+		errs := make([]error, len(manifest.Jobs))
+
+		var wg sync.WaitGroup
+		for range min(parallel, len(manifest.Jobs)) {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range queue {
+					if err := renderJob(cmd.Flags(), manifest.Jobs[i]); err != nil {
+						errs[i] = fmt.Errorf("job %d (%s): %w", i+1, manifest.Jobs[i].Output, err)
+						continue
+					}
+
+					_, _ = fmt.Fprintf(cmd.OutOrStdout(), "created %s\n", manifest.Jobs[i].Output)
+				}
+			}()
+		}
+
+		for i := range manifest.Jobs {
+			queue <- i
+		}
+
+		close(queue)
+		wg.Wait()
+
+		return errors.Join(errs...)
+	},
+}
+
+// renderJob creates the screenshot of a job with the look flags of the
+// batch command and the settings of the job
+func renderJob(flags *pflag.FlagSet, job batchJob) error {
+	switch {
+	case job.Output == "":
+		return fmt.Errorf("no output file")
+
+	case countNonEmpty(job.Command, job.Input, job.Content) != 1:
+		return fmt.Errorf("either a command, an input file, or content is required")
+	}
+
+	jobFlags := pflag.NewFlagSet(job.Output, pflag.ContinueOnError)
+	addLookFlags(jobFlags)
+	jobFlags.String("filename", "", "")
+	jobFlags.Bool("no-clobber", false, "")
+	jobFlags.Int("quality", 0, "")
+	jobFlags.Int("page-lines", 0, "")
+	addCacheFlags(jobFlags)
+
+	if err := copyChangedFlags(jobFlags, flags); err != nil {
+		return err
+	}
+
+	// The flags of the batch are only defaults of the job, so that the job
+	// can override them, even with a flag that cannot be used together with
+	// them, e.g. a theme of the job replaces a colorscheme of the batch
+	var err error
+	jobFlags.VisitAll(func(flag *pflag.Flag) {
+		if err == nil && explicitFlag(jobFlags, flag.Name) {
+			err = jobFlags.SetAnnotation(flag.Name, defaultSource, []string{batchSource})
+		}
+	})
+
+	if err != nil {
+		return err
+	}
+
+	// The output of the manifest is explicit and replaces existing files
+	if err := setFlagValues(jobFlags, "filename", job.Output); err != nil {
+		return err
+	}
+
+	for _, name := range sortedKeys(job.Settings) {
+		if jobFlags.Lookup(name) == nil || name == "filename" {
+			return fmt.Errorf("unknown setting %q", name)
+		}
+
+		if err := setFlagValue(jobFlags, name, job.Settings[name]); err != nil {
+			return fmt.Errorf("invalid value for setting %q: %w", name, err)
+		}
+	}
+
+	if job.Theme != "" {
+		if err := setFlagValues(jobFlags, "theme", job.Theme); err != nil {
+			return err
+		}
+	}
+
+	scaffold := img.NewImageCreator()
+	if err := applyLookFlags(jobFlags, &scaffold); err != nil {
+		return err
+	}
+
+	var content []byte
+	switch {
+	case job.Command != "":
+		if showCmd, _ := jobFlags.GetBool("show-cmd"); showCmd {
+			if err := scaffold.AddCommand(job.Command); err != nil {
+				return err
+			}
+		}
+
+		// Commands run next to each other, so they get no input and their
+		// output is not shown
+		pt := ptexec.New().Stdin(nil).Stdout(io.Discard)
+		if columns, err := jobFlags.GetInt("columns"); err == nil && columns > 0 {
+			pt.Cols(uint16(columns))
+		}
+
+		var err error
+		if content, err = pt.Command(job.Command).Run(); err != nil {
+			return fmt.Errorf("failed to run command in pseudo terminal: %w", err)
+		}
+
+	case job.Input != "":
+		var err error
+		if content, err = readFile(job.Input); err != nil {
+			return fmt.Errorf("failed to read contents: %w", err)
+		}
+
+	default:
+		content = []byte(job.Content)
+	}
+
+	if err := scaffold.AddContent(bytes.NewReader(content)); err != nil {
+		return err
+	}
+
+	if err := checkCardFormat(jobFlags); err != nil {
+		return err
+	}
+
+	file, err := createOutputFile(jobFlags, sortedKeys(imageWriters)...)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = file.Close() }()
+
+	// Jobs share the render cache with single screenshots
+	format := strings.ToLower(filepath.Ext(file.Name()))
+	if useCache, _ := jobFlags.GetBool("cache"); useCache {
+		return writeCached(jobFlags, &scaffold, file, format)
+	}
+
+	return imageWriters[format](&scaffold, file, jobFlags)
+}
+
+// runJSONJobs reads one JSON job per line and renders it, the result of
+// each job is written as one JSON record per line, so that other tools can
+// create many screenshots using one process
+func runJSONJobs(flags *pflag.FlagSet, in io.Reader, out io.Writer) error {
+	encoder := json.NewEncoder(out)
+
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var job batchJob
+		result := jobResult{}
+		if err := json.Unmarshal(line, &job); err != nil {
+			result.Error = fmt.Sprintf("failed to parse job: %v", err)
+
+		} else if err := renderJob(flags, job); err != nil {
+			result.ID, result.Error = job.ID, err.Error()
+
+		} else {
+			result.ID, result.Output = job.ID, job.Output
+		}
+
+		if err := encoder.Encode(result); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
+
+func countNonEmpty(values ...string) int {
+	var count int
+	for _, value := range values {
+		if value != "" {
+			count++
+		}
+	}
+
+	return count
+}
+
+func init() {
+	rootCmd.AddCommand(batchCmd)
+
+	batchCmd.Flags().SortFlags = false
+	addBatchFlags(batchCmd.Flags())
+}
+
+// addBatchFlags adds the flags of the batch command
+func addBatchFlags(flags *pflag.FlagSet) {
+	flags.Int("parallel", runtime.NumCPU(), "number of jobs to render at the same time")
+	flags.Bool("no-clobber", false, "fail instead of replacing output files that already exist")
+
+	// flags to control look
+	addLookFlags(flags)
+	addCacheFlags(flags)
+}
@@ -0,0 +1,140 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var _ = Describe("Batches", func() {
+	var dir string
+
+	var path = func(name string) string {
+		return filepath.Join(dir, name)
+	}
+
+	var batch = func(manifest string, args ...string) (string, error) {
+		filename := path("jobs.yaml")
+		Expect(os.WriteFile(filename, []byte(manifest), 0o600)).To(Succeed())
+
+		var out bytes.Buffer
+		cmd := &cobra.Command{Args: batchCmd.Args, RunE: batchCmd.RunE, SilenceUsage: true, SilenceErrors: true}
+		cmd.SetOut(&out)
+		addBatchFlags(cmd.Flags())
+		cmd.SetArgs(append(args, filename))
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+	})
+
+	It("should create the output files of all jobs", func() {
+		Expect(os.WriteFile(path("session.txt"), []byte("\x1b[32mok\x1b[0m\n"), 0o600)).To(Succeed())
+
+		out, err := batch(fmt.Sprintf("jobs:\n- content: foobar\n  output: %s\n- input: %s\n  output: %s\n",
+			path("docs/content.png"), path("session.txt"), path("session.svg")))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(out).To(ContainSubstring("created " + path("docs/content.png") + "\n"))
+		Expect(out).To(ContainSubstring("created " + path("session.svg") + "\n"))
+		Expect(path("docs/content.png")).To(BeAnExistingFile())
+		Expect(path("session.svg")).To(BeAnExistingFile())
+	})
+
+	It("should replace existing output files unless no-clobber is set", func() {
+		Expect(os.WriteFile(path("out.svg"), nil, 0o600)).To(Succeed())
+
+		_, err := batch(fmt.Sprintf("jobs:\n- content: foobar\n  output: %s\n", path("out.svg")))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(os.ReadFile(path("out.svg"))).To(ContainSubstring("foobar"))
+
+		_, err = batch(fmt.Sprintf("jobs:\n- content: foobar\n  output: %s\n", path("out.svg")), "--no-clobber")
+		Expect(err).To(MatchError(ContainSubstring("already exists")))
+	})
+
+	It("should let jobs override the look of the batch", func() {
+		Expect(os.WriteFile(path("cs.json"), []byte(`{"colors": {"color1": "#ff0000"}}`), 0o600)).To(Succeed())
+
+		_, err := batch(fmt.Sprintf("jobs:\n- content: foobar\n  output: %s\n  theme: nord\n- content: foobar\n  output: %s\n  settings:\n    scale: 1\n",
+			path("theme.png"), path("scale.png")), "--colorscheme", path("cs.json"), "--dpi", "192")
+		Expect(err).ToNot(HaveOccurred())
+
+		flags := pflag.NewFlagSet("batch", pflag.ContinueOnError)
+		addBatchFlags(flags)
+		Expect(flags.Parse([]string{"--theme", "nord"})).To(Succeed())
+		Expect(renderJob(flags, batchJob{Content: "foobar", Output: path("both.png"), Settings: map[string]any{"colorscheme": path("cs.json")}})).To(Succeed())
+	})
+
+	It("should report all failing jobs with their number and output", func() {
+		out, err := batch(fmt.Sprintf("jobs:\n- content: foobar\n- content: foobar\n  output: %s\n- content: foobar\n  input: %s\n  output: %s\n- content: foobar\n  output: %s\n  settings:\n    unknown: 1\n",
+			path("ok.png"), path("session.txt"), path("both.png"), path("unknown.png")))
+
+		Expect(err).To(MatchError(ContainSubstring("job 1 (): no output file")))
+		Expect(err).To(MatchError(ContainSubstring(fmt.Sprintf("job 3 (%s): either a command, an input file, or content is required", path("both.png")))))
+		Expect(err).To(MatchError(ContainSubstring(fmt.Sprintf(`job 4 (%s): unknown setting "unknown"`, path("unknown.png")))))
+		Expect(err.Error()).ToNot(ContainSubstring("job 2"))
+		Expect(out).To(Equal("created " + path("ok.png") + "\n"))
+	})
+
+	Context("JSON jobs", func() {
+		It("should write one result record per job", func() {
+			in := strings.Join([]string{
+				fmt.Sprintf(`{"id": 1, "content": "foobar", "output": %q}`, path("one.png")),
+				`{"id": 2, "content":`,
+				"",
+				fmt.Sprintf(`{"id": "three", "content": "foobar", "output": %q, "options": {"unknown": true}}`, path("three.png")),
+			}, "\n")
+
+			flags := pflag.NewFlagSet("termshot", pflag.ContinueOnError)
+			addBatchFlags(flags)
+
+			var out bytes.Buffer
+			Expect(runJSONJobs(flags, strings.NewReader(in), &out)).To(Succeed())
+
+			var results []jobResult
+			decoder := json.NewDecoder(&out)
+			for decoder.More() {
+				var result jobResult
+				Expect(decoder.Decode(&result)).To(Succeed())
+				results = append(results, result)
+			}
+
+			Expect(results).To(Equal([]jobResult{
+				{ID: 1.0, Output: path("one.png")},
+				{Error: "failed to parse job: unexpected end of JSON input"},
+				{ID: "three", Error: `unknown setting "unknown"`},
+			}))
+
+			Expect(path("one.png")).To(BeAnExistingFile())
+		})
+	})
+})
@@ -0,0 +1,163 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/homeport/termshot/internal/cache"
+	"github.com/homeport/termshot/pkg/img"
+)
+
+// addCacheFlags registers all flags that control the render cache
+func addCacheFlags(flags *pflag.FlagSet) {
+	flags.Bool("cache", false, "reuse previously rendered screenshots of identical content and look settings")
+	flags.String("cache-dir", "", "directory of the render cache (default is termshot in the user cache directory)")
+	flags.Int64("cache-size", 256, "maximum size of the render cache in megabytes")
+	flags.Duration("cache-ttl", 7*24*time.Hour, "time after which render cache entries expire")
+}
+
+// openCache opens the render cache configured by the cache flags
+func openCache(flags *pflag.FlagSet) (*cache.Cache, error) {
+	dir, _ := flags.GetString("cache-dir")
+	if dir == "" {
+		userCacheDir, err := os.UserCacheDir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to determine cache directory: %w", err)
+		}
+
+		dir = filepath.Join(userCacheDir, "termshot")
+	}
+
+	size, _ := flags.GetInt64("cache-size")
+	ttl, _ := flags.GetDuration("cache-ttl")
+
+	return cache.New(dir, size*1024*1024, ttl)
+}
+
+// renderKey creates the cache key for the screenshot based on the output
+// format and its settings, the fingerprint of the scaffold with its content
+// and settings, all flags that control the look, and the termshot version
+func renderKey(flags *pflag.FlagSet, scaffold *img.Scaffold, format string) (string, error) {
+	fingerprint, err := scaffold.Fingerprint()
+	if err != nil {
+		return "", err
+	}
+
+	var look bytes.Buffer
+	var lookErr error
+	lookFlags := pflag.NewFlagSet("look", pflag.ContinueOnError)
+	addLookFlags(lookFlags)
+	lookFlags.VisitAll(func(f *pflag.Flag) {
+		flag := flags.Lookup(f.Name)
+		if flag == nil || lookErr != nil {
+			return
+		}
+
+		fmt.Fprintf(&look, "%s=%s\n", flag.Name, flag.Value.String())
+
+		// Files referenced by flags can change without the flag changing
+		var files []string
+		switch flag.Name {
+		case "font", "fallback-font":
+			files, _ = flags.GetStringSlice(flag.Name)
+
+		case "colorscheme", "decorate":
+			if name, _ := flags.GetString(flag.Name); name != "" {
+				files = []string{name}
+			}
+
+		case "background":
+			if value, _ := flags.GetString(flag.Name); value != "" && isBackgroundImage(value) {
+				files = []string{value}
+			}
+
+		case "watermark":
+			if value, _ := flags.GetString(flag.Name); isWatermarkImage(value) {
+				files = []string{value}
+			}
+		}
+
+		for _, file := range files {
+			data, err := os.ReadFile(filepath.Clean(file))
+			if err != nil {
+				lookErr = err
+				return
+			}
+
+			look.Write(data)
+		}
+	})
+
+	if lookErr != nil {
+		return "", lookErr
+	}
+
+	quality, _ := flags.GetInt("quality")
+	linesPerPage, _ := flags.GetInt("page-lines")
+	embed, _ := flags.GetBool("embed-content")
+	return cache.Key([]byte(version), []byte(fmt.Sprintf("%s:%d:%d:%t", format, quality, linesPerPage, embed)), look.Bytes(), fingerprint), nil
+}
+
+// writeCached writes the screenshot in the format of the file extension,
+// using the render cache to skip the rendering in case the same screenshot
+// was rendered before
+func writeCached(flags *pflag.FlagSet, scaffold *img.Scaffold, w io.Writer, format string) error {
+	renderCache, err := openCache(flags)
+	if err != nil {
+		return err
+	}
+
+	// Screenshots that cannot be described are always rendered
+	key, err := renderKey(flags, scaffold, format)
+	switch {
+	case errors.Is(err, img.ErrNoFingerprint):
+		return imageWriters[format](scaffold, w, flags)
+
+	case err != nil:
+		return fmt.Errorf("failed to create cache key: %w", err)
+	}
+
+	if data, ok := renderCache.Get(key); ok {
+		_, err := w.Write(data)
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := imageWriters[format](scaffold, &buf, flags); err != nil {
+		return err
+	}
+
+	if err := renderCache.Put(key, buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to store screenshot in cache: %w", err)
+	}
+
+	_, err = w.Write(buf.Bytes())
+	return err
+}
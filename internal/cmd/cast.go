@@ -0,0 +1,310 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"image"
+	"image/png"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/homeport/termshot/internal/cast"
+	"github.com/homeport/termshot/internal/vt"
+	"github.com/homeport/termshot/pkg/img"
+)
+
+// minFrameDelay is the minimum time a frame is shown, events of the cast
+// within this time are combined into one frame
+const minFrameDelay = 50 * time.Millisecond
+
+// finalFrameDelay is the time the last frame is shown before the animation
+// starts over
+const finalFrameDelay = 2 * time.Second
+
+// castFrame is the point in time of the animation, where the output of all
+// events up to the end index is shown
+type castFrame struct {
+	at  time.Duration
+	end int
+}
+
+// addCastFlags registers all flags that control the rendering of casts
+func addCastFlags(flags *pflag.FlagSet) {
+	flags.String("cast", "", "render asciinema cast file as animated GIF, WebP, or APNG instead of executing a command")
+	flags.Float64("speed", 1, "playback speed of the cast")
+	flags.Duration("idle-time-limit", 0, "maximum time between two frames of the cast (default is the limit of the cast file)")
+	flags.Duration("at", 0, "render the screen of the cast at the point in time of the playback (after speed and idle time limit, not the time of the recording) as a still image, e.g. 12.5s")
+	flags.Int("filmstrip", 0, "render the screens of the cast at the number of evenly spaced points in time of the playback into one image")
+	flags.DurationSlice("filmstrip-at", nil, "render the screens of the cast at the points in time of the playback into one image, e.g. 1s,5s,12.5s")
+	flags.Int("filmstrip-columns", 1, "number of columns of the filmstrip, where one column results in a vertical strip")
+}
+
+// renderCast renders each output event of the cast file as a frame of an
+// animated GIF, WebP, or APNG, using the terminal size of the recording as the
+// window size and the screen of an emulated terminal as the content of each
+// frame, or only the frame at a point in time as a still image
+func renderCast(flags *pflag.FlagSet, filename string) error {
+	data, err := readFile(filename)
+	if err != nil {
+		return fmt.Errorf("failed to read cast file: %w", err)
+	}
+
+	recording, err := cast.Read(strings.NewReader(string(data)))
+	if err != nil {
+		return fmt.Errorf("failed to read cast file: %w", err)
+	}
+
+	if len(recording.Events) == 0 {
+		return fmt.Errorf("cast file %s contains no output", filename)
+	}
+
+	scaffold := img.NewImageCreator()
+	if err := applyLookFlags(flags, &scaffold); err != nil {
+		return err
+	}
+
+	if columns, err := flags.GetInt("columns"); err != nil || columns == 0 {
+		scaffold.SetColumns(recording.Width)
+	}
+
+	scaffold.SetRows(recording.Height)
+
+	if recording.Title != "" {
+		scaffold.SetTitle(recording.Title)
+	}
+
+	speed, _ := flags.GetFloat64("speed")
+	if speed <= 0 {
+		return fmt.Errorf("invalid speed %v, speed has to be greater than zero", speed)
+	}
+
+	idleTimeLimit, _ := flags.GetDuration("idle-time-limit")
+	if idleTimeLimit == 0 {
+		idleTimeLimit = recording.IdleTimeLimit
+	}
+
+	times := castEventTimes(recording.Events, speed, idleTimeLimit)
+	if flags.Changed("at") {
+		at, _ := flags.GetDuration("at")
+		return renderCastStill(flags, scaffold, recording, times, at)
+	}
+
+	if filmstrip, _ := flags.GetInt("filmstrip"); filmstrip != 0 || flags.Changed("filmstrip-at") {
+		return renderFilmstrip(flags, scaffold, recording, times)
+	}
+
+	castFrames := castTimeline(times)
+
+	// The terminal of the recording, where the screen content after the
+	// events of a frame is rendered as the frame
+	screen := vt.New(recording.Width, recording.Height)
+
+	var frames []img.Frame
+	var previous string
+	var next int
+	for i, castFrame := range castFrames {
+		for ; next < castFrame.end; next++ {
+			_, _ = screen.Write([]byte(recording.Events[next].Data))
+		}
+
+		delay := finalFrameDelay
+		if i+1 < len(castFrames) {
+			delay = castFrames[i+1].at - castFrame.at
+		}
+
+		content := screen.String()
+		if content == previous && len(frames) > 0 {
+			frames[len(frames)-1].Delay += delay
+			continue
+		}
+
+		previous = content
+
+		frameScaffold := scaffold
+		if err := frameScaffold.AddContent(strings.NewReader(content)); err != nil {
+			return err
+		}
+
+		image, err := frameScaffold.Image()
+		if err != nil {
+			return fmt.Errorf("failed to render frame at %v: %w", castFrame.at, err)
+		}
+
+		frames = append(frames, img.Frame{Image: image, Delay: delay})
+	}
+
+	if !explicitFilename(flags) {
+		if err := flags.Lookup("filename").Value.Set("out.gif"); err != nil {
+			return err
+		}
+	}
+
+	file, err := createOutputFile(flags, ".gif", ".webp", ".apng")
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = file.Close() }()
+
+	switch strings.ToLower(filepath.Ext(file.Name())) {
+	case ".webp":
+		quality, _ := flags.GetInt("quality")
+		return img.WriteAnimatedWebP(file, frames, img.WebPOptions{Quality: quality})
+
+	case ".apng":
+		return img.WriteAPNG(file, frames)
+
+	default:
+		return img.WriteGIF(file, frames)
+	}
+}
+
+// renderCastStill renders the screen of the emulated terminal after all
+// events up to the point in time of the playback as a single image
+func renderCastStill(flags *pflag.FlagSet, scaffold img.Scaffold, recording *cast.Cast, times []time.Duration, at time.Duration) error {
+	content, err := castScreen(recording, times, at)
+	if err != nil {
+		return err
+	}
+
+	if err := scaffold.AddContent(strings.NewReader(content)); err != nil {
+		return err
+	}
+
+	file, err := createOutputFile(flags, sortedKeys(imageWriters)...)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = file.Close() }()
+
+	return imageWriters[strings.ToLower(filepath.Ext(file.Name()))](&scaffold, file, flags)
+}
+
+// renderFilmstrip renders the screens of the emulated terminal at multiple
+// points in time of the playback and places them in a grid of one image,
+// which are either configured or evenly spaced until the end of the playback
+func renderFilmstrip(flags *pflag.FlagSet, scaffold img.Scaffold, recording *cast.Cast, times []time.Duration) error {
+	points, _ := flags.GetDurationSlice("filmstrip-at")
+	if len(points) == 0 {
+		count, _ := flags.GetInt("filmstrip")
+		if count < 0 {
+			return fmt.Errorf("invalid number of filmstrip frames %d, expected a positive number", count)
+		}
+
+		end := times[len(times)-1]
+		for i := 1; i <= count; i++ {
+			points = append(points, end*time.Duration(i)/time.Duration(count))
+		}
+	}
+
+	images := make([]image.Image, len(points))
+	for i, at := range points {
+		content, err := castScreen(recording, times, at)
+		if err != nil {
+			return err
+		}
+
+		frameScaffold := scaffold
+		if err := frameScaffold.AddContent(strings.NewReader(content)); err != nil {
+			return err
+		}
+
+		if images[i], err = frameScaffold.Image(); err != nil {
+			return fmt.Errorf("failed to render frame at %v: %w", at, err)
+		}
+	}
+
+	columns, _ := flags.GetInt("filmstrip-columns")
+	strip, err := img.Filmstrip(images, columns)
+	if err != nil {
+		return err
+	}
+
+	file, err := createOutputFile(flags)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = file.Close() }()
+
+	return png.Encode(file, strip)
+}
+
+// castScreen returns the screen content of the emulated terminal after all
+// events up to the point in time of the playback
+func castScreen(recording *cast.Cast, times []time.Duration, at time.Duration) (string, error) {
+	if at < 0 {
+		return "", fmt.Errorf("invalid point in time %v, it has to be zero or greater", at)
+	}
+
+	screen := vt.New(recording.Width, recording.Height)
+	for i, event := range recording.Events {
+		if times[i] > at {
+			break
+		}
+
+		_, _ = screen.Write([]byte(event.Data))
+	}
+
+	return screen.String(), nil
+}
+
+// castEventTimes returns the point in time of the playback of each event,
+// where the time between events is limited to the idle time limit and
+// scaled by the speed
+func castEventTimes(events []cast.Event, speed float64, idleTimeLimit time.Duration) []time.Duration {
+	times := make([]time.Duration, len(events))
+	var at, last time.Duration
+	for i, event := range events {
+		gap := event.Time - last
+		last = event.Time
+
+		if idleTimeLimit > 0 && gap > idleTimeLimit {
+			gap = idleTimeLimit
+		}
+
+		at += time.Duration(float64(gap) / speed)
+		times[i] = at
+	}
+
+	return times
+}
+
+// castTimeline combines the events into frames based on their point in time
+// of the playback
+func castTimeline(times []time.Duration) []castFrame {
+	var frames []castFrame
+	for i, at := range times {
+		if len(frames) > 0 && at-frames[len(frames)-1].at < minFrameDelay {
+			frames[len(frames)-1].end = i + 1
+			continue
+		}
+
+		frames = append(frames, castFrame{at: at, end: i + 1})
+	}
+
+	return frames
+}
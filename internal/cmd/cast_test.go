@@ -0,0 +1,104 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/pflag"
+
+	"github.com/homeport/termshot/internal/cast"
+	"github.com/homeport/termshot/pkg/img"
+)
+
+var _ = Describe("Casts", func() {
+	// The recording has a long pause before the last event, which the
+	// idle time limit shortens to two seconds and the speed halves
+	var recording = &cast.Cast{
+		Width:  20,
+		Height: 2,
+		Events: []cast.Event{
+			{Time: 0, Data: "one "},
+			{Time: time.Second, Data: "two "},
+			{Time: 11 * time.Second, Data: "three"},
+		},
+	}
+
+	Context("playback times", func() {
+		It("should limit the idle time and scale by the speed", func() {
+			Expect(castEventTimes(recording.Events, 1, 0)).To(Equal([]time.Duration{0, time.Second, 11 * time.Second}))
+			Expect(castEventTimes(recording.Events, 1, 2*time.Second)).To(Equal([]time.Duration{0, time.Second, 3 * time.Second}))
+			Expect(castEventTimes(recording.Events, 2, 2*time.Second)).To(Equal([]time.Duration{0, 500 * time.Millisecond, 1500 * time.Millisecond}))
+		})
+	})
+
+	Context("screens at a point in time", func() {
+		var times []time.Duration
+
+		BeforeEach(func() {
+			times = castEventTimes(recording.Events, 2, 2*time.Second)
+		})
+
+		It("should use the playback time and not the recording time", func() {
+			screen, err := castScreen(recording, times, 1500*time.Millisecond)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(screen).To(ContainSubstring("one two three"))
+
+			screen, err = castScreen(recording, times, time.Second)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(screen).To(ContainSubstring("one two"))
+			Expect(screen).ToNot(ContainSubstring("three"))
+		})
+
+		It("should include events exactly at the point in time", func() {
+			screen, err := castScreen(recording, times, 500*time.Millisecond)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(screen).To(ContainSubstring("one two"))
+
+			screen, err = castScreen(recording, times, 500*time.Millisecond-time.Nanosecond)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(screen).To(ContainSubstring("one"))
+			Expect(screen).ToNot(ContainSubstring("two"))
+		})
+
+		It("should fail for negative points in time", func() {
+			_, err := castScreen(recording, times, -time.Second)
+			Expect(err).To(MatchError(ContainSubstring("invalid point in time")))
+		})
+
+		It("should render the screen at the point in time as a still image", func() {
+			filename := filepath.Join(GinkgoT().TempDir(), "still.html")
+			flags := pflag.NewFlagSet("cast", pflag.ContinueOnError)
+			flags.StringP("filename", "f", filename, "")
+			flags.Bool("no-clobber", false, "")
+
+			Expect(renderCastStill(flags, img.NewImageCreator(), recording, times, 1500*time.Millisecond)).To(Succeed())
+
+			data, err := os.ReadFile(filename)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(ContainSubstring("three"))
+		})
+	})
+})
@@ -0,0 +1,216 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+
+	"github.com/homeport/termshot/internal/diff"
+	"github.com/homeport/termshot/internal/ptexec"
+	"github.com/homeport/termshot/pkg/img"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var (
+	removedLineColor = color.NRGBA{R: 0xED, G: 0x65, B: 0x5A, A: 0x50}
+	addedLineColor   = color.NRGBA{R: 0x71, G: 0xBD, B: 0x47, A: 0x50}
+)
+
+var compareCmd = &cobra.Command{
+	Use:     "compare --before command --after command",
+	Aliases: []string{"diff"},
+	Short:   "Creates a side-by-side screenshot comparing the output of two commands",
+	Long: `Executes both provided commands in a pseudo terminal and compares their
+output line by line. The result is rendered as two windows next to each
+other, where lines that only exist in the output of the first command and
+lines that only exist in the output of the second command are highlighted.
+
+Instead of the --before and --after flags, the two commands can be provided
+as arguments separated by --, or as two quoted arguments, for example:
+
+  termshot diff -- ls -l -- ls -lh
+`,
+	Args: cobra.ArbitraryArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		before, _ := cmd.Flags().GetString("before")
+		after, _ := cmd.Flags().GetString("after")
+
+		switch {
+		case len(args) > 0 && (before != "" || after != ""):
+			return fmt.Errorf("the commands are either provided with --before and --after, or as arguments")
+
+		case len(args) > 0:
+			beforeArgs, afterArgs, err := splitCommands(args)
+			if err != nil {
+				return err
+			}
+
+			return compareCommands(cmd.Flags(), beforeArgs, afterArgs)
+
+		case before == "" || after == "":
+			return fmt.Errorf("the commands have to be provided with --before and --after, or as arguments")
+		}
+
+		return compareCommands(cmd.Flags(), []string{before}, []string{after})
+	},
+}
+
+// splitCommands splits the arguments into the two commands, which are either
+// separated by -- and run as they are, or provided as exactly two arguments,
+// which are run like the --before and --after flags
+func splitCommands(args []string) ([]string, []string, error) {
+	for i, arg := range args {
+		if arg == "--" {
+			if i == 0 || i == len(args)-1 {
+				break
+			}
+
+			return args[:i], args[i+1:], nil
+		}
+	}
+
+	if len(args) == 2 {
+		return args[:1], args[1:], nil
+	}
+
+	return nil, nil, fmt.Errorf("expected two commands separated by --")
+}
+
+// compareCommands runs both commands, highlights the lines that differ, and
+// renders both windows next to each other, or below each other if stacked
+func compareCommands(flags *pflag.FlagSet, before, after []string) error {
+	beforeScaffold, beforeOffset, err := captureCommand(flags, before)
+	if err != nil {
+		return err
+	}
+
+	afterScaffold, afterOffset, err := captureCommand(flags, after)
+	if err != nil {
+		return err
+	}
+
+	beforeLines := beforeScaffold.Lines()[beforeOffset:]
+	afterLines := afterScaffold.Lines()[afterOffset:]
+
+	var removed, added int
+	for _, line := range diff.Lines(beforeLines, afterLines) {
+		switch line.Operation {
+		case diff.Removed:
+			beforeScaffold.HighlightLine(beforeOffset+line.A, removedLineColor)
+			removed++
+
+		case diff.Added:
+			afterScaffold.HighlightLine(afterOffset+line.B, addedLineColor)
+			added++
+		}
+	}
+
+	beforeScaffold.SetTitle("before: " + strings.Join(before, " "))
+	if err := beforeScaffold.AddFooter(fmt.Sprintf("%d of %d lines removed", removed, len(beforeLines))); err != nil {
+		return err
+	}
+
+	afterScaffold.SetTitle("after: " + strings.Join(after, " "))
+	if err := afterScaffold.AddFooter(fmt.Sprintf("%d of %d lines added", added, len(afterLines))); err != nil {
+		return err
+	}
+
+	var images []image.Image
+	for _, scaffold := range []img.Scaffold{beforeScaffold, afterScaffold} {
+		image, err := scaffold.Image()
+		if err != nil {
+			return err
+		}
+
+		images = append(images, image)
+	}
+
+	file, err := createOutputFile(flags)
+	if err != nil {
+		return err
+	}
+
+	columns := len(images)
+	if stacked, err := flags.GetBool("stacked"); err == nil && stacked {
+		columns = 1
+	}
+
+	defer func() { _ = file.Close() }()
+	return png.Encode(file, img.Grid(columns, images...))
+}
+
+// captureCommand runs the command in a pseudo terminal and returns a scaffold
+// with its output, as well as the index of the first line of the output
+func captureCommand(flags *pflag.FlagSet, command []string) (img.Scaffold, int, error) {
+	scaffold := img.NewImageCreator()
+	if err := applyLookFlags(flags, &scaffold); err != nil {
+		return scaffold, 0, err
+	}
+
+	pt := ptexec.New()
+	if columns, err := flags.GetInt("columns"); err == nil && columns > 0 {
+		pt.Cols(uint16(columns))
+	}
+
+	if includeCommand, err := flags.GetBool("show-cmd"); err == nil && includeCommand {
+		if err := scaffold.AddCommand(command...); err != nil {
+			return scaffold, 0, err
+		}
+	}
+
+	offset := len(scaffold.Lines())
+
+	out, err := pt.Command(command[0], command[1:]...).Run()
+	if err != nil {
+		return scaffold, 0, fmt.Errorf("failed to run command in pseudo terminal: %w", err)
+	}
+
+	if err := scaffold.AddContent(bytes.NewReader(out)); err != nil {
+		return scaffold, 0, err
+	}
+
+	return scaffold, offset, nil
+}
+
+func init() {
+	rootCmd.AddCommand(compareCmd)
+
+	compareCmd.Flags().SortFlags = false
+
+	// flags to control content
+	compareCmd.Flags().String("before", "", "command to create the output before the change")
+	compareCmd.Flags().String("after", "", "command to create the output after the change")
+
+	// flags to control look
+	addLookFlags(compareCmd.Flags())
+	compareCmd.Flags().Bool("stacked", false, "render the windows below each other instead of next to each other")
+
+	// flags for output related settings
+	compareCmd.Flags().StringP("filename", "f", "out.png", "filename of the screenshot")
+	compareCmd.Flags().Bool("no-clobber", false, "fail instead of replacing the file if it already exists")
+}
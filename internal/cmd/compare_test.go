@@ -0,0 +1,54 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Comparing commands", func() {
+	It("should split the arguments at -- and keep each argument as it is", func() {
+		before, after, err := splitCommands([]string{"echo", "a  b;", "--", "echo", "b"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(before).To(Equal([]string{"echo", "a  b;"}))
+		Expect(after).To(Equal([]string{"echo", "b"}))
+	})
+
+	It("should use two arguments as the two commands", func() {
+		before, after, err := splitCommands([]string{"ls -l", "ls -lh"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(before).To(Equal([]string{"ls -l"}))
+		Expect(after).To(Equal([]string{"ls -lh"}))
+	})
+
+	It("should fail if the arguments are not two commands", func() {
+		for _, args := range [][]string{
+			{"ls"},
+			{"ls", "-l", "-h"},
+			{"--", "ls", "-l"},
+			{"ls", "-l", "--"},
+		} {
+			_, _, err := splitCommands(args)
+			Expect(err).To(HaveOccurred(), "%v", args)
+		}
+	})
+})
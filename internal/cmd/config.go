@@ -0,0 +1,351 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultSource is the annotation of flags with a value that is only a
+// default, e.g. of the configuration file, such flags are marked as changed
+// to take effect, the annotation names where the value comes from
+const defaultSource = "termshot-default"
+
+// Sources of default values, where the values of a source take precedence
+// over the ones of the sources listed after it
+const (
+	batchSource    = "batch"
+	presetSource   = "preset"
+	embeddedSource = "embedded"
+	configSource   = "config"
+)
+
+var defaultSources = []string{batchSource, presetSource, embeddedSource, configSource}
+
+// deterministicTime is used instead of the current time for the filename
+// template when the output is deterministic
+var deterministicTime = time.Unix(0, 0).UTC()
+
+// defaultConfigFile returns the location of the configuration file that is
+// used if no other file is configured explicitly
+func defaultConfigFile() string {
+	configDir := os.Getenv("XDG_CONFIG_HOME")
+	if configDir == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+
+		configDir = filepath.Join(homeDir, ".config")
+	}
+
+	return filepath.Join(configDir, "termshot", "config.yaml")
+}
+
+// loadConfig reads the configuration file, which maps flag names to their
+// default values, a missing default configuration file is not an error
+func loadConfig(flags *pflag.FlagSet) (map[string]any, error) {
+	filename, _ := flags.GetString("config")
+
+	explicit := filename != ""
+	if !explicit {
+		// The configuration of the user is not used for deterministic output
+		if deterministic, err := flags.GetBool("deterministic"); err == nil && deterministic {
+			return nil, nil
+		}
+
+		filename = defaultConfigFile()
+	}
+
+	data, err := os.ReadFile(filepath.Clean(filename))
+	switch {
+	case err == nil:
+	case !explicit && errors.Is(err, os.ErrNotExist):
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var config map[string]any
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", filename, err)
+	}
+
+	for name := range config {
+		if !knownFlag(rootCmd, name) || name == "config" {
+			return nil, fmt.Errorf("unknown setting %q in config file %s", name, filename)
+		}
+	}
+
+	return config, nil
+}
+
+// knownFlag returns whether the command or any of its sub-commands has a flag
+// with the name, since the configuration file is shared by all commands
+func knownFlag(cmd *cobra.Command, name string) bool {
+	if cmd.Flags().Lookup(name) != nil || cmd.PersistentFlags().Lookup(name) != nil {
+		return true
+	}
+
+	for _, sub := range cmd.Commands() {
+		if knownFlag(sub, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// explicitFlag returns whether the flag was set on the command line, which
+// is not the case for values that are only defaults
+func explicitFlag(flags *pflag.FlagSet, name string) bool {
+	flag := flags.Lookup(name)
+	return flag != nil && precedence(flag) == len(defaultSources)+1
+}
+
+// explicitFilename returns whether the filename of the output was set on the
+// command line, otherwise files are numbered instead of replaced
+func explicitFilename(flags *pflag.FlagSet) bool {
+	return explicitFlag(flags, "filename") || explicitFlag(flags, "output")
+}
+
+// precedence returns how the value of the flag ranks against values of other
+// sources, flags that are not set have none and explicitly set flags rank
+// above all sources of defaults
+func precedence(flag *pflag.Flag) int {
+	if !flag.Changed {
+		return 0
+	}
+
+	source, ok := flag.Annotations[defaultSource]
+	if !ok || len(source) == 0 {
+		return len(defaultSources) + 1
+	}
+
+	return sourcePrecedence(source[0])
+}
+
+// sourcePrecedence returns how the values of a source of defaults rank
+func sourcePrecedence(source string) int {
+	return len(defaultSources) - slices.Index(defaultSources, source)
+}
+
+// exclusiveFlags returns an error if both flags are set by the same source,
+// otherwise the value with the lower precedence is dropped, so that a theme
+// of the configuration file gives way to a colorscheme on the command line,
+// flags set to an empty value count as not set
+func exclusiveFlags(flags *pflag.FlagSet, first string, second string) error {
+	a, b := flags.Lookup(first), flags.Lookup(second)
+	if a == nil || b == nil || !a.Changed || !b.Changed || a.Value.String() == "" || b.Value.String() == "" {
+		return nil
+	}
+
+	switch {
+	case precedence(a) > precedence(b):
+		return resetFlag(b)
+
+	case precedence(b) > precedence(a):
+		return resetFlag(a)
+	}
+
+	return fmt.Errorf("%s and %s cannot be used together", first, second)
+}
+
+// resetFlag sets the flag back to its default value as if it was never set
+func resetFlag(flag *pflag.Flag) error {
+	if slice, ok := flag.Value.(pflag.SliceValue); ok {
+		if err := slice.Replace(nil); err != nil {
+			return err
+		}
+
+	} else if err := flag.Value.Set(flag.DefValue); err != nil {
+		return err
+	}
+
+	flag.Changed = false
+	delete(flag.Annotations, defaultSource)
+	return nil
+}
+
+// applyConfig sets the flag values of the configuration file for all flags
+// of the command that were not set otherwise, settings for flags of other
+// commands are ignored
+func applyConfig(cmd *cobra.Command) error {
+	config, err := loadConfig(cmd.Flags())
+	if err != nil {
+		return err
+	}
+
+	flags := cmd.Flags()
+	for _, name := range sortedKeys(config) {
+		if err := setDefault(flags, configSource, name, settingValues(config[name])...); err != nil {
+			return fmt.Errorf("invalid value for setting %q in config file: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// setDefault sets the values of the flag unless it was set by a source with
+// the same or a higher precedence, and marks them as defaults of the source
+func setDefault(flags *pflag.FlagSet, source string, name string, values ...string) error {
+	flag := flags.Lookup(name)
+	if flag == nil || precedence(flag) >= sourcePrecedence(source) {
+		return nil
+	}
+
+	if err := setFlagValues(flags, name, values...); err != nil {
+		return err
+	}
+
+	return flags.SetAnnotation(name, defaultSource, []string{source})
+}
+
+// setFlagValue sets the value of a setting read from a YAML file, which is a
+// list of values in case of slice flags, paths are expanded like in a shell
+func setFlagValue(flags *pflag.FlagSet, name string, value any) error {
+	return setFlagValues(flags, name, settingValues(value)...)
+}
+
+// settingValues returns the values of a setting read from a YAML file, with
+// paths expanded like in a shell
+func settingValues(value any) []string {
+	values, ok := value.([]any)
+	if !ok {
+		values = []any{value}
+	}
+
+	var list []string
+	for _, value := range values {
+		list = append(list, expandHome(fmt.Sprint(value)))
+	}
+
+	return list
+}
+
+// setFlagValues sets the flag to the values, which replace the whole list
+// in case of slice flags, the flag counts as explicitly set afterwards
+func setFlagValues(flags *pflag.FlagSet, name string, values ...string) error {
+	flag := flags.Lookup(name)
+	if flag == nil {
+		return fmt.Errorf("unknown flag")
+	}
+
+	delete(flag.Annotations, defaultSource)
+	if slice, ok := flag.Value.(pflag.SliceValue); ok {
+		if err := slice.Replace(values); err != nil {
+			return err
+		}
+
+		flag.Changed = true
+		return nil
+	}
+
+	for _, value := range values {
+		if err := flags.Set(name, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// expandHome replaces a leading tilde with the home directory, since paths
+// in the configuration file are not expanded by a shell
+func expandHome(value string) string {
+	if !strings.HasPrefix(value, "~/") {
+		return value
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return value
+	}
+
+	return filepath.Join(homeDir, value[2:])
+}
+
+// expandFilename replaces the placeholders of the filename template, which
+// are the name of the command and the current date and time
+func expandFilename(filename string, args []string, now time.Time) (string, error) {
+	if !strings.Contains(filename, "{{") {
+		return filename, nil
+	}
+
+	tmpl, err := template.New("filename").Option("missingkey=error").Parse(filename)
+	if err != nil {
+		return "", fmt.Errorf("invalid filename template %q: %w", filename, err)
+	}
+
+	var command string
+	if len(args) > 0 {
+		command = filepath.Base(args[0])
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, map[string]string{
+		"Command": command,
+		"Date":    now.Format("2006-01-02"),
+		"Time":    now.Format("15-04-05"),
+	}); err != nil {
+		return "", fmt.Errorf("invalid filename template %q: %w", filename, err)
+	}
+
+	return buf.String(), nil
+}
+
+// configure applies the configuration file and expands the filename template
+// before any command runs
+func configure(cmd *cobra.Command, args []string) error {
+	if err := applyConfig(cmd); err != nil {
+		return err
+	}
+
+	if flag := cmd.Flags().Lookup("filename"); flag != nil {
+		now := time.Now()
+		if deterministic, err := cmd.Flags().GetBool("deterministic"); err == nil && deterministic {
+			now = deterministicTime
+		}
+
+		filename, err := expandFilename(flag.Value.String(), args, now)
+		if err != nil {
+			return err
+		}
+
+		if err := flag.Value.Set(filename); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
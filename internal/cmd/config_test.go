@@ -0,0 +1,129 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/homeport/termshot/pkg/img"
+)
+
+var _ = Describe("Configuration file", func() {
+	var command = func(config string) *cobra.Command {
+		filename := filepath.Join(GinkgoT().TempDir(), "config.yaml")
+		Expect(os.WriteFile(filename, []byte(config), 0o600)).To(Succeed())
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("config", filename, "")
+		cmd.Flags().StringP("filename", "f", "out.png", "")
+		cmd.Flags().Float64("padding", 0, "")
+		return cmd
+	}
+
+	It("should accept settings for flags of other commands", func() {
+		cmd := command("parallel: 4\npadding: 8\n")
+		Expect(applyConfig(cmd)).To(Succeed())
+		Expect(cmd.Flags().GetFloat64("padding")).To(Equal(8.0))
+	})
+
+	It("should fail for settings that are no flag of any command", func() {
+		Expect(applyConfig(command("unknown: 4\n"))).To(MatchError(ContainSubstring(`unknown setting "unknown"`)))
+	})
+
+	It("should use the filename of the configuration only as a default", func() {
+		cmd := command("filename: shot.png\n")
+		Expect(applyConfig(cmd)).To(Succeed())
+		Expect(cmd.Flags().GetString("filename")).To(Equal("shot.png"))
+		Expect(explicitFilename(cmd.Flags())).To(BeFalse())
+
+		cmd = command("filename: shot.png\n")
+		Expect(cmd.Flags().Parse([]string{"-f", "other.png"})).To(Succeed())
+		Expect(applyConfig(cmd)).To(Succeed())
+		Expect(cmd.Flags().GetString("filename")).To(Equal("other.png"))
+		Expect(explicitFilename(cmd.Flags())).To(BeTrue())
+	})
+
+	Context("precedence of flags", func() {
+		var lookCommand = func(config string, args ...string) *cobra.Command {
+			filename := filepath.Join(GinkgoT().TempDir(), "config.yaml")
+			Expect(os.WriteFile(filename, []byte(config), 0o600)).To(Succeed())
+
+			cmd := &cobra.Command{}
+			cmd.Flags().String("config", filename, "")
+			addLookFlags(cmd.Flags())
+			Expect(cmd.Flags().Parse(args)).To(Succeed())
+			Expect(applyConfig(cmd)).To(Succeed())
+			return cmd
+		}
+
+		It("should use a colorscheme of the command line instead of the theme of the configuration", func() {
+			colorscheme := filepath.Join(GinkgoT().TempDir(), "cs.json")
+			Expect(os.WriteFile(colorscheme, []byte(`{"colors": {"color1": "#ff0000"}}`), 0o600)).To(Succeed())
+
+			cmd := lookCommand("theme: nord\n", "--colorscheme", colorscheme)
+			scaffold := img.NewImageCreator()
+			Expect(applyLookFlags(cmd.Flags(), &scaffold)).To(Succeed())
+			Expect(cmd.Flags().GetString("theme")).To(BeEmpty())
+			Expect(cmd.Flags().Changed("theme")).To(BeFalse())
+		})
+
+		It("should use a resolution of the command line instead of the scale of the configuration", func() {
+			cmd := lookCommand("scale: 1\n", "--dpi", "192")
+			scaffold := img.NewImageCreator()
+			Expect(applyLookFlags(cmd.Flags(), &scaffold)).To(Succeed())
+			Expect(cmd.Flags().Changed("scale")).To(BeFalse())
+			Expect(cmd.Flags().GetFloat64("dpi")).To(Equal(192.0))
+		})
+
+		It("should ignore flags that are set to an empty value", func() {
+			cmd := lookCommand("", "--theme", "nord", "--colorscheme", "")
+			scaffold := img.NewImageCreator()
+			Expect(applyLookFlags(cmd.Flags(), &scaffold)).To(Succeed())
+			Expect(cmd.Flags().GetString("theme")).To(Equal("nord"))
+		})
+
+		It("should fail for conflicting flags of the same source", func() {
+			scaffold := img.NewImageCreator()
+			Expect(applyLookFlags(lookCommand("", "--scale", "1", "--dpi", "192").Flags(), &scaffold)).To(MatchError("scale and dpi cannot be used together"))
+			Expect(applyLookFlags(lookCommand("scale: 1\ndpi: 192\n").Flags(), &scaffold)).To(MatchError("scale and dpi cannot be used together"))
+		})
+
+		It("should use the values of a preset instead of the values of the configuration", func() {
+			cmd := lookCommand("no-shadow: false\npadding: 4\n", "--preset", "compact")
+			Expect(applyPreset(cmd.Flags(), "compact")).To(Succeed())
+			Expect(cmd.Flags().GetBool("no-shadow")).To(BeTrue())
+			Expect(cmd.Flags().GetString("padding")).To(Equal("8"))
+			Expect(explicitFlag(cmd.Flags(), "padding")).To(BeFalse())
+		})
+
+		It("should use explicitly set flags instead of the values of a preset", func() {
+			cmd := lookCommand("padding: 4\n", "--preset", "compact", "--padding", "16")
+			Expect(applyPreset(cmd.Flags(), "compact")).To(Succeed())
+			Expect(cmd.Flags().GetString("padding")).To(Equal("16"))
+			Expect(cmd.Flags().GetString("margin")).To(Equal("8"))
+		})
+	})
+})
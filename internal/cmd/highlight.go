@@ -0,0 +1,266 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"image/color"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+	"github.com/spf13/cobra"
+
+	"github.com/homeport/termshot/pkg/img"
+)
+
+var highlightCmd = &cobra.Command{
+	Use:   "highlight [flags] file",
+	Short: "Creates a screenshot of a source code file with syntax highlighting",
+	Long: `Reads the source code file, highlights its syntax, and renders it into
+a screenshot the same way as the output of a command. The language is
+detected based on the filename or the content, unless it is provided with
+the language flag. Use - as filename to read the source code from stdin.
+`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if list, err := cmd.Flags().GetBool("list-styles"); err == nil && list {
+			for _, name := range styles.Names() {
+				_, _ = fmt.Fprintln(cmd.OutOrStdout(), name)
+			}
+
+			return nil
+		}
+
+		if len(args) == 0 {
+			return cmd.Usage()
+		}
+
+		source, err := readFile(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to read source code: %w", err)
+		}
+
+		// Tabs are expanded, so that the indentation does not depend on the
+		// position of the tab and all lines can be fitted into the window
+		tabWidth, _ := cmd.Flags().GetInt("tab-width")
+		source = expandTabs(source, tabWidth)
+
+		language, _ := cmd.Flags().GetString("language")
+		lexer, err := sourceLexer(args[0], language, source)
+		if err != nil {
+			return err
+		}
+
+		name, _ := cmd.Flags().GetString("style")
+		style, ok := styles.Registry[name]
+		if !ok {
+			return fmt.Errorf("unknown style %q, use --list-styles to list all styles", name)
+		}
+
+		content, err := highlightSource(lexer, style, source)
+		if err != nil {
+			return err
+		}
+
+		// The colors of the style are applied first, so that an explicitly
+		// configured color scheme or theme takes precedence
+		scaffold := img.NewImageCreator()
+		if entry := style.Get(chroma.Background); entry.Background.IsSet() {
+			scaffold.SetBackgroundColor(chromaColor(entry.Background))
+		}
+
+		if entry := style.Get(chroma.Text); entry.Colour.IsSet() {
+			scaffold.SetForegroundColor(chromaColor(entry.Colour))
+		} else if entry := style.Get(chroma.Background); entry.Colour.IsSet() {
+			scaffold.SetForegroundColor(chromaColor(entry.Colour))
+		} else if entry.Background.IsSet() && entry.Background.Brightness() > 0.5 {
+			scaffold.SetForegroundColor(color.Black)
+		}
+
+		// Source code is not wrapped, unless the number of columns is set
+		scaffold.SetColumns(longestLine(source))
+
+		if err := applyLookFlags(cmd.Flags(), &scaffold); err != nil {
+			return err
+		}
+
+		if args[0] != "-" {
+			scaffold.SetTitle(filepath.Base(args[0]))
+		}
+
+		if err := scaffold.AddContent(bytes.NewReader(content)); err != nil {
+			return err
+		}
+
+		file, err := createOutputFile(cmd.Flags(), sortedKeys(imageWriters)...)
+		if err != nil {
+			return err
+		}
+
+		defer func() { _ = file.Close() }()
+
+		return imageWriters[strings.ToLower(filepath.Ext(file.Name()))](&scaffold, file, cmd.Flags())
+	},
+}
+
+// sourceLexer returns the lexer for the language, or the lexer that matches
+// the filename or content best if no language is provided
+func sourceLexer(filename string, language string, source []byte) (chroma.Lexer, error) {
+	var lexer chroma.Lexer
+	switch {
+	case language != "":
+		if lexer = lexers.Get(language); lexer == nil {
+			return nil, fmt.Errorf("unknown language %q", language)
+		}
+
+	default:
+		if lexer = lexers.Match(filepath.Base(filename)); lexer == nil {
+			if lexer = lexers.Analyse(string(source)); lexer == nil {
+				lexer = lexers.Fallback
+			}
+		}
+	}
+
+	return chroma.Coalesce(lexer), nil
+}
+
+// highlightSource renders the source code as text with ANSI escape sequences,
+// where only the text color and attributes of the style are used so that the
+// window background shows through
+func highlightSource(lexer chroma.Lexer, style *chroma.Style, source []byte) ([]byte, error) {
+	iterator, err := lexer.Tokenise(nil, string(source))
+	if err != nil {
+		return nil, fmt.Errorf("failed to highlight source code: %w", err)
+	}
+
+	var buf bytes.Buffer
+	for _, token := range iterator.Tokens() {
+		entry := style.Get(token.Type)
+
+		var params []string
+		if entry.Bold == chroma.Yes {
+			params = append(params, "1")
+		}
+
+		if entry.Italic == chroma.Yes {
+			params = append(params, "3")
+		}
+
+		if entry.Underline == chroma.Yes {
+			params = append(params, "4")
+		}
+
+		if entry.Colour.IsSet() {
+			params = append(params, fmt.Sprintf("38;2;%d;%d;%d", entry.Colour.Red(), entry.Colour.Green(), entry.Colour.Blue()))
+		}
+
+		if len(params) == 0 {
+			buf.WriteString(token.Value)
+			continue
+		}
+
+		// Escape sequences are repeated for each line, so that they do not
+		// depend on the state of previous lines
+		for i, line := range strings.Split(token.Value, "\n") {
+			if i > 0 {
+				buf.WriteString("\n")
+			}
+
+			if line != "" {
+				fmt.Fprintf(&buf, "\x1b[%sm%s\x1b[0m", strings.Join(params, ";"), line)
+			}
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// expandTabs replaces all tabs with spaces up to the next tab stop
+func expandTabs(source []byte, tabWidth int) []byte {
+	if tabWidth <= 0 {
+		return source
+	}
+
+	var buf bytes.Buffer
+	var column int
+	for _, r := range string(source) {
+		switch r {
+		case '\t':
+			spaces := tabWidth - column%tabWidth
+			buf.WriteString(strings.Repeat(" ", spaces))
+			column += spaces
+
+		case '\n':
+			buf.WriteRune(r)
+			column = 0
+
+		default:
+			buf.WriteRune(r)
+			column++
+		}
+	}
+
+	return buf.Bytes()
+}
+
+// longestLine returns the number of characters of the longest line
+func longestLine(source []byte) int {
+	var longest int
+	for _, line := range strings.Split(string(source), "\n") {
+		longest = max(longest, utf8.RuneCountInString(line))
+	}
+
+	return longest
+}
+
+func chromaColor(c chroma.Colour) color.Color {
+	return color.RGBA{R: c.Red(), G: c.Green(), B: c.Blue(), A: 255}
+}
+
+func init() {
+	rootCmd.AddCommand(highlightCmd)
+
+	highlightCmd.Flags().SortFlags = false
+
+	// flags to control content
+	highlightCmd.Flags().String("language", "", "language of the source code (default is detected based on filename or content)")
+	highlightCmd.Flags().String("style", "monokai", "style of the syntax highlighting")
+	highlightCmd.Flags().Bool("list-styles", false, "list all styles of the syntax highlighting")
+
+	// flags to control look
+	addLookFlags(highlightCmd.Flags())
+
+	// Source code is usually indented with narrower tabs than terminal output
+	tabWidth := highlightCmd.Flags().Lookup("tab-width")
+	tabWidth.DefValue = "4"
+	_ = tabWidth.Value.Set("4")
+
+	// flags for output related settings
+	highlightCmd.Flags().StringP("filename", "f", "out.png", "filename of the screenshot")
+	highlightCmd.Flags().Bool("no-clobber", false, "fail instead of replacing the file if it already exists")
+	highlightCmd.Flags().Int("quality", 0, "quality of JPEG and WebP images from 1 to 100 (default is 90 for JPEG and all colors for WebP)")
+	highlightCmd.Flags().Int("page-lines", 0, "number of lines per page of PDF documents (default is all lines on one page)")
+}
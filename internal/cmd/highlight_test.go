@@ -0,0 +1,104 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/styles"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/homeport/termshot/pkg/img"
+)
+
+var _ = Describe("Highlighting source code", func() {
+	type cell struct {
+		Rune  string   `json:"rune"`
+		FG    string   `json:"fg"`
+		Attrs []string `json:"attrs"`
+	}
+
+	// cells highlights the source code and returns the cells of the screen
+	var cells = func(language string, style string, source string) [][]cell {
+		lexer, err := sourceLexer("", language, []byte(source))
+		Expect(err).ToNot(HaveOccurred())
+
+		content, err := highlightSource(lexer, styles.Get(style), []byte(source))
+		Expect(err).ToNot(HaveOccurred())
+
+		scaffold := img.NewImageCreator()
+		Expect(scaffold.AddContent(bytes.NewReader(content))).To(Succeed())
+
+		var buf bytes.Buffer
+		Expect(scaffold.WriteCells(&buf)).To(Succeed())
+
+		var result struct {
+			Lines [][]cell `json:"lines"`
+		}
+
+		Expect(json.Unmarshal(buf.Bytes(), &result)).To(Succeed())
+		return result.Lines
+	}
+
+	var hex = func(style string, token chroma.TokenType) string {
+		return img.HexColor(chromaColor(styles.Get(style).Get(token).Colour))
+	}
+
+	It("should use the colors and attributes of the style for the tokens", func() {
+		lines := cells("go", "github", "package main\n")
+		Expect(lines).To(HaveLen(1))
+
+		for _, c := range lines[0][:len("package")] {
+			Expect(c.FG).To(Equal(hex("github", chroma.KeywordNamespace)))
+			Expect(c.Attrs).To(ContainElement("bold"))
+		}
+
+		Expect(lines[0][len("package")].Rune).To(Equal(" "))
+		Expect(lines[0][len("package ")].FG).ToNot(Equal(hex("github", chroma.KeywordNamespace)))
+	})
+
+	It("should color every line of tokens that span multiple lines", func() {
+		lines := cells("go", "monokai", "/* one\ntwo */\n")
+		Expect(lines).To(HaveLen(2))
+
+		for _, line := range lines {
+			for _, c := range line {
+				if strings.TrimSpace(c.Rune) != "" {
+					Expect(c.FG).To(Equal(hex("monokai", chroma.CommentMultiline)))
+				}
+			}
+		}
+	})
+
+	It("should fail for unknown languages", func() {
+		_, err := sourceLexer("main.go", "klingon", nil)
+		Expect(err).To(MatchError(`unknown language "klingon"`))
+	})
+
+	It("should expand tabs to the next tab stop", func() {
+		Expect(string(expandTabs([]byte("\tfoo\n ab\tc"), 4))).To(Equal("    foo\n ab c"))
+		Expect(longestLine([]byte("foo\nfoobar\n"))).To(Equal(6))
+	})
+})
@@ -0,0 +1,74 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	"github.com/homeport/termshot/pkg/img"
+)
+
+// runHook runs the command of the hook flag using the shell, where the
+// metadata of the screenshot is passed as environment variables
+func runHook(flags *pflag.FlagSet, name string, metadata map[string]string) error {
+	hook, err := flags.GetString(name)
+	if err != nil || hook == "" {
+		return nil
+	}
+
+	cmd := exec.Command("/bin/sh", "-c", hook)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = os.Environ()
+	for _, key := range sortedKeys(metadata) {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("TERMSHOT_%s=%s", key, metadata[key]))
+	}
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s command failed: %w", name, err)
+	}
+
+	return nil
+}
+
+// screenshotMetadata returns the metadata of the written screenshot for the
+// post hook, the exit code is only included if a command was run
+func screenshotMetadata(filename string, args []string, scaffold *img.Scaffold, result session) map[string]string {
+	metadata := map[string]string{
+		"OUTPUT":  filename,
+		"FORMAT":  strings.TrimPrefix(strings.ToLower(filepath.Ext(filename)), "."),
+		"COMMAND": strings.Join(args, " "),
+		"LINES":   strconv.Itoa(len(scaffold.Lines())),
+	}
+
+	if result.attempts > 0 {
+		metadata["EXIT_CODE"] = strconv.Itoa(result.exitCode)
+	}
+
+	return metadata
+}
@@ -0,0 +1,119 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/pflag"
+
+	"github.com/homeport/termshot/internal/ptexec"
+	"github.com/homeport/termshot/pkg/img"
+)
+
+var _ = Describe("Hooks", func() {
+	var dir string
+
+	var hookFlags = func(args ...string) *pflag.FlagSet {
+		flags := pflag.NewFlagSet("termshot", pflag.ContinueOnError)
+		flags.String("pre-hook", "", "")
+		flags.String("post-hook", "", "")
+		Expect(flags.Parse(args)).To(Succeed())
+		return flags
+	}
+
+	// environment returns the variables of the termshot environment that
+	// the hook wrote to the file
+	var environment = func() map[string]string {
+		data, err := os.ReadFile(filepath.Join(dir, "env"))
+		Expect(err).ToNot(HaveOccurred())
+
+		env := map[string]string{}
+		for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+			if key, value, ok := strings.Cut(line, "="); ok && strings.HasPrefix(key, "TERMSHOT_") {
+				env[key] = value
+			}
+		}
+
+		return env
+	}
+
+	var writeEnv string
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		writeEnv = "env > " + filepath.Join(dir, "env")
+	})
+
+	It("should pass the metadata of the screenshot to the post hook", func() {
+		args := []string{"sh", "-c", "echo one; exit 3", "++", "echo", "two"}
+		commands, err := sessionCommands(args)
+		Expect(err).ToNot(HaveOccurred())
+
+		var buf bytes.Buffer
+		scaffold := img.NewImageCreator()
+		result, err := runSession(ptexec.New().Stdin(nil).Stdout(io.Discard), &scaffold, &buf, commands, 0, 0, false)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(scaffold.AddContent(&buf)).To(Succeed())
+
+		metadata := screenshotMetadata(filepath.Join(dir, "shot.SVG"), args, &scaffold, result)
+		Expect(runHook(hookFlags("--post-hook", writeEnv), "post-hook", metadata)).To(Succeed())
+		Expect(environment()).To(Equal(map[string]string{
+			"TERMSHOT_OUTPUT":    filepath.Join(dir, "shot.SVG"),
+			"TERMSHOT_FORMAT":    "svg",
+			"TERMSHOT_COMMAND":   "sh -c echo one; exit 3 ++ echo two",
+			"TERMSHOT_LINES":     "4",
+			"TERMSHOT_EXIT_CODE": "3",
+		}))
+	})
+
+	It("should not pass an exit code if no command was run", func() {
+		scaffold := img.NewImageCreator()
+		Expect(scaffold.AddContent(strings.NewReader("foobar\n"))).To(Succeed())
+
+		metadata := screenshotMetadata(filepath.Join(dir, "shot.png"), nil, &scaffold, session{})
+		Expect(runHook(hookFlags("--post-hook", writeEnv), "post-hook", metadata)).To(Succeed())
+		Expect(environment()).ToNot(HaveKey("TERMSHOT_EXIT_CODE"))
+		Expect(environment()).To(HaveKeyWithValue("TERMSHOT_LINES", "1"))
+	})
+
+	It("should pass the command to the pre hook", func() {
+		flags := hookFlags("--pre-hook", writeEnv, "--post-hook", "exit 1")
+		Expect(runHook(flags, "pre-hook", map[string]string{"COMMAND": "ls -a"})).To(Succeed())
+		Expect(environment()).To(Equal(map[string]string{"TERMSHOT_COMMAND": "ls -a"}))
+	})
+
+	It("should fail if the hook fails", func() {
+		err := runHook(hookFlags("--post-hook", "exit 2"), "post-hook", nil)
+		Expect(err).To(MatchError(ContainSubstring("post-hook command failed: exit status 2")))
+	})
+
+	It("should do nothing without a hook", func() {
+		Expect(runHook(hookFlags(), "post-hook", nil)).To(Succeed())
+		Expect(filepath.Join(dir, "env")).ToNot(BeAnExistingFile())
+	})
+})
@@ -0,0 +1,386 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gonvenience/bunt"
+
+	"github.com/homeport/termshot/pkg/img"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+// preset is a named set of flag values that control the look of the screenshot
+type preset map[string]string
+
+// presets contains all presets that can be used by name
+var presets = map[string]preset{
+	"default": {},
+
+	"flat": {
+		"no-shadow": "true",
+	},
+
+	"borderless": {
+		"no-border": "true",
+	},
+
+	"minimal": {
+		"no-decoration": "true",
+		"no-shadow":     "true",
+		"clip-canvas":   "true",
+	},
+
+	"compact": {
+		"padding":   "8",
+		"margin":    "8",
+		"no-shadow": "true",
+	},
+
+	"spacious": {
+		"padding": "48",
+		"margin":  "64",
+	},
+}
+
+// sampleContent is used to preview presets in case no other content is provided
+const sampleContent = "Text with emphasis, like \x1b[1mbold\x1b[0m, \x1b[3mitalic\x1b[0m, or \x1b[4munderline\x1b[0m.\n" +
+	"\n" +
+	"Colors: \x1b[31mred\x1b[0m \x1b[32mgreen\x1b[0m \x1b[33myellow\x1b[0m \x1b[34mblue\x1b[0m \x1b[35mmagenta\x1b[0m \x1b[36mcyan\x1b[0m\n"
+
+var presetsCmd = &cobra.Command{
+	Use:   "presets",
+	Short: "Lists and previews presets with predefined look settings",
+	Long: `Presets are named sets of settings that control the look of the screenshot.
+Use the preset flag to apply a preset, all flags that are explicitly set
+take precedence over the settings of the preset.
+`,
+	Args: cobra.NoArgs,
+}
+
+var presetsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists all available presets and their settings",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		names, err := presetNames()
+		if err != nil {
+			return err
+		}
+
+		for _, name := range names {
+			var settings []string
+			preset, _, err := lookupPreset(name)
+			if err != nil {
+				return err
+			}
+
+			for _, flag := range sortedKeys(preset) {
+				settings = append(settings, fmt.Sprintf("--%s=%s", flag, preset[flag]))
+			}
+
+			_, _ = bunt.Fprintf(cmd.OutOrStdout(), "Lime{%s} DimGray{%s}\n", name, strings.Join(settings, " "))
+		}
+
+		return nil
+	},
+}
+
+var presetsPreviewCmd = &cobra.Command{
+	Use:   "preview",
+	Short: "Renders a contact sheet with the same content in all presets",
+	Long: `Renders the same content using every available preset and combines all
+screenshots into one image, so that the presets can be compared visually.
+By default, a sample content is used, use the raw-read flag to use the
+content of a file instead.
+`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		content := []byte(sampleContent)
+		if rawRead, err := cmd.Flags().GetString("raw-read"); err == nil && rawRead != "" {
+			if content, err = readFile(rawRead); err != nil {
+				return fmt.Errorf("failed to read contents: %w", err)
+			}
+		}
+
+		names, err := presetNames()
+		if err != nil {
+			return err
+		}
+
+		var images []image.Image
+		for _, name := range names {
+			image, err := renderVariant(cmd.Flags(), name, content, map[string]string{"preset": name})
+			if err != nil {
+				return err
+			}
+
+			images = append(images, image)
+		}
+
+		columns, _ := cmd.Flags().GetInt("grid-columns")
+
+		file, err := createOutputFile(cmd.Flags())
+		if err != nil {
+			return err
+		}
+
+		defer func() { _ = file.Close() }()
+		return png.Encode(file, img.Grid(columns, images...))
+	},
+}
+
+// renderVariant renders the content with a title using the look settings of
+// the provided flags, where the given flag values take precedence
+func renderVariant(flags *pflag.FlagSet, title string, content []byte, values map[string]string) (image.Image, error) {
+	variant := pflag.NewFlagSet(title, pflag.ContinueOnError)
+	addLookFlags(variant)
+
+	if err := copyChangedFlags(variant, flags); err != nil {
+		return nil, err
+	}
+
+	for flag, value := range values {
+		if err := setFlagValues(variant, flag, value); err != nil {
+			return nil, err
+		}
+	}
+
+	scaffold := img.NewImageCreator()
+	if err := applyLookFlags(variant, &scaffold); err != nil {
+		return nil, err
+	}
+
+	scaffold.SetTitle(title)
+	if err := scaffold.AddContent(bytes.NewReader(content)); err != nil {
+		return nil, err
+	}
+
+	return scaffold.Image()
+}
+
+// applyPreset sets the flag values of the preset with the given name for all
+// flags that were not explicitly set, values of the configuration file or of
+// an embedded screenshot are replaced
+func applyPreset(flags *pflag.FlagSet, name string) error {
+	preset, ok, err := lookupPreset(name)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		names, err := presetNames()
+		if err != nil {
+			return err
+		}
+
+		return fmt.Errorf("unknown preset %q, available presets are: %s", name, strings.Join(names, ", "))
+	}
+
+	for flag, value := range preset {
+		// Values of slice flags are comma separated like on the command line
+		values := []string{value}
+		if f := flags.Lookup(flag); f != nil && f.Value.Type() == "stringSlice" {
+			values = strings.Split(value, ",")
+		}
+
+		if err := setDefault(flags, presetSource, flag, values...); err != nil {
+			return fmt.Errorf("invalid value %q for flag %q in preset %q: %w", value, flag, name, err)
+		}
+	}
+
+	return nil
+}
+
+// copyChangedFlags copies the values of all set flags from the source flag
+// set to the destination flag set, if it has the same flag, values that are
+// only defaults stay defaults of the same source
+func copyChangedFlags(dst *pflag.FlagSet, src *pflag.FlagSet) error {
+	var err error
+	src.VisitAll(func(flag *pflag.Flag) {
+		target := dst.Lookup(flag.Name)
+		if !flag.Changed || target == nil || err != nil {
+			return
+		}
+
+		values := []string{flag.Value.String()}
+		if slice, ok := flag.Value.(pflag.SliceValue); ok {
+			values = slice.GetSlice()
+		}
+
+		if err = setFlagValues(dst, flag.Name, values...); err != nil {
+			return
+		}
+
+		if source, ok := flag.Annotations[defaultSource]; ok {
+			err = dst.SetAnnotation(flag.Name, defaultSource, source)
+		}
+	})
+
+	return err
+}
+
+// userPresetsFile returns the location of the file with the presets saved
+// by the user, which is next to the configuration file
+func userPresetsFile() string {
+	configFile := defaultConfigFile()
+	if configFile == "" {
+		return ""
+	}
+
+	return filepath.Join(filepath.Dir(configFile), "presets.yaml")
+}
+
+// loadUserPresets reads the presets saved by the user, a missing file means
+// that there are no user presets
+func loadUserPresets() (map[string]preset, error) {
+	filename := userPresetsFile()
+	if filename == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(filepath.Clean(filename))
+	switch {
+	case err == nil:
+	case errors.Is(err, os.ErrNotExist):
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("failed to read presets file: %w", err)
+	}
+
+	var userPresets map[string]preset
+	if err := yaml.Unmarshal(data, &userPresets); err != nil {
+		return nil, fmt.Errorf("failed to parse presets file %s: %w", filename, err)
+	}
+
+	return userPresets, nil
+}
+
+// saveUserPreset adds the preset to the presets saved by the user, where a
+// preset with the same name is replaced, built-in presets cannot be replaced
+func saveUserPreset(name string, settings preset) error {
+	if _, ok := presets[name]; ok {
+		return fmt.Errorf("preset %q is built-in and cannot be replaced", name)
+	}
+
+	userPresets, err := loadUserPresets()
+	if err != nil {
+		return err
+	}
+
+	if userPresets == nil {
+		userPresets = map[string]preset{}
+	}
+
+	userPresets[name] = settings
+
+	data, err := yaml.Marshal(userPresets)
+	if err != nil {
+		return err
+	}
+
+	filename := userPresetsFile()
+	if err := os.MkdirAll(filepath.Dir(filename), os.FileMode(0o755)); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	return os.WriteFile(filename, data, os.FileMode(0o644))
+}
+
+// lookupPreset returns the built-in preset or the preset saved by the user
+// with the given name
+func lookupPreset(name string) (preset, bool, error) {
+	if preset, ok := presets[name]; ok {
+		return preset, true, nil
+	}
+
+	userPresets, err := loadUserPresets()
+	if err != nil {
+		return nil, false, err
+	}
+
+	preset, ok := userPresets[name]
+	return preset, ok, nil
+}
+
+// presetNames returns the names of the built-in presets and the presets
+// saved by the user
+func presetNames() ([]string, error) {
+	userPresets, err := loadUserPresets()
+	if err != nil {
+		return nil, err
+	}
+
+	names := sortedKeys(presets)
+	for _, name := range sortedKeys(userPresets) {
+		if _, ok := presets[name]; !ok {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+	return names, nil
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+func init() {
+	rootCmd.AddCommand(presetsCmd)
+	presetsCmd.AddCommand(presetsListCmd)
+	presetsCmd.AddCommand(presetsPreviewCmd)
+
+	presetsPreviewCmd.Flags().SortFlags = false
+	addPresetsPreviewFlags(presetsPreviewCmd.Flags())
+}
+
+// addPresetsPreviewFlags adds the flags of the presets preview command
+func addPresetsPreviewFlags(flags *pflag.FlagSet) {
+	// flags to control look
+	addLookFlags(flags)
+	flags.Int("grid-columns", 3, "number of screenshots per row in the contact sheet")
+
+	// flags for output related settings
+	flags.StringP("filename", "f", "out.png", "filename of the screenshot")
+	flags.Bool("no-clobber", false, "fail instead of replacing the file if it already exists")
+
+	// flags for raw output processing
+	flags.String("raw-read", "", "read raw input from file instead of using sample content")
+}
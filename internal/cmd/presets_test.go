@@ -0,0 +1,167 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/homeport/termshot/pkg/img"
+)
+
+var _ = Describe("Presets", func() {
+	var configDir string
+
+	var lookFlags = func(args ...string) *pflag.FlagSet {
+		flags := pflag.NewFlagSet("termshot", pflag.ContinueOnError)
+		addLookFlags(flags)
+		Expect(flags.Parse(args)).To(Succeed())
+		return flags
+	}
+
+	BeforeEach(func() {
+		configDir = GinkgoT().TempDir()
+		GinkgoT().Setenv("XDG_CONFIG_HOME", configDir)
+
+		Expect(os.MkdirAll(filepath.Join(configDir, "termshot"), 0o755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(configDir, "termshot", "presets.yaml"), []byte(`
+mine:
+  padding: "2"
+  redact: "[0-9]{1,3}"
+flat:
+  no-border: "true"
+`), 0o600)).To(Succeed())
+	})
+
+	Context("lookup", func() {
+		It("should find built-in presets before the presets of the user", func() {
+			preset, ok, err := lookupPreset("flat")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ok).To(BeTrue())
+			Expect(preset).To(Equal(presets["flat"]))
+
+			preset, ok, err = lookupPreset("mine")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ok).To(BeTrue())
+			Expect(preset).To(HaveKeyWithValue("padding", "2"))
+
+			_, ok, err = lookupPreset("unknown")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should list the names of all presets once", func() {
+			Expect(presetNames()).To(Equal([]string{"borderless", "compact", "default", "flat", "mine", "minimal", "spacious"}))
+		})
+
+		It("should fail for an unknown preset with the available presets", func() {
+			Expect(applyPreset(lookFlags(), "unknown")).To(MatchError(`unknown preset "unknown", available presets are: borderless, compact, default, flat, mine, minimal, spacious`))
+		})
+	})
+
+	Context("precedence", func() {
+		It("should use explicitly set flags instead of the values of the preset", func() {
+			flags := lookFlags("--padding", "16")
+			Expect(applyPreset(flags, "compact")).To(Succeed())
+			Expect(flags.GetString("padding")).To(Equal("16"))
+			Expect(flags.GetString("margin")).To(Equal("8"))
+			Expect(flags.GetBool("no-shadow")).To(BeTrue())
+
+			Expect(explicitFlag(flags, "padding")).To(BeTrue())
+			Expect(explicitFlag(flags, "margin")).To(BeFalse())
+		})
+
+		It("should split comma separated values of slice flags", func() {
+			Expect(os.WriteFile(filepath.Join(configDir, "termshot", "presets.yaml"), []byte("ligatures:\n  font-features: liga,calt\n"), 0o600)).To(Succeed())
+
+			flags := lookFlags()
+			Expect(applyPreset(flags, "ligatures")).To(Succeed())
+			Expect(flags.GetStringSlice("font-features")).To(Equal([]string{"liga", "calt"}))
+		})
+
+		It("should not split values of repeatable flags", func() {
+			flags := lookFlags()
+			Expect(applyPreset(flags, "mine")).To(Succeed())
+			Expect(flags.GetStringArray("redact")).To(Equal([]string{"[0-9]{1,3}"}))
+		})
+
+		It("should keep the values of a preset as defaults when copying flags", func() {
+			flags := lookFlags("--theme", "nord")
+			Expect(applyPreset(flags, "compact")).To(Succeed())
+
+			copied := lookFlags()
+			Expect(copyChangedFlags(copied, flags)).To(Succeed())
+			Expect(copied.GetString("margin")).To(Equal("8"))
+			Expect(explicitFlag(copied, "margin")).To(BeFalse())
+			Expect(explicitFlag(copied, "theme")).To(BeTrue())
+		})
+	})
+
+	Context("contact sheet", func() {
+		It("should render the content in all presets into one image", func() {
+			filename := filepath.Join(GinkgoT().TempDir(), "sheet.png")
+
+			cmd := &cobra.Command{RunE: presetsPreviewCmd.RunE, SilenceUsage: true, SilenceErrors: true}
+			addPresetsPreviewFlags(cmd.Flags())
+			cmd.SetArgs([]string{"--grid-columns", "4", "--margin", "0", "--filename", filename})
+			Expect(cmd.Execute()).To(Succeed())
+
+			var images []image.Image
+			for _, name := range []string{"borderless", "compact", "default", "flat", "mine", "minimal", "spacious"} {
+				image, err := renderVariant(lookFlags("--margin", "0"), name, []byte(sampleContent), map[string]string{"preset": name})
+				Expect(err).ToNot(HaveOccurred())
+				images = append(images, image)
+			}
+
+			file, err := os.Open(filename)
+			Expect(err).ToNot(HaveOccurred())
+			defer func() { _ = file.Close() }()
+
+			sheet, err := png.Decode(file)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sheet.Bounds()).To(Equal(img.Grid(4, images...).Bounds()))
+		})
+
+		It("should keep explicitly set flags in all variants", func() {
+			spacious, err := renderVariant(lookFlags("--margin", "0", "--padding", "0"), "spacious", []byte(sampleContent), map[string]string{"preset": "spacious"})
+			Expect(err).ToNot(HaveOccurred())
+
+			standard, err := renderVariant(lookFlags("--margin", "0", "--padding", "0"), "spacious", []byte(sampleContent), map[string]string{"preset": "default"})
+			Expect(err).ToNot(HaveOccurred())
+
+			compact, err := renderVariant(lookFlags("--margin", "0", "--padding", "0", "--no-shadow"), "compact", []byte(sampleContent), map[string]string{"preset": "compact"})
+			Expect(err).ToNot(HaveOccurred())
+
+			flat, err := renderVariant(lookFlags("--margin", "0", "--padding", "0", "--no-shadow"), "flat", []byte(sampleContent), map[string]string{"preset": "flat"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(compact.Bounds()).To(Equal(flat.Bounds()))
+			Expect(spacious.Bounds()).To(Equal(standard.Bounds()))
+		})
+	})
+})
@@ -0,0 +1,258 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color/palette"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"golang.org/x/image/draw"
+
+	"github.com/homeport/termshot/pkg/img"
+)
+
+// Supported protocols to display images inline in the terminal
+const (
+	previewKitty = "kitty"
+	previewITerm = "iterm"
+	previewSixel = "sixel"
+)
+
+// detectPreviewProtocol returns the protocol to display images that the
+// terminal supports based on its environment variables, or an empty string
+// if the terminal is not known to support inline images
+func detectPreviewProtocol() string {
+	term, program := os.Getenv("TERM"), os.Getenv("TERM_PROGRAM")
+
+	switch {
+	case os.Getenv("KITTY_WINDOW_ID") != "" || term == "xterm-kitty" || term == "xterm-ghostty" || program == "ghostty":
+		return previewKitty
+
+	case os.Getenv("ITERM_SESSION_ID") != "" || program == "iTerm.app" || program == "WezTerm":
+		return previewITerm
+
+	case strings.Contains(term, "sixel") || slices.Contains([]string{"foot", "foot-extra", "mlterm", "yaft-256color"}, term):
+		return previewSixel
+
+	default:
+		return ""
+	}
+}
+
+// previewImage writes the PNG image to the terminal using the protocol, where
+// auto detects the protocol based on the terminal
+func previewImage(w io.Writer, data []byte, protocol string) error {
+	if protocol == "auto" {
+		if protocol = detectPreviewProtocol(); protocol == "" {
+			fmt.Fprintf(os.Stderr, "terminal is not known to support inline images, use --preview with %s, %s, or %s to select a protocol\n", previewKitty, previewITerm, previewSixel)
+			return nil
+		}
+	}
+
+	switch protocol {
+	case previewKitty:
+		return writeKittyImage(w, data)
+
+	case previewITerm:
+		return writeITermImage(w, data)
+
+	case previewSixel:
+		screenshot, err := png.Decode(bytes.NewReader(data))
+		if err != nil {
+			return fmt.Errorf("failed to decode image for preview: %w", err)
+		}
+
+		return writeSixelImage(w, screenshot)
+
+	default:
+		return fmt.Errorf("unsupported preview protocol %q, supported are: auto, %s, %s, %s", protocol, previewKitty, previewITerm, previewSixel)
+	}
+}
+
+// preview displays the screenshot in the terminal in case it is configured,
+// using the data of the written file of the format if there is one
+func preview(flags *pflag.FlagSet, scaffold *img.Scaffold, format string, data []byte) error {
+	protocol, err := flags.GetString("preview")
+	if err != nil || protocol == "" {
+		return nil
+	}
+
+	if data, err = previewData(scaffold, format, data); err != nil {
+		return err
+	}
+
+	return previewImage(os.Stdout, data, protocol)
+}
+
+// previewData returns the screenshot as PNG for the preview, which is the
+// data of the written file in case it is a PNG image, JPEG and GIF images
+// are converted, and all other formats are rendered again
+func previewData(scaffold *img.Scaffold, format string, data []byte) ([]byte, error) {
+	var screenshot image.Image
+	switch format {
+	case ".png", ".apng":
+		return data, nil
+
+	case ".jpg", ".jpeg", ".gif":
+		var err error
+		if screenshot, _, err = image.Decode(bytes.NewReader(data)); err != nil {
+			return nil, fmt.Errorf("failed to decode image for preview: %w", err)
+		}
+
+	default:
+		var err error
+		if screenshot, err = scaffold.Image(); err != nil {
+			return nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, screenshot); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// writeKittyImage writes the PNG image using the kitty graphics protocol,
+// which requires the data to be sent in chunks of at most 4096 bytes
+func writeKittyImage(w io.Writer, png []byte) error {
+	data := base64.StdEncoding.EncodeToString(png)
+
+	out := bufio.NewWriter(w)
+	for i := 0; i < len(data); i += 4096 {
+		chunk := data[i:min(i+4096, len(data))]
+
+		more := 0
+		if i+4096 < len(data) {
+			more = 1
+		}
+
+		if i == 0 {
+			fmt.Fprintf(out, "\x1b_Ga=T,f=100,m=%d;%s\x1b\\", more, chunk)
+		} else {
+			fmt.Fprintf(out, "\x1b_Gm=%d;%s\x1b\\", more, chunk)
+		}
+	}
+
+	fmt.Fprintln(out)
+	return out.Flush()
+}
+
+// writeITermImage writes the PNG image using the inline images protocol of
+// iTerm2
+func writeITermImage(w io.Writer, png []byte) error {
+	_, err := fmt.Fprintf(w, "\x1b]1337;File=inline=1;size=%d;preserveAspectRatio=1:%s\a\n",
+		len(png),
+		base64.StdEncoding.EncodeToString(png),
+	)
+
+	return err
+}
+
+// writeSixelImage writes the image as Sixel graphics with the web safe color
+// palette, transparent pixels are not painted
+func writeSixelImage(w io.Writer, img image.Image) error {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	paletted := image.NewPaletted(image.Rect(0, 0, width, height), palette.WebSafe)
+	draw.FloydSteinberg.Draw(paletted, paletted.Bounds(), img, bounds.Min)
+
+	out := bufio.NewWriter(w)
+	fmt.Fprintf(out, "\x1bP0;1;0q\"1;1;%d;%d", width, height)
+	for i, c := range paletted.Palette {
+		r, g, b, _ := c.RGBA()
+		fmt.Fprintf(out, "#%d;2;%d;%d;%d", i, r*100/0xFFFF, g*100/0xFFFF, b*100/0xFFFF)
+	}
+
+	// Each band of six rows is written one color after the other, where
+	// each character encodes which of the six pixels have the color
+	bands := make([][]byte, len(paletted.Palette))
+	for y0 := 0; y0 < height; y0 += 6 {
+		var used []int
+		for y := y0; y < min(y0+6, height); y++ {
+			for x := 0; x < width; x++ {
+				if _, _, _, a := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA(); a < 0x8000 {
+					continue
+				}
+
+				idx := paletted.ColorIndexAt(x, y)
+				if bands[idx] == nil {
+					bands[idx] = make([]byte, width)
+					used = append(used, int(idx))
+				}
+
+				bands[idx][x] |= 1 << (y - y0)
+			}
+		}
+
+		slices.Sort(used)
+		for n, idx := range used {
+			if n > 0 {
+				out.WriteByte('$')
+			}
+
+			fmt.Fprintf(out, "#%d", idx)
+			writeSixelRow(out, bands[idx])
+			bands[idx] = nil
+		}
+
+		out.WriteByte('-')
+	}
+
+	out.WriteString("\x1b\\\n")
+	return out.Flush()
+}
+
+// writeSixelRow writes the sixels of one color of a band, where repeated
+// sixels are run-length encoded
+func writeSixelRow(out *bufio.Writer, row []byte) {
+	for x := 0; x < len(row); {
+		count := 1
+		for x+count < len(row) && row[x+count] == row[x] {
+			count++
+		}
+
+		char := 63 + row[x]
+		if count > 3 {
+			fmt.Fprintf(out, "!%d%c", count, char)
+		} else {
+			for i := 0; i < count; i++ {
+				out.WriteByte(char)
+			}
+		}
+
+		x += count
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"math/rand"
+	"regexp"
+	"strconv"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Previews", func() {
+	var encode = func(screenshot image.Image) []byte {
+		var buf bytes.Buffer
+		Expect(png.Encode(&buf, screenshot)).To(Succeed())
+		return buf.Bytes()
+	}
+
+	// noise returns an image that cannot be compressed well, so that its
+	// data exceeds the size of one chunk
+	var noise = func() []byte {
+		rng := rand.New(rand.NewSource(1))
+		screenshot := image.NewRGBA(image.Rect(0, 0, 64, 64))
+		for i := range screenshot.Pix {
+			screenshot.Pix[i] = byte(rng.Intn(256))
+		}
+
+		return encode(screenshot)
+	}
+
+	Context("kitty graphics protocol", func() {
+		It("should send the PNG data in chunks of at most 4096 bytes", func() {
+			data := noise()
+
+			var buf bytes.Buffer
+			Expect(previewImage(&buf, data, previewKitty)).To(Succeed())
+
+			chunks := regexp.MustCompile("\x1b_G(?:a=T,f=100,)?m=([01]);([^\x1b]*)\x1b\\\\").FindAllStringSubmatch(buf.String(), -1)
+			Expect(len(chunks)).To(BeNumerically(">", 1))
+			Expect(buf.String()).To(HavePrefix("\x1b_Ga=T,f=100,m=1;"))
+
+			var encoded strings.Builder
+			for i, chunk := range chunks {
+				Expect(len(chunk[2])).To(BeNumerically("<=", 4096))
+				Expect(chunk[1]).To(Equal(map[bool]string{true: "0", false: "1"}[i == len(chunks)-1]))
+				encoded.WriteString(chunk[2])
+			}
+
+			Expect(base64.StdEncoding.DecodeString(encoded.String())).To(Equal(data))
+		})
+	})
+
+	Context("iTerm2 inline images protocol", func() {
+		It("should send the PNG data with its size", func() {
+			data := encode(image.NewRGBA(image.Rect(0, 0, 4, 4)))
+
+			var buf bytes.Buffer
+			Expect(previewImage(&buf, data, previewITerm)).To(Succeed())
+			Expect(buf.String()).To(Equal("\x1b]1337;File=inline=1;size=" + strconv.Itoa(len(data)) + ";preserveAspectRatio=1:" + base64.StdEncoding.EncodeToString(data) + "\a\n"))
+		})
+	})
+
+	Context("Sixel graphics", func() {
+		It("should paint the opaque pixels in bands of six rows", func() {
+			screenshot := image.NewRGBA(image.Rect(0, 0, 2, 7))
+			for y := 0; y < 7; y++ {
+				screenshot.Set(0, y, color.RGBA{R: 255, A: 255})
+			}
+
+			var buf bytes.Buffer
+			Expect(previewImage(&buf, encode(screenshot), previewSixel)).To(Succeed())
+
+			out := buf.String()
+			Expect(out).To(HavePrefix("\x1bP0;1;0q\"1;1;2;7"))
+			Expect(out).To(HaveSuffix("\x1b\\\n"))
+
+			// The red pixels of the left column are the first six sixels of
+			// the first band and the top sixel of the second band, the
+			// transparent right column is not painted
+			red := strings.Index(out, ";2;100;0;0")
+			Expect(red).To(BeNumerically(">", 0))
+			index := out[strings.LastIndex(out[:red], "#")+1 : red]
+			Expect(out).To(HaveSuffix("#" + index + "~?-#" + index + "@?-\x1b\\\n"))
+		})
+
+		It("should run-length encode repeated sixels", func() {
+			var buf bytes.Buffer
+			out := bufio.NewWriter(&buf)
+			writeSixelRow(out, []byte{1, 1, 1, 1, 2, 0, 0})
+			Expect(out.Flush()).To(Succeed())
+			Expect(buf.String()).To(Equal("!4@A??"))
+		})
+	})
+
+	It("should fail for unsupported protocols", func() {
+		Expect(previewImage(&bytes.Buffer{}, nil, "ascii")).To(MatchError(ContainSubstring(`unsupported preview protocol "ascii"`)))
+	})
+
+	Context("data of the written file", func() {
+		It("should use PNG files as they are", func() {
+			data := noise()
+			Expect(previewData(nil, ".png", data)).To(Equal(data))
+		})
+
+		It("should convert other raster images to PNG", func() {
+			var buf bytes.Buffer
+			Expect(jpeg.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 8, 4)), nil)).To(Succeed())
+
+			data, err := previewData(nil, ".jpg", buf.Bytes())
+			Expect(err).ToNot(HaveOccurred())
+
+			screenshot, err := png.Decode(bytes.NewReader(data))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(screenshot.Bounds().Size()).To(Equal(image.Pt(8, 4)))
+		})
+	})
+})
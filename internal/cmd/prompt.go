@@ -0,0 +1,70 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"bytes"
+
+	"github.com/spf13/pflag"
+
+	"github.com/homeport/termshot/internal/prompt"
+	"github.com/homeport/termshot/pkg/img"
+)
+
+// promptDetector creates the prompt detector configured by the flags, or
+// nil in case prompt detection is not enabled
+func promptDetector(flags *pflag.FlagSet) (*prompt.Detector, error) {
+	patterns, _ := flags.GetStringSlice("prompt-pattern")
+	detect, _ := flags.GetBool("detect-prompts")
+
+	if !detect && len(patterns) == 0 {
+		return nil, nil
+	}
+
+	return prompt.New(patterns...)
+}
+
+// addTranscript adds the content to the scaffold line by line, where lines
+// starting with a prompt are added as a command
+func addTranscript(scaffold *img.Scaffold, detector *prompt.Detector, data []byte) error {
+	var output bytes.Buffer
+	for _, line := range bytes.SplitAfter(data, []byte("\n")) {
+		command, ok := detector.Command(bytes.TrimRight(line, "\r\n"))
+		if !ok {
+			output.Write(line)
+			continue
+		}
+
+		if output.Len() > 0 {
+			if err := scaffold.AddContent(&output); err != nil {
+				return err
+			}
+
+			output.Reset()
+		}
+
+		if err := scaffold.AddCommand(command); err != nil {
+			return err
+		}
+	}
+
+	return scaffold.AddContent(&output)
+}
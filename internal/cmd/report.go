@@ -0,0 +1,63 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/homeport/termshot/pkg/img"
+)
+
+// reportColors writes a table of all colors used in the screenshot, including
+// the color scheme color they were mapped to
+func reportColors(w io.Writer, usages []img.ColorUsage) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "TYPE\tCOLOR\tCOUNT\tPALETTE\tRENDERED")
+
+	for _, usage := range usages {
+		kind := "foreground"
+		if usage.Background {
+			kind = "background"
+		}
+
+		original := "default"
+		if usage.Color != nil {
+			original = img.HexColor(usage.Color)
+		}
+
+		palette := "-"
+		if usage.PaletteIndex >= 0 {
+			palette = fmt.Sprintf("color%d", usage.PaletteIndex)
+		}
+
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\n",
+			kind,
+			original,
+			usage.Count,
+			palette,
+			img.HexColor(usage.Rendered),
+		)
+	}
+
+	return tw.Flush()
+}
@@ -0,0 +1,209 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/homeport/termshot/pkg/img"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const (
+	contentMetadataKey  = "termshot:content"
+	settingsMetadataKey = "termshot:settings"
+)
+
+var rerenderCmd = &cobra.Command{
+	Use:   "rerender [flags] file.png",
+	Short: "Renders a screenshot again using the content embedded in the image",
+	Long: `Renders a screenshot again based on the content and settings that were
+embedded into the PNG image using --embed-content. The embedded settings are
+used for all flags that are not set explicitly, so that only the look that
+should change needs to be specified, for example a different theme.
+`,
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// The embedded settings are applied before the configuration file,
+		// so that the screenshot keeps its look unless explicitly changed
+		_, settings, err := readEmbeddedContent(args[0])
+		if err != nil {
+			return err
+		}
+
+		if err := applyEmbeddedSettings(cmd.Flags(), settings); err != nil {
+			return err
+		}
+
+		return configure(cmd, args)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		content, _, err := readEmbeddedContent(args[0])
+		if err != nil {
+			return err
+		}
+
+		scaffold := img.NewImageCreator()
+		if err := applyLookFlags(cmd.Flags(), &scaffold); err != nil {
+			return err
+		}
+
+		if err := scaffold.AddContent(strings.NewReader(content)); err != nil {
+			return err
+		}
+
+		if embed, err := cmd.Flags().GetBool("embed-content"); err == nil && embed {
+			if err := embedContent(cmd.Flags(), &scaffold); err != nil {
+				return err
+			}
+		}
+
+		file, err := createOutputFile(cmd.Flags(), sortedKeys(imageWriters)...)
+		if err != nil {
+			return err
+		}
+
+		defer func() { _ = file.Close() }()
+
+		return imageWriters[strings.ToLower(filepath.Ext(file.Name()))](&scaffold, file, cmd.Flags())
+	},
+}
+
+// readEmbeddedContent reads the content and settings that were embedded into
+// the PNG image when the screenshot was created
+func readEmbeddedContent(filename string) (string, string, error) {
+	file, err := os.Open(filepath.Clean(filename))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to open image: %w", err)
+	}
+
+	defer func() { _ = file.Close() }()
+
+	metadata, err := img.ReadMetadata(file)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read image %s: %w", filename, err)
+	}
+
+	content, ok := metadata[contentMetadataKey]
+	if !ok {
+		return "", "", fmt.Errorf("failed to read image %s: no embedded content, the screenshot has to be created using --embed-content", filename)
+	}
+
+	return content, metadata[settingsMetadataKey], nil
+}
+
+// embedContent sets the content as it was read and all set look flags as
+// metadata of the scaffold, so that the screenshot can be rendered again
+func embedContent(flags *pflag.FlagSet, scaffold *img.Scaffold) error {
+	scaffold.SetMetadata(contentMetadataKey, string(scaffold.Input()))
+	scaffold.SetMetadata(settingsMetadataKey, embeddedSettings(flags))
+	return nil
+}
+
+// embeddedSettings returns all look flags that were set explicitly with one
+// name=value pair per line, list flags use one line per element
+func embeddedSettings(flags *pflag.FlagSet) string {
+	var settings strings.Builder
+
+	lookFlags := pflag.NewFlagSet("look", pflag.ContinueOnError)
+	addLookFlags(lookFlags)
+	lookFlags.VisitAll(func(f *pflag.Flag) {
+		flag := flags.Lookup(f.Name)
+		if flag == nil || !flag.Changed {
+			return
+		}
+
+		// The command is part of the embedded content already
+		if flag.Name == "show-cmd" {
+			return
+		}
+
+		if slice, ok := flag.Value.(pflag.SliceValue); ok {
+			for _, value := range slice.GetSlice() {
+				fmt.Fprintf(&settings, "%s=%s\n", flag.Name, value)
+			}
+			return
+		}
+
+		fmt.Fprintf(&settings, "%s=%s\n", flag.Name, flag.Value.String())
+	})
+
+	return settings.String()
+}
+
+// applyEmbeddedSettings sets the flag values of the embedded settings for all
+// flags that were not explicitly set, they are only defaults, so that they
+// give way to flags of the command line that cannot be used together with
+// them, like a theme and a colorscheme
+func applyEmbeddedSettings(flags *pflag.FlagSet, settings string) error {
+	var names []string
+	values := map[string][]string{}
+
+	scanner := bufio.NewScanner(strings.NewReader(settings))
+	for scanner.Scan() {
+		name, value, found := strings.Cut(scanner.Text(), "=")
+		if !found {
+			continue
+		}
+
+		if _, ok := values[name]; !ok {
+			names = append(names, name)
+		}
+
+		values[name] = append(values[name], value)
+	}
+
+	for _, name := range names {
+		if err := setDefault(flags, embeddedSource, name, values[name]...); err != nil {
+			return fmt.Errorf("invalid value for embedded setting %q: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(rerenderCmd)
+
+	rerenderCmd.Flags().SortFlags = false
+	addRerenderFlags(rerenderCmd.Flags())
+}
+
+// addRerenderFlags adds the flags of the rerender command
+func addRerenderFlags(flags *pflag.FlagSet) {
+	// flags to control look
+	addLookFlags(flags)
+
+	// flags for output related settings
+	flags.StringP("filename", "f", "out.png", "filename of the screenshot")
+	flags.Bool("no-clobber", false, "fail instead of replacing the file if it already exists")
+	flags.Int("quality", 0, "quality of JPEG and WebP images from 1 to 100 (default is 90 for JPEG and all colors for WebP)")
+	flags.Int("page-lines", 0, "number of lines per page of PDF documents (default is all lines on one page)")
+	flags.Bool("embed-content", true, "embed content and settings into PNG images again, so that they can be rendered again")
+}
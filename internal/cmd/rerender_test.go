@@ -0,0 +1,132 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/homeport/termshot/pkg/img"
+)
+
+var _ = Describe("Rendering screenshots again", func() {
+	const content = "\x1b[31mloading\x1b[0m\rdone   \n"
+
+	var dir string
+
+	var screenshot = func(args ...string) string {
+		flags := pflag.NewFlagSet("termshot", pflag.ContinueOnError)
+		addRerenderFlags(flags)
+		Expect(flags.Parse(args)).To(Succeed())
+
+		scaffold := img.NewImageCreator()
+		Expect(applyLookFlags(flags, &scaffold)).To(Succeed())
+		Expect(scaffold.AddContent(strings.NewReader(content))).To(Succeed())
+		Expect(embedContent(flags, &scaffold)).To(Succeed())
+
+		filename := filepath.Join(dir, "shot.png")
+		file, err := os.Create(filename)
+		Expect(err).ToNot(HaveOccurred())
+		defer func() { _ = file.Close() }()
+
+		Expect(scaffold.WritePNG(file)).To(Succeed())
+		return filename
+	}
+
+	var rerender = func(args ...string) (string, error) {
+		cmd := &cobra.Command{
+			Args:              rerenderCmd.Args,
+			PersistentPreRunE: rerenderCmd.PersistentPreRunE,
+			RunE:              rerenderCmd.RunE,
+			SilenceUsage:      true,
+			SilenceErrors:     true,
+		}
+
+		addRerenderFlags(cmd.Flags())
+
+		filename := filepath.Join(dir, "again.png")
+		cmd.SetArgs(append(args, "--filename", filename))
+		if err := cmd.Execute(); err != nil {
+			return "", err
+		}
+
+		_, settings, err := readEmbeddedContent(filename)
+		Expect(err).ToNot(HaveOccurred())
+		return settings, nil
+	}
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		GinkgoT().Setenv("XDG_CONFIG_HOME", dir)
+	})
+
+	It("should embed the content as it was read", func() {
+		embedded, _, err := readEmbeddedContent(screenshot())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(embedded).To(Equal(content))
+	})
+
+	It("should keep the content and settings when rendering again", func() {
+		settings, err := rerender(screenshot("--theme", "dracula"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(settings).To(ContainSubstring("theme=dracula\n"))
+
+		embedded, _, err := readEmbeddedContent(filepath.Join(dir, "again.png"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(embedded).To(Equal(content))
+	})
+
+	It("should use a colorscheme instead of the embedded theme", func() {
+		colorscheme := filepath.Join(dir, "cs.json")
+		Expect(os.WriteFile(colorscheme, []byte(`{"colors": {"color1": "#ff0000"}}`), 0o600)).To(Succeed())
+
+		settings, err := rerender(screenshot("--theme", "dracula"), "--colorscheme", colorscheme)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(settings).To(ContainSubstring("colorscheme=" + colorscheme + "\n"))
+		Expect(settings).ToNot(ContainSubstring("theme="))
+	})
+
+	It("should use a resolution instead of the embedded scale", func() {
+		settings, err := rerender(screenshot("--scale", "1"), "--dpi", "300")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(settings).To(ContainSubstring("dpi=300\n"))
+		Expect(settings).ToNot(ContainSubstring("scale="))
+	})
+
+	It("should fail for images without embedded content", func() {
+		filename := filepath.Join(dir, "plain.png")
+		scaffold := img.NewImageCreator()
+		Expect(scaffold.AddContent(strings.NewReader(content))).To(Succeed())
+		file, err := os.Create(filename)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(scaffold.WritePNG(file)).To(Succeed())
+		Expect(file.Close()).To(Succeed())
+
+		_, err = rerender(filename)
+		Expect(err).To(MatchError(ContainSubstring("no embedded content")))
+	})
+})
@@ -22,20 +22,31 @@ package cmd
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gonvenience/bunt"
 	"github.com/gonvenience/neat"
+	"github.com/gonvenience/term"
 
-	"github.com/homeport/termshot/internal/img"
+	"github.com/homeport/termshot/internal/decorate"
+	"github.com/homeport/termshot/internal/diff"
 	"github.com/homeport/termshot/internal/ptexec"
+	"github.com/homeport/termshot/internal/vt"
+	"github.com/homeport/termshot/pkg/img"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"golang.org/x/image/font"
 )
 
 // version string will be injected by automation
@@ -44,14 +55,31 @@ var version string
 // saveToClipboard function will be implemented by OS specific code
 var saveToClipboard func(img.Scaffold) error
 
+// deterministicRows is the number of lines of the pseudo terminal commands
+// run in when the output is deterministic
+const deterministicRows = 25
+
+// commandSeparator separates multiple commands that run one after another
+const commandSeparator = "++"
+
+// registerClipboard registers the clipboard flag and the OS specific function
+// to copy the image into the clipboard
+func registerClipboard(save func(img.Scaffold) error) {
+	rootCmd.Flags().BoolP("clipboard", "b", false, "copy termshot to clipboard, overrules filename option unless it is set explicitly")
+	saveToClipboard = save
+}
+
 var rootCmd = &cobra.Command{
-	Use:   fmt.Sprintf("%s [%s flags] [--] command [command flags] [command arguments] [...]", executableName(), executableName()),
+	Use:   fmt.Sprintf("%s [%s flags] [--] command [command flags] [command arguments] [++ command ...]", executableName(), executableName()),
 	Short: "Creates a screenshot of terminal command output",
 	Long: `Executes the provided command as-is with all flags and arguments in a pseudo
 terminal and captures the generated output. The result is printed as it was
 produced. Additionally, an image will be rendered in a lookalike terminal
-window including all terminal colors and text decorations.
+window including all terminal colors and text decorations. Multiple commands
+separated by ++ run one after another and are shown like a session, each
+with its command line followed by its output.
 `,
+	Args:          cobra.ArbitraryArgs,
 	SilenceUsage:  true,
 	SilenceErrors: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -70,87 +98,163 @@ window including all terminal colors and text decorations.
 			return nil
 		}
 
+		// Optional: Render jobs read from standard input instead
+		//
+		if jsonJobs, err := cmd.Flags().GetBool("json-jobs"); err == nil && jsonJobs {
+			if len(args) > 0 {
+				return fmt.Errorf("JSON jobs cannot be combined with a command")
+			}
+
+			return runJSONJobs(cmd.Flags(), cmd.InOrStdin(), cmd.OutOrStdout())
+		}
+
+		// Optional: Render a cast file as an animation instead
+		//
+		if castFile, err := cmd.Flags().GetString("cast"); err == nil && castFile != "" {
+			return renderCast(cmd.Flags(), castFile)
+		}
+
 		rawRead, _ := cmd.Flags().GetString("raw-read")
 		rawWrite, _ := cmd.Flags().GetString("raw-write")
+		tmuxPane, _ := cmd.Flags().GetString("tmux-pane")
+		diffFile, _ := cmd.Flags().GetString("diff-file")
 
-		if len(args) == 0 && rawRead == "" {
-			return cmd.Usage()
-		}
+		// A patch is read like raw input, with the colors of a diff added
+		if diffFile != "" {
+			if len(args) > 0 || rawRead != "" || tmuxPane != "" {
+				return fmt.Errorf("diff file cannot be combined with a command, raw input, or tmux pane")
+			}
 
-		scaffold := img.NewImageCreator()
-		var buf bytes.Buffer
-		pt := ptexec.New()
+			rawRead = diffFile
+		}
 
-		// Apply custom fonts if provided
+		// Optional: Run an interactive shell session instead of a command
 		//
-		if fonts, err := cmd.Flags().GetStringSlice("font"); err == nil && len(fonts) > 0 {
-			if err := scaffold.LoadCustomFonts(fonts); err != nil {
-				return fmt.Errorf("failed to load custom fonts: %w", err)
+		interactive, _ := cmd.Flags().GetBool("interactive")
+		if interactive {
+			if len(args) > 0 || rawRead != "" || tmuxPane != "" {
+				return fmt.Errorf("interactive session cannot be combined with a command, raw input, or tmux pane")
 			}
+
+			args = []string{interactiveShell()}
+			fmt.Fprintf(os.Stderr, "Recording interactive session with %s, exit the shell to create the screenshot\n", args[0])
 		}
 
-		// Apply custom colorscheme if provided
-		//
-		if colorscheme, err := cmd.Flags().GetString("colorscheme"); err == nil && colorscheme != "" {
-			if err := scaffold.LoadColorscheme(colorscheme); err != nil {
-				return fmt.Errorf("failed to load colorscheme: %w", err)
+		if tmuxPane != "" && (len(args) > 0 || rawRead != "") {
+			return fmt.Errorf("tmux pane capture cannot be combined with a command or raw input")
+		}
+
+		if len(args) == 0 && rawRead == "" && tmuxPane == "" {
+			return cmd.Usage()
+		}
+
+		if err := checkCardFormat(cmd.Flags()); err != nil {
+			return err
+		}
+
+		// The file for the screenshot is checked before anything runs, so
+		// that the command does not run in vain, the screenshot is not
+		// written to a file if only raw output or the clipboard is requested
+		var filename string
+		toClipboard, _ := cmd.Flags().GetBool("clipboard")
+		if rawWrite == "" && (!toClipboard || explicitFilename(cmd.Flags())) {
+			var err error
+			if filename, err = outputFilename(cmd.Flags(), sortedKeys(imageWriters)...); err != nil {
+				return err
 			}
 		}
 
-		// Initialise scaffold with a column sizing so that the
-		// content can be wrapped accordingly
+		// Optional: Run a command of the user before the capture, e.g. to
+		// prepare the environment for the screenshot
 		//
+		if err := runHook(cmd.Flags(), "pre-hook", map[string]string{"COMMAND": strings.Join(args, " ")}); err != nil {
+			return err
+		}
+
+		scaffold := img.NewImageCreator()
+		var buf bytes.Buffer
+		pt := ptexec.New()
+
+		if err := applyLookFlags(cmd.Flags(), &scaffold); err != nil {
+			return err
+		}
+
 		if columns, err := cmd.Flags().GetInt("columns"); err == nil && columns > 0 {
-			scaffold.SetColumns(columns)
 			pt.Cols(uint16(columns))
 		}
 
-		if cmd.Flags().Changed("padding") {
-			if val, err := cmd.Flags().GetString("padding"); err == nil {
-				top, right, bottom, left, err := parseBox(val)
-				if err != nil {
-					return fmt.Errorf("invalid padding: %w", err)
-				}
-				scaffold.SetPadding(top, right, bottom, left)
-			}
+		// Optional: Kill commands that run too long, keeping their output
+		//
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+		if timeout < 0 {
+			return fmt.Errorf("invalid timeout %v, it has to be zero or greater", timeout)
 		}
 
-		if cmd.Flags().Changed("margin") {
-			if val, err := cmd.Flags().GetString("margin"); err == nil {
-				top, right, bottom, left, err := parseBox(val)
-				if err != nil {
-					return fmt.Errorf("invalid margin: %w", err)
-				}
-				scaffold.SetMargin(top, right, bottom, left)
+		pt.Timeout(timeout)
+
+		// Optional: Capture the standard error separately to style it
+		//
+		if style, err := cmd.Flags().GetString("stderr-style"); err == nil && style != "" {
+			if err := scaffold.SetStderrStyle(style); err != nil {
+				return err
 			}
+
+			pt.Stderr(img.StderrStart, img.StderrEnd)
 		}
 
-		// Disable window shadow if requested
+		// Optional: Ignore the current terminal and environment, so that the
+		// same input always creates the same image
 		//
-		if val, err := cmd.Flags().GetBool("no-shadow"); err == nil {
-			scaffold.DrawShadow(!val)
+		if deterministic, err := cmd.Flags().GetBool("deterministic"); err == nil && deterministic {
+			scaffold.SetDeterministic(true)
+			pt.Rows(deterministicRows)
+			pt.Cols(uint16(scaffold.GetFixedColumns()))
 		}
 
-		// Disable window decorations (buttons) if requested
+		// Optional: Render the output on a screen with the height of the
+		// terminal, and keep the lines that scroll out of it
 		//
+		if value, err := cmd.Flags().GetString("scrollback"); err == nil && value != "" {
+			scrollback, err := parseScrollback(value)
+			if err != nil {
+				return err
+			}
+
+			rows := deterministicRows
+			if deterministic, _ := cmd.Flags().GetBool("deterministic"); !deterministic {
+				if _, height := term.GetTerminalSize(); height > 0 {
+					rows = height
+				}
+			}
+
+			if err := scaffold.SetScrollback(rows, scrollback); err != nil {
+				return err
+			}
 
-		if val, err := cmd.Flags().GetBool("no-decoration"); err == nil {
-			scaffold.DrawDecorations(!val)
+			pt.Rows(uint16(rows))
 		}
 
-		if val, err := cmd.Flags().GetBool("no-border"); err == nil {
-			scaffold.DrawBorder(!val)
+		// Optional: Show the time of the capture in the title bar
+		//
+		if layout, err := cmd.Flags().GetString("timestamp"); err == nil && layout != "" {
+			now := time.Now()
+			if deterministic, _ := cmd.Flags().GetBool("deterministic"); deterministic {
+				now = deterministicTime
+			}
+
+			scaffold.SetTimestamp(now.Format(layout))
 		}
 
-		// Configure that canvas is clipped at the end
+		// Multiple commands are separated by ++ and run one after another
 		//
-		if val, err := cmd.Flags().GetBool("clip-canvas"); err == nil {
-			scaffold.ClipCanvas(val)
+		commands, err := sessionCommands(args)
+		if err != nil {
+			return err
 		}
 
 		// Optional: Prepend command line arguments to output content
 		//
-		if includeCommand, err := cmd.Flags().GetBool("show-cmd"); err == nil && includeCommand && rawRead == "" {
+		if includeCommand, err := cmd.Flags().GetBool("show-cmd"); err == nil && includeCommand && rawRead == "" && tmuxPane == "" && !interactive && len(commands) == 1 {
 			if err := scaffold.AddCommand(args...); err != nil {
 				return err
 			}
@@ -158,22 +262,40 @@ window including all terminal colors and text decorations.
 
 		// Get the actual content for the screenshot
 		//
-		if rawRead == "" {
-			// Run the provided command in a pseudo terminal and capture
-			// the output to be later rendered into the screenshot
-			bytes, err := pt.Command(args[0], args[1:]...).Run()
+		var result session
+		switch {
+		case tmuxPane != "":
+			// Capture the styled content of a tmux pane instead of
+			// executing a command to read its output
+			bytes, err := captureTmuxPane(tmuxPane)
 			if err != nil {
-				return fmt.Errorf("failed to run command in pseudo terminal: %w", err)
+				return err
 			}
 			buf.Write(bytes)
 
-		} else {
+		case rawRead == "":
+			retries, _ := cmd.Flags().GetInt("retries")
+			retryDelay, _ := cmd.Flags().GetDuration("retry-delay")
+
+			result, err = runSession(pt, &scaffold, &buf, commands, retries, retryDelay, interactive)
+			if err != nil {
+				return err
+			}
+
+		default:
 			// Read the content from an existing file instead of
 			// executing a command to read its output
 			bytes, err := readFile(rawRead)
 			if err != nil {
 				return fmt.Errorf("failed to read contents: %w", err)
 			}
+
+			// Patches that were not colored, e.g. by git, get the colors
+			// of a diff, colored patches are kept as-is
+			if diffFile != "" && !strings.Contains(string(bytes), "\x1b[") {
+				bytes = []byte(diff.Colorize(string(bytes)))
+			}
+
 			buf.Write(bytes)
 		}
 
@@ -209,12 +331,98 @@ window including all terminal colors and text decorations.
 			buf.Write(bytes)
 		}
 
-		// Add the captured output to the scaffold
+		// Optional: Report escape sequences that are not fully supported
+		//
+		if lint, err := cmd.Flags().GetBool("lint"); err == nil && lint {
+			for _, issue := range vt.Lint(buf.Bytes()) {
+				fmt.Fprintln(os.Stderr, issue)
+			}
+		}
+
+		// Add the captured output to the scaffold, optionally restyling
+		// prompt lines of a transcript the same way as the command
 		//
-		if err := scaffold.AddContent(&buf); err != nil {
+		detector, err := promptDetector(cmd.Flags())
+		if err != nil {
 			return err
 		}
 
+		switch {
+		case detector != nil && rawRead != "":
+			if err := addTranscript(&scaffold, detector, buf.Bytes()); err != nil {
+				return err
+			}
+
+		default:
+			if err := scaffold.AddContent(&buf); err != nil {
+				return err
+			}
+		}
+
+		// Optional: Annotate how many attempts it took to run the command
+		//
+		if annotate, err := cmd.Flags().GetBool("show-attempts"); err == nil && annotate && result.attempts > 0 {
+			retries, _ := cmd.Flags().GetInt("retries")
+			if err := scaffold.AddFooter(fmt.Sprintf("attempt %d of %d", result.attempts, retries+1)); err != nil {
+				return err
+			}
+		}
+
+		// Optional: Show how long it took to run the command
+		//
+		if showDuration, err := cmd.Flags().GetBool("show-duration"); err == nil && showDuration && result.attempts > 0 {
+			if err := scaffold.AddFooter("took " + formatDuration(result.duration)); err != nil {
+				return err
+			}
+		}
+
+		// Optional: Show that the command was killed after the timeout
+		//
+		if showTimeout, err := cmd.Flags().GetBool("show-timeout"); err == nil && showTimeout && result.timedOut {
+			if err := scaffold.AddFooter("timed out after " + formatDuration(timeout)); err != nil {
+				return err
+			}
+		}
+
+		// Optional: Show the exit code of the command
+		//
+		if showExitCode, err := cmd.Flags().GetBool("show-exit-code"); err == nil && showExitCode && result.attempts > 0 {
+			if err := scaffold.AddExitCode(result.exitCode); err != nil {
+				return err
+			}
+		}
+
+		// Optional: Report which colors are used in the screenshot
+		//
+		if report, err := cmd.Flags().GetBool("report-colors"); err == nil && report {
+			if err := reportColors(os.Stderr, scaffold.ColorUsage()); err != nil {
+				return err
+			}
+		}
+
+		// Optional: Save content as plain text to be used as alternative text
+		//
+		if altText, err := cmd.Flags().GetString("alt-text"); err == nil && altText != "" {
+			summary, _ := cmd.Flags().GetBool("alt-text-summary")
+			if err := writeAltText(altText, &scaffold, summary, args); err != nil {
+				return err
+			}
+		}
+
+		// Optional: Save the parsed content as JSON
+		//
+		if dumpCells, err := cmd.Flags().GetString("dump-cells"); err == nil && dumpCells != "" {
+			file, err := os.Create(filepath.Clean(dumpCells))
+			if err != nil {
+				return fmt.Errorf("failed to create file: %w", err)
+			}
+
+			defer func() { _ = file.Close() }()
+			if err := scaffold.WriteCells(file); err != nil {
+				return err
+			}
+		}
+
 		// Optional: Save content as-is to a file
 		//
 		if rawWrite != "" {
@@ -236,34 +444,79 @@ window including all terminal colors and text decorations.
 			return scaffold.WriteRaw(output)
 		}
 
+		// Optional: Embed content and settings into PNG images
+		//
+		if embed, err := cmd.Flags().GetBool("embed-content"); err == nil && embed {
+			if err := embedContent(cmd.Flags(), &scaffold); err != nil {
+				return err
+			}
+		}
+
 		// Optional: Save image to clipboard
 		//
 		if toClipboard, err := cmd.Flags().GetBool("clipboard"); err == nil && toClipboard {
-			return saveToClipboard(scaffold)
+			if err := saveToClipboard(scaffold); err != nil {
+				return err
+			}
+
+			// Only write the file in addition, if explicitly requested
+			if !explicitFilename(cmd.Flags()) {
+				return preview(cmd.Flags(), &scaffold, "", nil)
+			}
 		}
 
 		// Save image to file
 		//
-		filename, err := cmd.Flags().GetString("filename")
-		if filename == "" || err != nil {
-			fmt.Fprintf(os.Stderr, "failed to read filename from command-line, defaulting to out.png")
-			filename = "out.png"
+		file, err := openOutputFile(cmd.Flags(), filename)
+		if err != nil {
+			return err
 		}
 
-		if extension := filepath.Ext(filename); extension != ".png" {
-			return fmt.Errorf("file extension %q of filename %q is not supported, only png is supported", extension, filename)
+		defer func() { _ = file.Close() }()
+
+		// The written data is kept for the preview, so that it shows the
+		// same image as the file without rendering it again
+		var written bytes.Buffer
+		out := io.MultiWriter(file, &written)
+
+		format := strings.ToLower(filepath.Ext(file.Name()))
+		if useCache, err := cmd.Flags().GetBool("cache"); err == nil && useCache {
+			err = writeCached(cmd.Flags(), &scaffold, out, format)
+		} else {
+			err = imageWriters[format](&scaffold, out, cmd.Flags())
 		}
 
-		file, err := os.Create(filepath.Clean(filename))
 		if err != nil {
-			return fmt.Errorf("failed to create file: %w", err)
+			return err
 		}
 
-		defer func() { _ = file.Close() }()
-		return scaffold.WritePNG(file)
+		if err := file.Close(); err != nil {
+			return fmt.Errorf("failed to write file: %w", err)
+		}
+
+		// Optional: Display the image in the terminal
+		//
+		if err := preview(cmd.Flags(), &scaffold, format, written.Bytes()); err != nil {
+			return err
+		}
+
+		// Optional: Run a command of the user with the created file, e.g. to
+		// optimize or upload it
+		//
+
+		return runHook(cmd.Flags(), "post-hook", screenshotMetadata(file.Name(), args, &scaffold, result))
 	},
 }
 
+// interactiveShell returns the shell of the user for interactive sessions
+func interactiveShell() string {
+	if shell := os.Getenv("SHELL"); shell != "" {
+		return shell
+	}
+
+	return "/bin/sh"
+}
+
 // Execute is the main entry point into the CLI code
 func Execute() {
 	rootCmd.SetFlagErrorFunc(func(c *cobra.Command, e error) error {
@@ -321,6 +574,896 @@ func readFile(name string) ([]byte, error) {
 	}
 }
 
+// imageWriters are the supported output formats of the screenshot by file
+// extension, the flags contain the format specific settings
+var imageWriters = map[string]func(s *img.Scaffold, w io.Writer, flags *pflag.FlagSet) error{
+	".png":  func(s *img.Scaffold, w io.Writer, _ *pflag.FlagSet) error { return s.WritePNG(w) },
+	".svg":  func(s *img.Scaffold, w io.Writer, _ *pflag.FlagSet) error { return s.WriteSVG(w) },
+	".html": func(s *img.Scaffold, w io.Writer, _ *pflag.FlagSet) error { return s.WriteHTML(w) },
+	".gif": func(s *img.Scaffold, w io.Writer, _ *pflag.FlagSet) error {
+		frames, err := s.BlinkFrames()
+		if err != nil {
+			return err
+		}
+
+		return img.WriteGIF(w, frames)
+	},
+	".apng": func(s *img.Scaffold, w io.Writer, _ *pflag.FlagSet) error {
+		frames, err := s.BlinkFrames()
+		if err != nil {
+			return err
+		}
+
+		return img.WriteAPNG(w, frames)
+	},
+	".jpg":  writeJPEG,
+	".jpeg": writeJPEG,
+	".webp": func(s *img.Scaffold, w io.Writer, flags *pflag.FlagSet) error {
+		quality, _ := flags.GetInt("quality")
+		return s.WriteWebP(w, img.WebPOptions{Quality: quality})
+	},
+	".pdf": func(s *img.Scaffold, w io.Writer, flags *pflag.FlagSet) error {
+		linesPerPage, _ := flags.GetInt("page-lines")
+		return s.WritePDF(w, img.PDFOptions{LinesPerPage: linesPerPage})
+	},
+}
+
+// writeJPEG writes the screenshot as JPEG, using a quality of 90 by default
+func writeJPEG(s *img.Scaffold, w io.Writer, flags *pflag.FlagSet) error {
+	quality, _ := flags.GetInt("quality")
+	if quality == 0 {
+		quality = 90
+	}
+
+	return s.WriteJPEG(w, quality)
+}
+
+// createOutputFile creates the file for the screenshot based on the filename
+// flag, making sure that the file extension is one of the supported ones,
+// which is only png if no extensions are provided
+func createOutputFile(flags *pflag.FlagSet, extensions ...string) (*os.File, error) {
+	filename, err := outputFilename(flags, extensions...)
+	if err != nil {
+		return nil, err
+	}
+
+	return openOutputFile(flags, filename)
+}
+
+// outputFilename returns the file for the screenshot based on the filename
+// flag, making sure that the file extension is one of the supported ones,
+// which is only png if no extensions are provided. Existing files are only
+// replaced if the filename is explicitly set, the default filename is
+// numbered instead to keep previous screenshots.
+func outputFilename(flags *pflag.FlagSet, extensions ...string) (string, error) {
+	filename, err := flags.GetString("filename")
+	if filename == "" || err != nil {
+		fmt.Fprintf(os.Stderr, "failed to read filename from command-line, defaulting to out.png")
+		filename = "out.png"
+	}
+
+	if len(extensions) == 0 {
+		extensions = []string{".png"}
+	}
+
+	if extension := strings.ToLower(filepath.Ext(filename)); !slices.Contains(extensions, extension) {
+		names := make([]string, len(extensions))
+		for i, ext := range extensions {
+			names[i] = strings.TrimPrefix(ext, ".")
+		}
+
+		return "", fmt.Errorf("file extension %q of filename %q is not supported, supported are: %s", extension, filename, strings.Join(names, ", "))
+	}
+
+	explicit := explicitFilename(flags)
+	noClobber, _ := flags.GetBool("no-clobber")
+
+	candidate := filepath.Clean(filename)
+	for i := 1; ; i++ {
+		_, err := os.Stat(candidate)
+		switch {
+		case errors.Is(err, fs.ErrNotExist) || (explicit && !noClobber):
+			return candidate, nil
+
+		case err != nil:
+			return "", fmt.Errorf("failed to check file: %w", err)
+
+		case explicit:
+			return "", fmt.Errorf("file %s already exists, use a different filename or omit --no-clobber", candidate)
+		}
+
+		candidate = numberedFilename(filename, i)
+	}
+}
+
+// openOutputFile creates the file returned by outputFilename including its
+// directory, where the file is only replaced if this is allowed, in case it
+// was created in the meantime
+func openOutputFile(flags *pflag.FlagSet, filename string) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(filename), os.FileMode(0o755)); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	explicit := explicitFilename(flags)
+	noClobber, _ := flags.GetBool("no-clobber")
+
+	mode := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if !explicit || noClobber {
+		mode = os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	}
+
+	file, err := os.OpenFile(filename, mode, 0o666)
+	switch {
+	case errors.Is(err, fs.ErrExist):
+		return nil, fmt.Errorf("file %s already exists, use a different filename or omit --no-clobber", filename)
+
+	case err != nil:
+		return nil, fmt.Errorf("failed to create file: %w", err)
+	}
+
+	return file, nil
+}
+
+// numberedFilename returns the filename with the number appended to the name
+// before the file extension, e.g. out-1.png for out.png
+func numberedFilename(filename string, number int) string {
+	filename = filepath.Clean(filename)
+	extension := filepath.Ext(filename)
+	return fmt.Sprintf("%s-%d%s", strings.TrimSuffix(filename, extension), number, extension)
+}
+
+// writeAltText writes the content as plain text into the file, optionally
+// starting with a one-line summary of the screenshot
+func writeAltText(filename string, scaffold *img.Scaffold, summary bool, args []string) error {
+	file, err := os.Create(filepath.Clean(filename))
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+
+	defer func() { _ = file.Close() }()
+
+	if summary {
+		lines := len(scaffold.Lines())
+
+		var text string
+		switch len(args) {
+		case 0:
+			text = fmt.Sprintf("Terminal screenshot showing %d lines", lines)
+
+		default:
+			text = fmt.Sprintf("Terminal screenshot of the command \"%s\" showing %d lines", strings.Join(args, " "), lines)
+		}
+
+		if _, err := fmt.Fprintf(file, "%s\n\n", text); err != nil {
+			return err
+		}
+	}
+
+	return scaffold.WriteText(file)
+}
+
+// addLookFlags registers all flags that control the look of the screenshot
+func addLookFlags(flags *pflag.FlagSet) {
+	flags.String("preset", "", "name of preset with predefined look settings, see presets command")
+	flags.BoolP("show-cmd", "c", false, "include command in screenshot")
+	flags.String("prompt-symbol", "", "symbol in front of the command, instead of ➜ or $TS_COMMAND_INDICATOR")
+	flags.String("prompt-style", "", "style of the prompt symbol: color (#rrggbb), bold, dim, italic, or underline, e.g. bold,#ff8800")
+	flags.String("prompt-template", "", "template of the command line with {user}, {host}, {cwd}, {indicator}, and {command}, optionally styled like {cwd:bold,#00ffff}")
+	flags.IntP("columns", "C", 0, "force fixed number of columns in screenshot")
+	flags.Int("tab-width", 8, "number of columns between tab stops")
+	flags.String("lines", "", "range of lines of the output to show, e.g. 20:80, 20: or :80 (counting from one)")
+	flags.Int("max-lines", 0, "maximum number of lines of the content, further lines are left out")
+	flags.String("max-lines-keep", "last", "which lines are kept if there are more than the maximum: first or last")
+	flags.String("max-lines-marker", "separator", "how left out lines are marked: separator (line with the number of omitted lines), fade, or none")
+	flags.String("wrap", "char", "how long lines are wrapped: char (after the last column), word (at whitespace), or none")
+	flags.Bool("no-decoration", false, "do not draw window decorations")
+	flags.Bool("no-shadow", false, "do not draw window shadow")
+	flags.Bool("no-border", false, "do not draw outer window border")
+	flags.String("border-color", "", "color of the window border (#rrggbb), or outer and inner color for a two-tone border, e.g. #404040,#ffffff")
+	flags.Float64("border-width", 1, "width of the window border in pixels")
+	flags.String("padding", "", "set padding in pixels (t,r,b,l)")
+	flags.String("margin", "", "set margin in pixels (t,r,b,l)")
+	flags.Float64("corner-radius", 6, "radius of the rounded window corners in pixels (0 for square corners)")
+	flags.Float64("min-width", 0, "minimum width of the window in pixels")
+	flags.Float64("min-height", 0, "minimum height of the window in pixels")
+	flags.String("aspect-ratio", "", "aspect ratio the window is grown to, e.g. 16:9 or 1.5")
+	flags.String("align", "left", "horizontal alignment of the content if the window is wider: left or center")
+	flags.String("vertical-align", "top", "vertical alignment of the content if the window is higher: top or center")
+	flags.BoolP("clip-canvas", "s", false, "clip canvas to visible image area (no margin)")
+	flags.Bool("trim-whitespace", false, "ignore trailing whitespace and common indentation for the window size")
+	flags.Bool("trim", false, "leave out empty lines at the start and end of the content")
+	flags.Bool("line-numbers", false, "show line numbers in a gutter left of the content")
+	flags.StringSlice("font", nil, "custom font files (TTF/OTF) to use instead of default Hack font")
+	flags.Float64("font-size", 12, "size of the font in points")
+	flags.StringSlice("fallback-font", nil, "font files (TTF/OTF) to use for characters the font has no glyph for, e.g. emoji or icons")
+	flags.StringSlice("font-features", nil, "OpenType features of the custom fonts to enable (e.g. ss01,zero) or disable (e.g. -liga)")
+	flags.Bool("ligatures", false, "draw ligatures of the custom fonts, e.g. for => or != in coding fonts")
+	flags.Float64("scale", 2, "factor all sizes are scaled with, e.g. 1 for the web or 3 for print")
+	flags.Float64("dpi", 0, "resolution in dots per inch that sets the scale (96 is a scale of 1) and is stored in PNG images")
+	flags.Bool("no-antialias", false, "render glyphs without antialiasing")
+	flags.String("hinting", "none", "hinting of glyph outlines: none, vertical, or full")
+	flags.String("colorscheme", "", "JSON file with custom color scheme (color0-color255), or color scheme of a terminal")
+	flags.String("scheme-format", "", "format of the colorscheme file if not detected: "+strings.Join(img.ColorschemeFormats(), ", "))
+	flags.String("theme", "", "name of built-in color scheme, see themes command")
+	flags.String("source-palette", img.PaletteAuto, "palette of the terminal emulator whose colors the content uses: "+strings.Join(img.SourcePalettes(), ", "))
+	flags.Float64("dim-opacity", 0.5, "opacity of dim text on top of its background between 0 and 1")
+	flags.Float64("transparent", 1, "opacity of the window background between 0 and 1, the text stays opaque (0 if no value is given)")
+	flags.Lookup("transparent").NoOptDefVal = "0"
+	flags.String("cursor", "none", "draw the cursor of the terminal as block, bar, or underline")
+	flags.String("blink-style", "bold", "how blinking text is shown in static images: bold, italic, or none (animated GIF images show and hide it)")
+	flags.String("decorate", "", "Starlark script that draws custom decorations on top of the window")
+	flags.String("background", "", "background behind the window: color, gradient of colors, or image file")
+	flags.String("card", "", "place the window on a card of fixed size in pixels for social media, e.g. 1200x630, og, or twitter")
+	flags.StringArray("redact", nil, "regular expression of text to mask in the screenshot, e.g. tokens or email addresses (repeatable)")
+	flags.String("redact-style", "block", "how redacted text is masked: block or blur")
+	flags.String("watermark", "", "image file (PNG/JPEG) or text to stamp on top of the window")
+	flags.String("watermark-position", "bottom-right", "position of the watermark: top-left, top-right, bottom-left, bottom-right, or center")
+	flags.Float64("watermark-opacity", 0.5, "opacity of the watermark between 0 and 1")
+}
+
+// applyLookFlags configures the scaffold based on the flags that control the
+// look of the screenshot
+func applyLookFlags(flags *pflag.FlagSet, scaffold *img.Scaffold) error {
+	// Apply preset settings for all flags that were not explicitly set
+	//
+	if name, err := flags.GetString("preset"); err == nil && name != "" {
+		if err := applyPreset(flags, name); err != nil {
+			return err
+		}
+	}
+
+	// Configure the scale of the image, either directly or by resolution
+	//
+	if err := exclusiveFlags(flags, "scale", "dpi"); err != nil {
+		return err
+	}
+
+	if val, err := flags.GetFloat64("scale"); err == nil && flags.Changed("scale") {
+		if err := scaffold.SetScale(val); err != nil {
+			return err
+		}
+	}
+
+	if val, err := flags.GetFloat64("dpi"); err == nil && flags.Changed("dpi") {
+		if err := scaffold.SetDPI(val); err != nil {
+			return err
+		}
+	}
+
+	// Configure glyph rendering quality
+	//
+	if val, err := flags.GetBool("no-antialias"); err == nil {
+		scaffold.SetAntialiasing(!val)
+	}
+
+	if val, err := flags.GetString("hinting"); err == nil {
+		hinting, err := parseHinting(val)
+		if err != nil {
+			return err
+		}
+
+		if err := scaffold.SetHinting(hinting); err != nil {
+			return err
+		}
+	}
+
+	// Apply custom font size if provided, which also applies to custom fonts
+	//
+	if val, err := flags.GetFloat64("font-size"); err == nil && flags.Changed("font-size") {
+		if err := scaffold.SetFontSize(val); err != nil {
+			return err
+		}
+	}
+
+	// Apply custom fonts if provided
+	//
+	features, _ := flags.GetStringSlice("font-features")
+	ligatures, _ := flags.GetBool("ligatures")
+	if fonts, err := flags.GetStringSlice("font"); err == nil && len(fonts) > 0 {
+		scaffold.SetLigatures(ligatures)
+		if err := scaffold.LoadCustomFonts(fonts, features...); err != nil {
+			return fmt.Errorf("failed to load custom fonts: %w", err)
+		}
+
+	} else if len(features) > 0 {
+		return fmt.Errorf("font features can only be used with custom fonts, use --font to load a font")
+
+	} else if ligatures {
+		return fmt.Errorf("ligatures can only be used with custom fonts, use --font to load a font")
+	}
+
+	if fallbacks, err := flags.GetStringSlice("fallback-font"); err == nil && len(fallbacks) > 0 {
+		if err := scaffold.LoadFallbackFonts(fallbacks...); err != nil {
+			return fmt.Errorf("failed to load fallback fonts: %w", err)
+		}
+	}
+
+	// Apply custom colorscheme or built-in theme if provided
+	//
+	if err := exclusiveFlags(flags, "colorscheme", "theme"); err != nil {
+		return err
+	}
+
+	if colorscheme, err := flags.GetString("colorscheme"); err == nil && colorscheme != "" {
+		if theme, _ := flags.GetString("theme"); theme != "" {
+			return fmt.Errorf("colorscheme and theme cannot be used together")
+		}
+
+		format, _ := flags.GetString("scheme-format")
+		if err := scaffold.LoadColorschemeFormat(colorscheme, img.ColorschemeFormat(format)); err != nil {
+			return fmt.Errorf("failed to load colorscheme: %w", err)
+		}
+	}
+
+	if theme, err := flags.GetString("theme"); err == nil && theme != "" {
+		if err := scaffold.LoadTheme(theme); err != nil {
+			return err
+		}
+	}
+
+	if palette, err := flags.GetString("source-palette"); err == nil {
+		if err := scaffold.SetSourcePalette(palette); err != nil {
+			return err
+		}
+	}
+
+	if val, err := flags.GetFloat64("transparent"); err == nil && flags.Changed("transparent") {
+		if err := scaffold.SetWindowOpacity(val); err != nil {
+			return err
+		}
+	}
+
+	if val, err := flags.GetFloat64("dim-opacity"); err == nil && flags.Changed("dim-opacity") {
+		if err := scaffold.SetDimOpacity(val); err != nil {
+			return err
+		}
+	}
+
+	switch value, _ := flags.GetString("cursor"); value {
+	case "none":
+		scaffold.SetCursor(img.CursorNone)
+
+	case "block":
+		scaffold.SetCursor(img.CursorBlock)
+
+	case "bar":
+		scaffold.SetCursor(img.CursorBar)
+
+	case "underline":
+		scaffold.SetCursor(img.CursorUnderline)
+
+	default:
+		return fmt.Errorf("unsupported cursor %q, supported are: block, bar, underline, none", value)
+	}
+
+	switch value, _ := flags.GetString("blink-style"); value {
+	case "bold":
+		scaffold.SetBlinkStyle(img.BlinkBold)
+
+	case "italic":
+		scaffold.SetBlinkStyle(img.BlinkItalic)
+
+	case "none":
+		scaffold.SetBlinkStyle(img.BlinkNone)
+
+	default:
+		return fmt.Errorf("unsupported blink style %q, supported are: bold, italic, none", value)
+	}
+
+	// Apply background behind the window if provided
+	//
+	if value, err := flags.GetString("background"); err == nil && value != "" {
+		background, err := parseBackground(value)
+		if err != nil {
+			return err
+		}
+
+		scaffold.SetBackground(background)
+	}
+
+	// Place the window on a card of fixed size if configured
+	//
+	if value, err := flags.GetString("card"); err == nil && value != "" {
+		width, height, err := parseCardSize(value)
+		if err != nil {
+			return err
+		}
+
+		if err := scaffold.SetCardSize(width, height); err != nil {
+			return err
+		}
+	}
+
+	// Apply redaction of sensitive text if configured
+	//
+	if patterns, err := flags.GetStringArray("redact"); err == nil && len(patterns) > 0 {
+		var style img.RedactStyle
+		switch value, _ := flags.GetString("redact-style"); value {
+		case "block":
+			style = img.RedactBlock
+
+		case "blur":
+			style = img.RedactBlur
+
+		default:
+			return fmt.Errorf("unsupported redact style %q, supported are: block, blur", value)
+		}
+
+		for _, pattern := range patterns {
+			regex, err := regexp.Compile(pattern)
+			if err != nil {
+				return fmt.Errorf("invalid redact pattern %q: %w", pattern, err)
+			}
+
+			scaffold.Redact(regex, style)
+		}
+	}
+
+	// Apply watermark on top of the window if provided
+	//
+	if value, err := flags.GetString("watermark"); err == nil && value != "" {
+		position, _ := flags.GetString("watermark-position")
+		opacity, _ := flags.GetFloat64("watermark-opacity")
+		watermark, err := parseWatermark(value, position, opacity)
+		if err != nil {
+			return err
+		}
+
+		scaffold.SetWatermark(watermark)
+	}
+
+	// Apply custom decorations if provided
+	//
+	if script, err := flags.GetString("decorate"); err == nil && script != "" {
+		decoration, err := decorate.Load(script)
+		if err != nil {
+			return err
+		}
+
+		scaffold.AddDecoration(decoration)
+	}
+
+	// Initialise scaffold with a column sizing so that the
+	// content can be wrapped accordingly
+	//
+	if columns, err := flags.GetInt("columns"); err == nil && columns > 0 {
+		scaffold.SetColumns(columns)
+	}
+
+	if val, err := flags.GetString("lines"); err == nil && val != "" {
+		first, last, err := parseLineRange(val)
+		if err != nil {
+			return fmt.Errorf("invalid line range: %w", err)
+		}
+
+		scaffold.SetLineRange(first, last)
+	}
+
+	if maxLines, err := flags.GetInt("max-lines"); err == nil && maxLines > 0 {
+		scaffold.SetMaxLines(maxLines)
+	}
+
+	switch value, _ := flags.GetString("max-lines-keep"); value {
+	case "first":
+		scaffold.KeepFirstLines(true)
+
+	case "last":
+		scaffold.KeepFirstLines(false)
+
+	default:
+		return fmt.Errorf("unsupported lines to keep %q, supported are: first, last", value)
+	}
+
+	switch value, _ := flags.GetString("max-lines-marker"); value {
+	case "separator":
+		scaffold.SetTruncationMarker(img.TruncationSeparator)
+
+	case "fade":
+		scaffold.SetTruncationMarker(img.TruncationFade)
+
+	case "none":
+		scaffold.SetTruncationMarker(img.TruncationNone)
+
+	default:
+		return fmt.Errorf("unsupported marker for left out lines %q, supported are: separator, fade, none", value)
+	}
+
+	switch value, _ := flags.GetString("wrap"); value {
+	case "char":
+		scaffold.SetWrap(img.WrapCharacter)
+
+	case "word":
+		scaffold.SetWrap(img.WrapWord)
+
+	case "none":
+		scaffold.SetWrap(img.WrapNone)
+
+	default:
+		return fmt.Errorf("unsupported wrap mode %q, supported are: char, word, none", value)
+	}
+
+	if flags.Changed("prompt-symbol") {
+		symbol, _ := flags.GetString("prompt-symbol")
+		scaffold.SetCommandIndicator(symbol)
+	}
+
+	if style, err := flags.GetString("prompt-style"); err == nil {
+		if err := scaffold.SetCommandIndicatorStyle(style); err != nil {
+			return err
+		}
+	}
+
+	if template, err := flags.GetString("prompt-template"); err == nil && template != "" {
+		if err := scaffold.SetPromptTemplate(template); err != nil {
+			return err
+		}
+	}
+
+	if tabWidth, err := flags.GetInt("tab-width"); err == nil {
+		if tabWidth < 1 {
+			return fmt.Errorf("invalid tab width %d, it has to be one column or more", tabWidth)
+		}
+
+		scaffold.SetTabWidth(tabWidth)
+	}
+
+	if flags.Changed("padding") {
+		if val, err := flags.GetString("padding"); err == nil {
+			top, right, bottom, left, err := parseBox(val)
+			if err != nil {
+				return fmt.Errorf("invalid padding: %w", err)
+			}
+			scaffold.SetPadding(top, right, bottom, left)
+		}
+	}
+
+	if val, err := flags.GetFloat64("corner-radius"); err == nil && flags.Changed("corner-radius") {
+		if val < 0 {
+			return fmt.Errorf("invalid corner radius %v, expected zero or a positive number", val)
+		}
+
+		scaffold.SetCornerRadius(val)
+	}
+
+	minWidth, _ := flags.GetFloat64("min-width")
+	minHeight, _ := flags.GetFloat64("min-height")
+	if err := scaffold.SetMinSize(minWidth, minHeight); err != nil {
+		return err
+	}
+
+	if val, err := flags.GetString("aspect-ratio"); err == nil && val != "" {
+		ratio, err := parseAspectRatio(val)
+		if err != nil {
+			return err
+		}
+
+		if err := scaffold.SetAspectRatio(ratio); err != nil {
+			return err
+		}
+	}
+
+	var horizontal, vertical img.Alignment
+	switch value, _ := flags.GetString("align"); value {
+	case "left":
+	case "center":
+		horizontal = img.AlignCenter
+
+	default:
+		return fmt.Errorf("unsupported alignment %q, supported are: left, center", value)
+	}
+
+	switch value, _ := flags.GetString("vertical-align"); value {
+	case "top":
+	case "center":
+		vertical = img.AlignCenter
+
+	default:
+		return fmt.Errorf("unsupported vertical alignment %q, supported are: top, center", value)
+	}
+
+	scaffold.SetAlignment(horizontal, vertical)
+
+	if flags.Changed("margin") {
+		if val, err := flags.GetString("margin"); err == nil {
+			top, right, bottom, left, err := parseBox(val)
+			if err != nil {
+				return fmt.Errorf("invalid margin: %w", err)
+			}
+			scaffold.SetMargin(top, right, bottom, left)
+		}
+	}
+
+	// Show line numbers in a gutter if requested
+	//
+	if val, err := flags.GetBool("line-numbers"); err == nil {
+		scaffold.ShowLineNumbers(val)
+	}
+
+	// Disable window shadow if requested
+	//
+	if val, err := flags.GetBool("no-shadow"); err == nil {
+		scaffold.DrawShadow(!val)
+	}
+
+	// Disable window decorations (buttons) if requested
+	//
+	if val, err := flags.GetBool("no-decoration"); err == nil {
+		scaffold.DrawDecorations(!val)
+	}
+
+	if val, err := flags.GetBool("no-border"); err == nil {
+		scaffold.DrawBorder(!val)
+	}
+
+	if flags.Changed("border-color") || flags.Changed("border-width") {
+		var style img.BorderStyle
+		style.Width, _ = flags.GetFloat64("border-width")
+
+		if value, _ := flags.GetString("border-color"); value != "" {
+			outer, inner, twoTone := strings.Cut(value, ",")
+
+			var err error
+			if style.Color, err = img.ParseHexColor(strings.TrimSpace(outer)); err != nil {
+				return fmt.Errorf("invalid border color %q: %w", outer, err)
+			}
+
+			if twoTone {
+				if style.InnerColor, err = img.ParseHexColor(strings.TrimSpace(inner)); err != nil {
+					return fmt.Errorf("invalid inner border color %q: %w", inner, err)
+				}
+			}
+		}
+
+		if err := scaffold.SetBorderStyle(style); err != nil {
+			return err
+		}
+	}
+
+	// Configure that canvas is clipped at the end
+	//
+	if val, err := flags.GetBool("clip-canvas"); err == nil {
+		scaffold.ClipCanvas(val)
+	}
+
+	if val, err := flags.GetBool("trim-whitespace"); err == nil {
+		scaffold.TrimWhitespace(val)
+	}
+
+	if val, err := flags.GetBool("trim"); err == nil {
+		scaffold.TrimBlankLines(val)
+	}
+
+	return nil
+}
+
+func parseHinting(raw string) (font.Hinting, error) {
+	switch raw {
+	case "none":
+		return font.HintingNone, nil
+
+	case "vertical":
+		return font.HintingVertical, nil
+
+	case "full":
+		return font.HintingFull, nil
+
+	default:
+		return font.HintingNone, fmt.Errorf("unsupported hinting %q, expected none, vertical, or full", raw)
+	}
+}
+
+// sessionCommands splits the arguments into the commands, which are
+// separated by ++ in case multiple commands are run one after another
+func sessionCommands(args []string) ([][]string, error) {
+	var commands [][]string
+	var command []string
+	for _, arg := range append(args, commandSeparator) {
+		if arg != commandSeparator {
+			command = append(command, arg)
+			continue
+		}
+
+		if len(command) == 0 && len(args) > 0 {
+			return nil, fmt.Errorf("empty command, %s has to be in between two commands", commandSeparator)
+		}
+
+		commands, command = append(commands, command), nil
+	}
+
+	return commands, nil
+}
+
+// session is the outcome of running the commands of a session
+type session struct {
+	// attempts is the highest number of attempts it took to run one of
+	// the commands
+	attempts int
+
+	// exitCode is the first non-zero exit code of the commands, so that a
+	// failing command is not hidden by the commands after it
+	exitCode int
+
+	duration time.Duration
+	timedOut bool
+}
+
+// runSession runs the commands one after another in the pseudo terminal and
+// writes their output to the buffer, in case a command fails, it is retried
+// and only the output of its last attempt is used
+func runSession(pt *ptexec.PseudoTerminal, scaffold *img.Scaffold, buf *bytes.Buffer, commands [][]string, retries int, retryDelay time.Duration, interactive bool) (session, error) {
+	var result session
+	for _, command := range commands {
+		// Multiple commands are shown like a session, where each
+		// command line is followed by the output of the command
+		if len(commands) > 1 {
+			if buf.Len() > 0 && !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+				buf.WriteString("\r\n")
+			}
+
+			buf.WriteString(scaffold.CommandLine(command...) + "\r\n")
+		}
+
+		for attempt := 1; ; attempt++ {
+			output, err := pt.Command(command[0], command[1:]...).Run()
+			if err != nil {
+				return result, fmt.Errorf("failed to run command in pseudo terminal: %w", err)
+			}
+
+			if pt.ExitCode() == 0 || attempt > retries || interactive {
+				buf.Write(output)
+				result.attempts = max(result.attempts, attempt)
+				if result.exitCode == 0 {
+					result.exitCode = pt.ExitCode()
+				}
+
+				result.duration += pt.Duration()
+				result.timedOut = result.timedOut || pt.TimedOut()
+				break
+			}
+
+			time.Sleep(retryDelay)
+		}
+	}
+
+	return result, nil
+}
+
+// formatDuration returns the duration rounded to a precision that is
+// readable, e.g. 3.4s or 120ms
+func formatDuration(d time.Duration) string {
+	if d < time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+
+	return d.Round(100 * time.Millisecond).String()
+}
+
+// parseScrollback parses the number of lines to keep above the screen, where
+// full keeps all of them, and screen none
+func parseScrollback(raw string) (int, error) {
+	switch raw {
+	case "full":
+		return -1, nil
+
+	case "screen":
+		return 0, nil
+	}
+
+	lines, err := strconv.Atoi(raw)
+	if err != nil || lines < 0 {
+		return 0, fmt.Errorf("unsupported scrollback %q, supported are: full, screen, or a number of lines", raw)
+	}
+
+	return lines, nil
+}
+
+// parseLineRange parses a range of lines like 20:80, where either end can be
+// left out, and returns zero for an open end
+func parseLineRange(raw string) (first, last int, err error) {
+	start, end, found := strings.Cut(raw, ":")
+	if !found {
+		return 0, 0, fmt.Errorf("expected first and last line separated by a colon, e.g. 20:80")
+	}
+
+	parse := func(text string) (int, error) {
+		if text == "" {
+			return 0, nil
+		}
+
+		line, err := strconv.Atoi(strings.TrimSpace(text))
+		if err != nil || line < 1 {
+			return 0, fmt.Errorf("line %q is not a number of one or more", text)
+		}
+
+		return line, nil
+	}
+
+	if first, err = parse(start); err != nil {
+		return 0, 0, err
+	}
+
+	if last, err = parse(end); err != nil {
+		return 0, 0, err
+	}
+
+	if last > 0 && first > last {
+		return 0, 0, fmt.Errorf("first line %d is after last line %d", first, last)
+	}
+
+	return first, last, nil
+}
+
+// parseAspectRatio parses an aspect ratio, which is either width and height
+// separated by a colon, e.g. 16:9, or the ratio as a number
+func parseAspectRatio(raw string) (float64, error) {
+	invalid := fmt.Errorf("invalid aspect ratio %q, expected width:height (e.g. 16:9) or a number", raw)
+
+	w, h, found := strings.Cut(raw, ":")
+	if !found {
+		ratio, err := strconv.ParseFloat(raw, 64)
+		if err != nil || ratio <= 0 {
+			return 0, invalid
+		}
+
+		return ratio, nil
+	}
+
+	width, err := strconv.ParseFloat(w, 64)
+	if err != nil || width <= 0 {
+		return 0, invalid
+	}
+
+	height, err := strconv.ParseFloat(h, 64)
+	if err != nil || height <= 0 {
+		return 0, invalid
+	}
+
+	return width / height, nil
+}
+
+// cardSizes are the sizes of cards that can be used by name
+var cardSizes = map[string][2]int{
+	"og":      {1200, 630},
+	"twitter": {1200, 675},
+}
+
+// parseCardSize parses the size of a card, which is either the name of a
+// common size or width and height in pixels, e.g. 1200x630
+func parseCardSize(raw string) (width, height int, err error) {
+	if size, ok := cardSizes[raw]; ok {
+		return size[0], size[1], nil
+	}
+
+	w, h, found := strings.Cut(strings.ToLower(raw), "x")
+	if !found {
+		return 0, 0, fmt.Errorf("invalid card size %q, expected width x height in pixels (e.g. 1200x630) or one of: %s", raw, strings.Join(sortedKeys(cardSizes), ", "))
+	}
+
+	if width, err = strconv.Atoi(w); err != nil || width <= 0 {
+		return 0, 0, fmt.Errorf("invalid card width %q", w)
+	}
+
+	if height, err = strconv.Atoi(h); err != nil || height <= 0 {
+		return 0, 0, fmt.Errorf("invalid card height %q", h)
+	}
+
+	return width, height, nil
+}
+
+// checkCardFormat returns an error in case a card is configured for an
+// output file that is not a raster image, which cards only apply to
+func checkCardFormat(flags *pflag.FlagSet) error {
+	card, _ := flags.GetString("card")
+	filename, _ := flags.GetString("filename")
+
+	extension := strings.ToLower(filepath.Ext(filename))
+	if card != "" && !slices.Contains([]string{".png", ".jpg", ".jpeg", ".webp", ".gif", ".apng"}, extension) {
+		return fmt.Errorf("cards can only be created as png, jpg, webp, gif, or apng images, not as %s", strings.TrimPrefix(extension, "."))
+	}
+
+	return nil
+}
+
 func parseBox(raw string) (top, right, bottom, left float64, err error) {
 	parts := strings.Split(raw, ",")
 	vals := make([]float64, 0, len(parts))
@@ -359,30 +1502,58 @@ func parseBox(raw string) (top, right, bottom, left float64, err error) {
 }
 
 func init() {
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
+	rootCmd.PersistentPreRunE = configure
 	rootCmd.Flags().SortFlags = false
 
 	// flags to control content
 	rootCmd.Flags().BoolP("edit", "e", false, "edit content before creating screenshot")
+	rootCmd.Flags().Bool("interactive", false, "record an interactive session of the user's shell and create the screenshot once the shell exits")
+	rootCmd.Flags().Int("retries", 0, "number of times to re-run the command in case it fails")
+	rootCmd.Flags().Duration("retry-delay", time.Second, "time to wait before re-running a failed command")
+	rootCmd.Flags().Bool("show-attempts", false, "include number of command attempts in screenshot")
+	rootCmd.Flags().Bool("show-duration", false, "include how long the command took to run in screenshot")
+	rootCmd.Flags().Duration("timeout", 0, "kill the command and its child processes if it runs longer than this, e.g. 30s (default is no timeout)")
+	rootCmd.Flags().Bool("show-timeout", false, "include a note in screenshot in case the command was killed after the timeout")
+	rootCmd.Flags().String("timestamp", "", "include the time of the capture in the title bar, optionally with a Go time layout (default 2006-01-02 15:04:05 MST)")
+	rootCmd.Flags().Lookup("timestamp").NoOptDefVal = "2006-01-02 15:04:05 MST"
+	rootCmd.Flags().Bool("show-exit-code", false, "include exit code of the command with a success or failure badge in screenshot")
+	rootCmd.Flags().String("stderr-style", "", "capture standard error separately and style it: color (#rrggbb), bold, dim, italic, underline, or marker, e.g. italic,#ff5555")
+	rootCmd.Flags().String("scrollback", "", "render the output on a screen with the height of the terminal: full keeps all lines scrolled out of it, screen none, or a number of lines (default is all output)")
+	rootCmd.Flags().Bool("detect-prompts", false, "style prompt lines of transcripts read with --raw-read like the command")
+	rootCmd.Flags().StringSlice("prompt-pattern", nil, "regular expression to detect prompt lines (implies --detect-prompts)")
 
 	// flags to control look
-	rootCmd.Flags().BoolP("show-cmd", "c", false, "include command in screenshot")
-	rootCmd.Flags().IntP("columns", "C", 0, "force fixed number of columns in screenshot")
-	rootCmd.Flags().Bool("no-decoration", false, "do not draw window decorations")
-	rootCmd.Flags().Bool("no-shadow", false, "do not draw window shadow")
-	rootCmd.Flags().Bool("no-border", false, "do not draw outer window border")
-	rootCmd.Flags().String("padding", "", "set padding in pixels (t,r,b,l)")
-	rootCmd.Flags().String("margin", "", "set margin in pixels (t,r,b,l)")
-	rootCmd.Flags().BoolP("clip-canvas", "s", false, "clip canvas to visible image area (no margin)")
-	rootCmd.Flags().StringSlice("font", nil, "custom font files (TTF/OTF) to use instead of default Hack font")
-	rootCmd.Flags().String("colorscheme", "", "JSON file with custom color scheme (color0-color15)")
+	addLookFlags(rootCmd.Flags())
 
 	// flags for output related settings
 	rootCmd.Flags().StringP("filename", "f", "out.png", "filename of the screenshot")
+	rootCmd.Flags().VarP(rootCmd.Flags().Lookup("filename").Value, "output", "o", "alias for --filename")
+	rootCmd.Flags().Bool("no-clobber", false, "fail instead of replacing the file if it already exists")
+	rootCmd.Flags().String("preview", "", "display the screenshot in the terminal using the kitty, iterm, or sixel protocol (default is auto detection)")
+	rootCmd.Flags().Lookup("preview").NoOptDefVal = "auto"
+	rootCmd.Flags().Int("quality", 0, "quality of JPEG and WebP images from 1 to 100 (default is 90 for JPEG and all colors for WebP)")
+	rootCmd.Flags().Int("page-lines", 0, "number of lines per page of PDF documents (default is all lines on one page)")
+	rootCmd.Flags().Bool("embed-content", false, "embed content and settings into PNG images, so that they can be rendered again using the rerender command")
+	rootCmd.Flags().Bool("deterministic", false, "create the same image for the same input by ignoring the terminal size, environment, config file, and current time, e.g. for snapshot tests")
+	rootCmd.Flags().String("alt-text", "", "write content as plain text to file to be used as image alternative text")
+	rootCmd.Flags().Bool("alt-text-summary", false, "start alternative text with a one-line summary")
+	rootCmd.Flags().String("dump-cells", "", "write parsed content with colors and attributes of each character as JSON to file")
+	rootCmd.Flags().Bool("lint", false, "report all escape sequences of the content that are not fully supported")
+	rootCmd.Flags().Bool("report-colors", false, "report all colors used in the screenshot and how they were mapped")
+	addCacheFlags(rootCmd.Flags())
 
 	// flags for raw output processing
 	rootCmd.Flags().String("raw-write", "", "write raw output to file instead of creating a screenshot")
 	rootCmd.Flags().String("raw-read", "", "read raw input from file instead of executing a command")
+	rootCmd.Flags().String("diff-file", "", "read patch in unified diff format from file (- for stdin) and color it like a diff instead of executing a command")
+	rootCmd.Flags().String("tmux-pane", "", "capture content of tmux pane (e.g. %3) instead of executing a command")
+	addCastFlags(rootCmd.Flags())
+	rootCmd.Flags().String("pre-hook", "", "shell command to run before the capture")
+	rootCmd.Flags().String("post-hook", "", "shell command to run after the screenshot is written, with the file in $TERMSHOT_OUTPUT")
+	rootCmd.Flags().Bool("json-jobs", false, "read JSON jobs from standard input, one per line, and write a JSON result record for each")
 
 	// internals
 	rootCmd.Flags().BoolP("version", "v", false, "show version")
+	rootCmd.PersistentFlags().String("config", "", "configuration file with default flag values (default is termshot/config.yaml in the user config directory)")
 }
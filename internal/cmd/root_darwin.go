@@ -27,7 +27,7 @@ import (
 	"os"
 	"os/exec"
 
-	"github.com/homeport/termshot/internal/img"
+	"github.com/homeport/termshot/pkg/img"
 )
 
 const osascript = "/usr/bin/osascript"
@@ -43,14 +43,11 @@ func hasOsascript() bool {
 
 func init() {
 	if hasOsascript() {
-		// register tool flag to enable clipboard option
-		rootCmd.Flags().BoolP("clipboard", "b", false, "copy termshot to clipboard, overrules filename option")
-
-		// register function to copy image into the clipboard
-		saveToClipboard = func(scaffold img.Scaffold) error {
+		// register tool flag and function to copy image into the clipboard
+		registerClipboard(func(scaffold img.Scaffold) error {
 			var buf bytes.Buffer
 
-			if _, err := buf.WriteString("set the clipboard to «data PICT"); err != nil {
+			if _, err := buf.WriteString("set the clipboard to «data PNGf"); err != nil {
 				return err
 			}
 
@@ -70,6 +67,6 @@ func init() {
 			}
 
 			return nil
-		}
+		})
 	}
 }
@@ -0,0 +1,66 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/homeport/termshot/pkg/img"
+)
+
+// clipboardCommand returns the command to copy a PNG image from stdin into
+// the clipboard, preferring Wayland over X11 if both are available
+func clipboardCommand() []string {
+	if _, err := exec.LookPath("wl-copy"); err == nil && os.Getenv("WAYLAND_DISPLAY") != "" {
+		return []string{"wl-copy", "--type", "image/png"}
+	}
+
+	if _, err := exec.LookPath("xclip"); err == nil && os.Getenv("DISPLAY") != "" {
+		return []string{"xclip", "-selection", "clipboard", "-target", "image/png", "-in"}
+	}
+
+	return nil
+}
+
+func init() {
+	if args := clipboardCommand(); args != nil {
+		// register tool flag and function to copy image into the clipboard
+		registerClipboard(func(scaffold img.Scaffold) error {
+			var buf bytes.Buffer
+			if err := scaffold.WritePNG(&buf); err != nil {
+				return err
+			}
+
+			cmd := exec.Command(args[0], args[1:]...) // #nosec G204
+			cmd.Stdin = &buf
+			out, err := cmd.CombinedOutput()
+			if err != nil {
+				fmt.Fprint(os.Stderr, string(out))
+				return err
+			}
+
+			return nil
+		})
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/pflag"
+
+	"github.com/homeport/termshot/internal/ptexec"
+	"github.com/homeport/termshot/pkg/img"
+)
+
+var _ = Describe("Output files", func() {
+	var dir string
+
+	var outputFlags = func(args ...string) *pflag.FlagSet {
+		flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		flags.StringP("filename", "f", filepath.Join(dir, "out.png"), "")
+		flags.VarP(flags.Lookup("filename").Value, "output", "o", "")
+		flags.Bool("no-clobber", false, "")
+		Expect(flags.Parse(args)).To(Succeed())
+		return flags
+	}
+
+	var touch = func(name string) {
+		Expect(os.WriteFile(filepath.Join(dir, name), nil, 0o600)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+	})
+
+	It("should number the default filename instead of replacing existing files", func() {
+		Expect(outputFilename(outputFlags())).To(Equal(filepath.Join(dir, "out.png")))
+
+		touch("out.png")
+		touch("out-1.png")
+		Expect(outputFilename(outputFlags())).To(Equal(filepath.Join(dir, "out-2.png")))
+	})
+
+	It("should replace an explicitly set file", func() {
+		touch("shot.png")
+		Expect(outputFilename(outputFlags("-f", filepath.Join(dir, "shot.png")))).To(Equal(filepath.Join(dir, "shot.png")))
+		Expect(outputFilename(outputFlags("-o", filepath.Join(dir, "shot.png")))).To(Equal(filepath.Join(dir, "shot.png")))
+	})
+
+	It("should fail for an existing explicitly set file with no-clobber", func() {
+		touch("shot.png")
+		_, err := outputFilename(outputFlags("--no-clobber", "-f", filepath.Join(dir, "shot.png")))
+		Expect(err).To(MatchError(ContainSubstring("already exists")))
+
+		Expect(outputFilename(outputFlags("--no-clobber", "-f", filepath.Join(dir, "new.png")))).To(Equal(filepath.Join(dir, "new.png")))
+	})
+
+	It("should number the default filename with no-clobber", func() {
+		touch("out.png")
+		Expect(outputFilename(outputFlags("--no-clobber"))).To(Equal(filepath.Join(dir, "out-1.png")))
+	})
+
+	It("should fail for unsupported file extensions", func() {
+		_, err := outputFilename(outputFlags("-f", filepath.Join(dir, "shot.txt")), ".png", ".svg")
+		Expect(err).To(MatchError(ContainSubstring("supported are: png, svg")))
+	})
+
+	It("should not replace a file that was created after the filename was checked", func() {
+		flags := outputFlags("--no-clobber", "-f", filepath.Join(dir, "sub", "shot.png"))
+		filename, err := outputFilename(flags)
+		Expect(err).ToNot(HaveOccurred())
+
+		file, err := openOutputFile(flags, filename)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(file.Close()).To(Succeed())
+
+		_, err = openOutputFile(flags, filename)
+		Expect(err).To(MatchError(ContainSubstring("already exists")))
+	})
+})
+
+var _ = Describe("Sessions", func() {
+	var run = func(retries int, args ...string) (session, string) {
+		commands, err := sessionCommands(args)
+		Expect(err).ToNot(HaveOccurred())
+
+		var buf bytes.Buffer
+		scaffold := img.NewImageCreator()
+		pt := ptexec.New().Stdin(nil).Stdout(io.Discard)
+		result, err := runSession(pt, &scaffold, &buf, commands, retries, 0, false)
+		Expect(err).ToNot(HaveOccurred())
+		return result, buf.String()
+	}
+
+	It("should show each command line followed by its output", func() {
+		_, output := run(0, "echo", "one", "++", "echo", "two")
+		Expect(output).To(MatchRegexp(`(?s)echo one.*\r\none\r\n.*echo two.*\r\ntwo\r\n`))
+	})
+
+	It("should retry each command and report the highest number of attempts", func() {
+		counter := filepath.Join(GinkgoT().TempDir(), "counter")
+		flaky := "echo >> " + counter + "; test $(wc -l < " + counter + ") -ge 2"
+
+		result, _ := run(3, "sh", "-c", flaky, "++", "true")
+		Expect(result.attempts).To(Equal(2))
+		Expect(result.exitCode).To(Equal(0))
+	})
+	It("should report the first non-zero exit code of the commands", func() {
+		result, _ := run(0, "sh", "-c", "exit 3", "++", "sh", "-c", "exit 4", "++", "true")
+		Expect(result.attempts).To(Equal(1))
+		Expect(result.exitCode).To(Equal(3))
+
+		result, _ = run(0, "true", "++", "sh", "-c", "exit 5")
+		Expect(result.exitCode).To(Equal(5))
+	})
+})
@@ -0,0 +1,160 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/homeport/termshot/internal/ptexec"
+	"github.com/homeport/termshot/pkg/img"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var themesCmd = &cobra.Command{
+	Use:   "themes",
+	Short: "Lists built-in themes and compares color schemes",
+	Args:  cobra.NoArgs,
+}
+
+var themesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists all built-in themes",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		for _, name := range img.Themes() {
+			_, _ = fmt.Fprintln(cmd.OutOrStdout(), name)
+		}
+
+		return nil
+	},
+}
+
+var themesGridCmd = &cobra.Command{
+	Use:   "grid [flags] colorscheme|theme [colorscheme|theme] [...]",
+	Short: "Renders a grid with the same content in multiple color schemes",
+	Long: `Renders the same content using each of the provided color schemes and
+combines all screenshots into one image, so that the color schemes can be
+compared visually. Arguments can be color scheme files or names of
+built-in themes. Each screenshot is titled with the name of the color
+scheme file or theme. By default, a sample content is used, use the command flag
+to capture the output of a command, or the raw-read flag to use the
+content of a file instead.
+`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		content := []byte(sampleContent)
+
+		if rawRead, err := cmd.Flags().GetString("raw-read"); err == nil && rawRead != "" {
+			if content, err = readFile(rawRead); err != nil {
+				return fmt.Errorf("failed to read contents: %w", err)
+			}
+		}
+
+		if command, err := cmd.Flags().GetString("command"); err == nil && command != "" {
+			pt := ptexec.New()
+			if columns, err := cmd.Flags().GetInt("columns"); err == nil && columns > 0 {
+				pt.Cols(uint16(columns))
+			}
+
+			if content, err = pt.Command(command).Run(); err != nil {
+				return fmt.Errorf("failed to run command in pseudo terminal: %w", err)
+			}
+		}
+
+		// Unknown names are reported before any screenshot is rendered
+		for _, colorscheme := range args {
+			if _, err := os.Stat(colorscheme); err != nil && !isTheme(colorscheme) {
+				return fmt.Errorf("unknown theme or color scheme file %q, available themes are: %s", colorscheme, strings.Join(img.Themes(), ", "))
+			}
+		}
+
+		var images []image.Image
+		for _, colorscheme := range args {
+			name := strings.TrimSuffix(filepath.Base(colorscheme), filepath.Ext(colorscheme))
+
+			values := map[string]string{"colorscheme": colorscheme, "theme": ""}
+			if isTheme(colorscheme) {
+				values = map[string]string{"colorscheme": "", "theme": colorscheme}
+			}
+
+			image, err := renderVariant(cmd.Flags(), name, content, values)
+			if err != nil {
+				return err
+			}
+
+			images = append(images, image)
+		}
+
+		columns, _ := cmd.Flags().GetInt("grid-columns")
+
+		file, err := createOutputFile(cmd.Flags())
+		if err != nil {
+			return err
+		}
+
+		defer func() { _ = file.Close() }()
+		return png.Encode(file, img.Grid(columns, images...))
+	},
+}
+
+// isTheme returns whether the argument refers to a built-in theme rather
+// than a color scheme file
+func isTheme(arg string) bool {
+	if _, err := os.Stat(arg); err == nil {
+		return false
+	}
+
+	return slices.Contains(img.Themes(), arg)
+}
+
+func init() {
+	rootCmd.AddCommand(themesCmd)
+	themesCmd.AddCommand(themesListCmd)
+	themesCmd.AddCommand(themesGridCmd)
+
+	themesGridCmd.Flags().SortFlags = false
+	addThemesGridFlags(themesGridCmd.Flags())
+}
+
+// addThemesGridFlags adds the flags of the themes grid command
+func addThemesGridFlags(flags *pflag.FlagSet) {
+	// flags to control content
+	flags.String("command", "", "command to capture the output from instead of using sample content")
+
+	// flags to control look
+	addLookFlags(flags)
+	flags.Int("grid-columns", 3, "number of screenshots per row in the grid")
+
+	// flags for output related settings
+	flags.StringP("filename", "f", "out.png", "filename of the screenshot")
+	flags.Bool("no-clobber", false, "fail instead of replacing the file if it already exists")
+
+	// flags for raw output processing
+	flags.String("raw-read", "", "read raw input from file instead of using sample content")
+}
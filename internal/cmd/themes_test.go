@@ -0,0 +1,87 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/homeport/termshot/pkg/img"
+)
+
+var _ = Describe("Themes", func() {
+	var dir string
+
+	var grid = func(args ...string) error {
+		cmd := &cobra.Command{Args: themesGridCmd.Args, RunE: themesGridCmd.RunE, SilenceUsage: true, SilenceErrors: true}
+		addThemesGridFlags(cmd.Flags())
+		cmd.SetArgs(append(args, "--filename", filepath.Join(dir, "grid.png")))
+		return cmd.Execute()
+	}
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+	})
+
+	It("should render the content in each theme next to each other", func() {
+		Expect(grid("nord", "dracula", "--margin", "0")).To(Succeed())
+
+		file, err := os.Open(filepath.Join(dir, "grid.png"))
+		Expect(err).ToNot(HaveOccurred())
+		defer func() { _ = file.Close() }()
+
+		sheet, err := png.Decode(file)
+		Expect(err).ToNot(HaveOccurred())
+
+		var images []image.Image
+		flags := pflag.NewFlagSet("themes", pflag.ContinueOnError)
+		addLookFlags(flags)
+		Expect(flags.Parse([]string{"--margin", "0"})).To(Succeed())
+
+		for _, theme := range []string{"nord", "dracula"} {
+			image, err := renderVariant(flags, theme, []byte(sampleContent), map[string]string{"theme": theme})
+			Expect(err).ToNot(HaveOccurred())
+			images = append(images, image)
+		}
+
+		Expect(sheet.Bounds()).To(Equal(img.Grid(3, images...).Bounds()))
+		Expect(sheet.Bounds().Dx()).To(Equal(images[0].Bounds().Dx() + images[1].Bounds().Dx()))
+	})
+
+	It("should use theme and color scheme files together", func() {
+		colorscheme := filepath.Join(dir, "cs.json")
+		Expect(os.WriteFile(colorscheme, []byte(`{"colors": {"color1": "#ff0000"}}`), 0o600)).To(Succeed())
+		Expect(grid("nord", colorscheme)).To(Succeed())
+	})
+
+	It("should fail for unknown themes before rendering", func() {
+		err := grid("nord", "unknown")
+		Expect(err).To(MatchError(ContainSubstring(`unknown theme or color scheme file "unknown", available themes are: `)))
+		Expect(filepath.Join(dir, "grid.png")).ToNot(BeAnExistingFile())
+	})
+})
@@ -0,0 +1,452 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gonvenience/bunt"
+
+	"github.com/homeport/termshot/pkg/img"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"golang.org/x/term"
+)
+
+// tuneKeys describes the keys of the tune command shown as help
+const tuneKeys = "d decorations · s shadow · b border · +/- padding · t/T theme · f/F font · [/] font size · w save preset · q quit"
+
+var tuneCmd = &cobra.Command{
+	Use:   "tune [flags]",
+	Short: "Tunes the look settings interactively with a live preview",
+	Long: `Opens an interactive view to tune the look of the screenshot. Keys toggle
+the window decorations, shadow, and border, adjust the padding, and switch
+the theme and font. Every change renders the screenshot again, which is
+shown in the terminal if it supports inline images, and written to the file
+if the filename flag is set. The result can be saved as a preset to be used
+with the preset flag afterwards.
+
+By default, a sample content is used, use the raw-read flag to use the
+content of a file instead.
+`,
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		if !term.IsTerminal(int(os.Stdin.Fd())) {
+			return fmt.Errorf("tune requires an interactive terminal")
+		}
+
+		content := []byte(sampleContent)
+		if rawRead, err := cmd.Flags().GetString("raw-read"); err == nil && rawRead != "" {
+			if content, err = readFile(rawRead); err != nil {
+				return fmt.Errorf("failed to read contents: %w", err)
+			}
+		}
+
+		t, err := newTuner(cmd.Flags(), content)
+		if err != nil {
+			return err
+		}
+
+		if t.protocol == "" && t.filename == "" {
+			return fmt.Errorf("terminal is not known to support inline images, use --preview with %s, %s, or %s to select a protocol, or --filename to write the screenshot to a file", previewKitty, previewITerm, previewSixel)
+		}
+
+		oldState, err := term.MakeRaw(int(os.Stdin.Fd()))
+		if err != nil {
+			return err
+		}
+
+		defer func() { _ = term.Restore(int(os.Stdin.Fd()), oldState) }()
+		return t.run(os.Stdin, cmd.OutOrStdout())
+	},
+}
+
+// tuner is the state of the tune command, where the look settings are kept
+// as flag values, so that they can be saved as a preset
+type tuner struct {
+	flags   *pflag.FlagSet
+	content []byte
+
+	protocol string
+	filename string
+
+	// themes and fonts to switch between, where the first entry is the
+	// default colors and the default Hack font
+	themes, fonts []string
+	theme, font   int
+
+	padding  float64
+	fontSize float64
+
+	// naming is set while the name of the preset to save is typed
+	naming bool
+	name   string
+
+	message string
+}
+
+func newTuner(flags *pflag.FlagSet, content []byte) (*tuner, error) {
+	t := &tuner{
+		flags:   pflag.NewFlagSet("tune", pflag.ContinueOnError),
+		content: content,
+		themes:  append([]string{""}, img.Themes()...),
+		fonts:   []string{""},
+		padding: 24,
+	}
+
+	addLookFlags(t.flags)
+	if err := copyChangedFlags(t.flags, flags); err != nil {
+		return nil, err
+	}
+
+	// The settings of the preset are the starting point for tuning
+	if name, _ := t.flags.GetString("preset"); name != "" {
+		if err := applyPreset(t.flags, name); err != nil {
+			return nil, err
+		}
+
+		if err := setFlagValues(t.flags, "preset", ""); err != nil {
+			return nil, err
+		}
+	}
+
+	if flags.Changed("filename") {
+		t.filename, _ = flags.GetString("filename")
+		if extension := strings.ToLower(filepath.Ext(t.filename)); extension != ".png" {
+			return nil, fmt.Errorf("file extension %q of filename %q is not supported, supported are: png", extension, t.filename)
+		}
+	}
+
+	switch protocol, _ := flags.GetString("preview"); protocol {
+	case "auto":
+		t.protocol = detectPreviewProtocol()
+
+	case previewKitty, previewITerm, previewSixel:
+		t.protocol = protocol
+
+	default:
+		return nil, fmt.Errorf("unsupported preview protocol %q, supported are: auto, %s, %s, %s", protocol, previewKitty, previewITerm, previewSixel)
+	}
+
+	if theme, _ := t.flags.GetString("theme"); theme != "" {
+		t.theme = max(slices.Index(t.themes, theme), 0)
+	}
+
+	fontChoices, _ := flags.GetStringArray("font-choice")
+	if fonts, _ := t.flags.GetStringSlice("font"); len(fonts) > 0 {
+		fontChoices = append([]string{strings.Join(fonts, ",")}, fontChoices...)
+		t.font = 1
+	}
+
+	for _, font := range fontChoices {
+		if !slices.Contains(t.fonts, font) {
+			t.fonts = append(t.fonts, font)
+		}
+	}
+
+	if t.flags.Changed("padding") {
+		padding, _ := t.flags.GetString("padding")
+		top, _, _, _, err := parseBox(padding)
+		if err != nil {
+			return nil, fmt.Errorf("invalid padding: %w", err)
+		}
+
+		t.padding = top
+	}
+
+	t.fontSize, _ = t.flags.GetFloat64("font-size")
+	return t, nil
+}
+
+// run reads keys from the input and renders the screenshot again after each
+// change until the user quits
+func (t *tuner) run(in io.Reader, out io.Writer) error {
+	t.refresh(out)
+
+	buf := make([]byte, 64)
+	for {
+		n, err := in.Read(buf)
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+
+			return err
+		}
+
+		// Escape sequences of special keys, e.g. arrow keys, are ignored
+		if n > 1 && buf[0] == '\x1b' {
+			continue
+		}
+
+		for _, key := range buf[:n] {
+			if !t.naming && (key == 'q' || key == '\x03') {
+				_, _ = fmt.Fprint(out, "\r\n")
+				return nil
+			}
+
+			t.message = ""
+			if t.naming {
+				t.typeName(key)
+			} else if err := t.press(key); err != nil {
+				t.message = err.Error()
+			}
+		}
+
+		t.refresh(out)
+	}
+}
+
+// press changes the setting bound to the key
+func (t *tuner) press(key byte) error {
+	toggle := func(name string) error {
+		value, _ := t.flags.GetBool(name)
+		return setFlagValues(t.flags, name, strconv.FormatBool(!value))
+	}
+
+	cycle := func(index, length, delta int) int {
+		return (index + delta + length) % length
+	}
+
+	switch key {
+	case 'd':
+		return toggle("no-decoration")
+
+	case 's':
+		return toggle("no-shadow")
+
+	case 'b':
+		return toggle("no-border")
+
+	case '+', '=':
+		t.padding += 4
+		return setFlagValues(t.flags, "padding", strconv.FormatFloat(t.padding, 'f', -1, 64))
+
+	case '-':
+		t.padding = max(t.padding-4, 0)
+		return setFlagValues(t.flags, "padding", strconv.FormatFloat(t.padding, 'f', -1, 64))
+
+	case 't', 'T':
+		t.theme = cycle(t.theme, len(t.themes), map[byte]int{'t': 1, 'T': -1}[key])
+		return setFlagValues(t.flags, "theme", t.themes[t.theme])
+
+	case 'f', 'F':
+		if len(t.fonts) == 1 {
+			return fmt.Errorf("no fonts to switch between, use --font-choice to add font files")
+		}
+
+		t.font = cycle(t.font, len(t.fonts), map[byte]int{'f': 1, 'F': -1}[key])
+		return t.setFont(t.fonts[t.font])
+
+	case '[':
+		t.fontSize = max(t.fontSize-1, 6)
+		return setFlagValues(t.flags, "font-size", strconv.FormatFloat(t.fontSize, 'f', -1, 64))
+
+	case ']':
+		t.fontSize = min(t.fontSize+1, 72)
+		return setFlagValues(t.flags, "font-size", strconv.FormatFloat(t.fontSize, 'f', -1, 64))
+
+	case 'w':
+		t.naming, t.name = true, ""
+	}
+
+	return nil
+}
+
+// setFont uses the font files, or the default font if there are none
+func (t *tuner) setFont(fonts string) error {
+	flag := t.flags.Lookup("font")
+
+	var files []string
+	if fonts != "" {
+		files = strings.Split(fonts, ",")
+	}
+
+	if err := flag.Value.(pflag.SliceValue).Replace(files); err != nil {
+		return err
+	}
+
+	flag.Changed = len(files) > 0
+	return nil
+}
+
+// typeName edits the name of the preset to save, which is saved on enter
+// and discarded on escape
+func (t *tuner) typeName(key byte) {
+	switch {
+	case key == '\r' || key == '\n':
+		t.naming = false
+		if t.name == "" {
+			return
+		}
+
+		if err := saveUserPreset(t.name, t.preset()); err != nil {
+			t.message = err.Error()
+			return
+		}
+
+		t.message = fmt.Sprintf("saved preset %s, use it with --preset %s", t.name, t.name)
+
+	case key == '\x1b' || key == '\x03':
+		t.naming = false
+
+	case key == '\x7f' || key == '\b':
+		if len(t.name) > 0 {
+			t.name = t.name[:len(t.name)-1]
+		}
+
+	case key >= 'a' && key <= 'z', key >= 'A' && key <= 'Z', key >= '0' && key <= '9', key == '-', key == '_':
+		t.name += string(key)
+	}
+}
+
+// preset returns the settings that differ from the defaults
+func (t *tuner) preset() preset {
+	settings := preset{}
+	t.flags.Visit(func(flag *pflag.Flag) {
+		value := flag.Value.String()
+		if slice, ok := flag.Value.(pflag.SliceValue); ok {
+			value = strings.Join(slice.GetSlice(), ",")
+		}
+
+		if flag.Name == "preset" || value == flag.DefValue || slices.Contains([]string{"", "[]"}, value) {
+			return
+		}
+
+		settings[flag.Name] = value
+	})
+
+	return settings
+}
+
+// refresh renders the screenshot and shows it along with the settings
+func (t *tuner) refresh(out io.Writer) {
+	var sb strings.Builder
+	sb.WriteString("\x1b[H\x1b[2J")
+	if t.protocol == previewKitty {
+		sb.WriteString("\x1b_Ga=d\x1b\\")
+	}
+
+	onOff := func(name string) string {
+		if value, _ := t.flags.GetBool(name); value {
+			return "off"
+		}
+
+		return "on"
+	}
+
+	theme, font := t.themes[t.theme], filepath.Base(t.fonts[t.font])
+	if theme == "" {
+		theme = "default"
+	}
+
+	if t.fonts[t.font] == "" {
+		font = "Hack"
+	}
+
+	sb.WriteString(bunt.Sprintf("Lime{*termshot tune*} DimGray{%s}\r\n", tuneKeys))
+	sb.WriteString(fmt.Sprintf("decorations %s · shadow %s · border %s · padding %g · theme %s · font %s · size %g\r\n",
+		onOff("no-decoration"),
+		onOff("no-shadow"),
+		onOff("no-border"),
+		t.padding,
+		theme,
+		font,
+		t.fontSize,
+	))
+
+	switch {
+	case t.naming:
+		sb.WriteString(bunt.Sprintf("preset name: %s\r\n", t.name))
+
+	case t.message != "":
+		sb.WriteString(bunt.Sprintf("DimGray{%s}\r\n", t.message))
+
+	default:
+		sb.WriteString("\r\n")
+	}
+
+	image, err := renderVariant(t.flags, "", t.content, nil)
+	if err != nil {
+		sb.WriteString(bunt.Sprintf("Red{%s}\r\n", err.Error()))
+		_, _ = io.WriteString(out, sb.String())
+		return
+	}
+
+	_, _ = io.WriteString(out, sb.String())
+
+	// The image is encoded once for both the file and the preview
+	var data bytes.Buffer
+	if err := png.Encode(&data, image); err != nil {
+		_, _ = bunt.Fprintf(out, "Red{%s}\r\n", err.Error())
+		return
+	}
+
+	if t.filename != "" {
+		if err := writeTunedImage(t.filename, data.Bytes()); err != nil {
+			_, _ = bunt.Fprintf(out, "Red{%s}\r\n", err.Error())
+		}
+	}
+
+	if t.protocol != "" {
+		if err := previewImage(out, data.Bytes(), t.protocol); err != nil {
+			_, _ = bunt.Fprintf(out, "Red{%s}\r\n", err.Error())
+		}
+	}
+}
+
+// writeTunedImage replaces the file with the PNG image, so that it can be
+// watched by an image viewer that reloads the file after changes
+func writeTunedImage(filename string, data []byte) error {
+	if err := os.WriteFile(filepath.Clean(filename), data, os.FileMode(0o644)); err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(tuneCmd)
+
+	tuneCmd.Flags().SortFlags = false
+
+	// flags to control look
+	addLookFlags(tuneCmd.Flags())
+	tuneCmd.Flags().StringArray("font-choice", nil, "font files to switch between in addition to the default Hack font, comma separated for multiple variants (repeatable)")
+
+	// flags for output related settings
+	tuneCmd.Flags().StringP("filename", "f", "", "PNG file that is written after each change, e.g. to watch it in an image viewer")
+	tuneCmd.Flags().String("preview", "auto", "display the screenshot in the terminal using the kitty, iterm, or sixel protocol")
+
+	// flags for raw output processing
+	tuneCmd.Flags().String("raw-read", "", "read raw input from file instead of using sample content")
+}
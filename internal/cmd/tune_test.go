@@ -0,0 +1,140 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/pflag"
+
+	"github.com/homeport/termshot/pkg/img"
+)
+
+var _ = Describe("Tuning the look", func() {
+	var configDir string
+
+	var newTestTuner = func(args ...string) *tuner {
+		flags := pflag.NewFlagSet("tune", pflag.ContinueOnError)
+		addLookFlags(flags)
+		flags.StringArray("font-choice", nil, "")
+		flags.StringP("filename", "f", "", "")
+		flags.String("preview", previewKitty, "")
+		Expect(flags.Parse(args)).To(Succeed())
+
+		t, err := newTuner(flags, nil)
+		Expect(err).ToNot(HaveOccurred())
+		return t
+	}
+
+	var typeName = func(t *tuner, keys string) {
+		for _, key := range []byte(keys) {
+			t.typeName(key)
+		}
+	}
+
+	BeforeEach(func() {
+		configDir = GinkgoT().TempDir()
+		GinkgoT().Setenv("XDG_CONFIG_HOME", configDir)
+	})
+
+	It("should change the settings bound to the keys", func() {
+		t := newTestTuner()
+
+		Expect(t.press('d')).To(Succeed())
+		Expect(t.flags.GetBool("no-decoration")).To(BeTrue())
+		Expect(t.press('d')).To(Succeed())
+		Expect(t.flags.GetBool("no-decoration")).To(BeFalse())
+
+		Expect(t.press('+')).To(Succeed())
+		Expect(t.flags.GetString("padding")).To(Equal("28"))
+		for range 10 {
+			Expect(t.press('-')).To(Succeed())
+		}
+		Expect(t.flags.GetString("padding")).To(Equal("0"))
+
+		Expect(t.press('t')).To(Succeed())
+		Expect(t.flags.GetString("theme")).To(Equal(img.Themes()[0]))
+		Expect(t.press('T')).To(Succeed())
+		Expect(t.flags.GetString("theme")).To(BeEmpty())
+
+		Expect(t.press(']')).To(Succeed())
+		Expect(t.flags.GetFloat64("font-size")).To(Equal(13.0))
+
+		Expect(t.press('f')).To(MatchError(ContainSubstring("no fonts to switch between")))
+	})
+
+	It("should describe the settings that differ from the defaults as a preset", func() {
+		t := newTestTuner("--no-shadow")
+		Expect(t.preset()).To(Equal(preset{"no-shadow": "true"}))
+
+		Expect(t.press('d')).To(Succeed())
+		Expect(t.press('+')).To(Succeed())
+		Expect(t.press('d')).To(Succeed())
+		Expect(t.preset()).To(Equal(preset{"no-shadow": "true", "padding": "28"}))
+	})
+
+	It("should save the preset with the typed name", func() {
+		t := newTestTuner()
+		Expect(t.press('s')).To(Succeed())
+		Expect(t.press('w')).To(Succeed())
+		Expect(t.naming).To(BeTrue())
+
+		typeName(t, "my look!\x7f\x7fk-2\r")
+		Expect(t.naming).To(BeFalse())
+		Expect(t.message).To(ContainSubstring("saved preset mylok-2"))
+
+		saved, ok, err := lookupPreset("mylok-2")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(saved).To(Equal(preset{"no-shadow": "true"}))
+	})
+
+	It("should discard the name on escape", func() {
+		t := newTestTuner()
+		Expect(t.press('w')).To(Succeed())
+		typeName(t, "name\x1b")
+		Expect(t.naming).To(BeFalse())
+		Expect(t.message).To(BeEmpty())
+
+		names, err := presetNames()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(names).To(Equal(sortedKeys(presets)))
+	})
+
+	It("should report invalid presets of the user", func() {
+		Expect(os.MkdirAll(filepath.Join(configDir, "termshot"), 0o755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(configDir, "termshot", "presets.yaml"), []byte("- invalid"), 0o600)).To(Succeed())
+
+		_, _, err := lookupPreset("mine")
+		Expect(err).To(MatchError(ContainSubstring("failed to parse presets file")))
+
+		_, err = presetNames()
+		Expect(err).To(MatchError(ContainSubstring("failed to parse presets file")))
+
+		t := newTestTuner()
+		Expect(t.press('w')).To(Succeed())
+		typeName(t, "mine\r")
+		Expect(t.message).To(ContainSubstring("failed to parse presets file"))
+	})
+})
@@ -0,0 +1,81 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"image"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/homeport/termshot/pkg/img"
+)
+
+// parseWatermark creates the watermark from the watermark settings, where
+// the value is either an image file or the text to stamp
+func parseWatermark(value string, position string, opacity float64) (*img.Watermark, error) {
+	switch img.WatermarkPosition(position) {
+	case img.WatermarkTopLeft, img.WatermarkTopRight, img.WatermarkBottomLeft, img.WatermarkBottomRight, img.WatermarkCenter:
+	default:
+		return nil, fmt.Errorf("unsupported watermark position %q, supported are: top-left, top-right, bottom-left, bottom-right, center", position)
+	}
+
+	if opacity < 0 || opacity > 1 {
+		return nil, fmt.Errorf("invalid watermark opacity %v, expected a value between 0 and 1", opacity)
+	}
+
+	watermark := img.Watermark{
+		Position: img.WatermarkPosition(position),
+		Opacity:  opacity,
+	}
+
+	if !isWatermarkImage(value) {
+		watermark.Text = value
+		return &watermark, nil
+	}
+
+	file, err := os.Open(filepath.Clean(value))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open watermark image: %w", err)
+	}
+
+	defer func() { _ = file.Close() }()
+
+	watermark.Image, _, err = image.Decode(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode watermark image: %w", err)
+	}
+
+	return &watermark, nil
+}
+
+// isWatermarkImage returns whether the watermark setting refers to an image
+// file instead of a text
+func isWatermarkImage(value string) bool {
+	switch strings.ToLower(filepath.Ext(value)) {
+	case ".png", ".jpg", ".jpeg":
+		return true
+
+	default:
+		return false
+	}
+}
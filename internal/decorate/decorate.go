@@ -0,0 +1,344 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package decorate implements screenshot decorations that are drawn by user
+// provided Starlark scripts
+//
+// A script has to define a decorate function, which is called with the
+// layout of the screenshot once the window and its content are rendered.
+// All coordinates and sizes are in pixels of the rendered image.
+//
+//	def decorate(layout):
+//	    text("ACME Corp.", layout.window.x + layout.window.width - 16, layout.height - 16, color = "#808080", anchor_x = 1)
+package decorate
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/fogleman/gg"
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+	"go.starlark.net/syntax"
+
+	"github.com/homeport/termshot/pkg/img"
+)
+
+const contextKey = "context"
+
+// Load reads the Starlark script from the given file and creates a
+// decoration that runs its decorate function
+func Load(filename string) (img.Decoration, error) {
+	src, err := os.ReadFile(filepath.Clean(filename))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read decoration script: %w", err)
+	}
+
+	return Compile(filename, src)
+}
+
+// Compile creates a decoration that runs the decorate function of the
+// Starlark script, relative image paths are resolved based on the location
+// of the provided filename
+func Compile(filename string, src []byte) (img.Decoration, error) {
+	predeclared := builtins(filepath.Dir(filename))
+
+	globals, err := starlark.ExecFileOptions(&syntax.FileOptions{}, newThread("load"), filename, src, predeclared)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load decoration script: %w", scriptError(err))
+	}
+
+	decorate, ok := globals["decorate"].(starlark.Callable)
+	if !ok {
+		return nil, fmt.Errorf("decoration script %s does not define a decorate function", filename)
+	}
+
+	return func(dc *gg.Context, layout img.Layout) error {
+		thread := newThread("decorate")
+		thread.SetLocal(contextKey, dc)
+
+		if _, err := starlark.Call(thread, decorate, starlark.Tuple{layoutValue(layout)}, nil); err != nil {
+			return fmt.Errorf("failed to run decoration script: %w", scriptError(err))
+		}
+
+		return nil
+	}, nil
+}
+
+func newThread(name string) *starlark.Thread {
+	return &starlark.Thread{
+		Name:  name,
+		Print: func(_ *starlark.Thread, msg string) { fmt.Fprintln(os.Stderr, msg) },
+	}
+}
+
+// scriptError includes the script backtrace for errors that occurred while
+// running the script
+func scriptError(err error) error {
+	var evalErr *starlark.EvalError
+	if errors.As(err, &evalErr) {
+		return errors.New(evalErr.Backtrace())
+	}
+
+	return err
+}
+
+func layoutValue(layout img.Layout) starlark.Value {
+	area := func(area img.Area) starlark.Value {
+		return starlarkstruct.FromStringDict(starlarkstruct.Default, starlark.StringDict{
+			"x":      starlark.Float(area.X),
+			"y":      starlark.Float(area.Y),
+			"width":  starlark.Float(area.Width),
+			"height": starlark.Float(area.Height),
+		})
+	}
+
+	return starlarkstruct.FromStringDict(starlarkstruct.Default, starlark.StringDict{
+		"width":   starlark.Float(layout.Width),
+		"height":  starlark.Float(layout.Height),
+		"window":  area(layout.Window),
+		"content": area(layout.Content),
+		"cell": starlarkstruct.FromStringDict(starlarkstruct.Default, starlark.StringDict{
+			"width":  starlark.Float(layout.CellWidth),
+			"height": starlark.Float(layout.CellHeight),
+		}),
+		"columns": starlark.MakeInt(layout.Columns),
+		"rows":    starlark.MakeInt(layout.Rows),
+		"scale":   starlark.Float(layout.Scale),
+	})
+}
+
+// number is a script argument, which can be either an int or a float
+type number float64
+
+func (n *number) Unpack(v starlark.Value) error {
+	f, ok := starlark.AsFloat(v)
+	if !ok {
+		return fmt.Errorf("got %s, want number", v.Type())
+	}
+
+	*n = number(f)
+	return nil
+}
+
+// hexColor is a script argument with a color in hex notation
+type hexColor string
+
+func (c *hexColor) Unpack(v starlark.Value) error {
+	str, ok := starlark.AsString(v)
+	if !ok {
+		return fmt.Errorf("got %s, want string", v.Type())
+	}
+
+	hex := strings.TrimPrefix(str, "#")
+	switch len(hex) {
+	case 3, 6, 8:
+		if _, err := strconv.ParseUint(hex, 16, 32); err == nil {
+			*c = hexColor(hex)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid color %q, expected #rgb, #rrggbb, or #rrggbbaa", str)
+}
+
+func context(thread *starlark.Thread, name string) (*gg.Context, error) {
+	dc, ok := thread.Local(contextKey).(*gg.Context)
+	if !ok {
+		return nil, fmt.Errorf("%s: can only be used inside of the decorate function", name)
+	}
+
+	return dc, nil
+}
+
+func paint(dc *gg.Context, color hexColor, fill bool, width number) {
+	dc.SetHexColor(string(color))
+
+	switch {
+	case fill:
+		dc.Fill()
+
+	default:
+		dc.SetLineWidth(float64(width))
+		dc.Stroke()
+	}
+}
+
+func builtins(dir string) starlark.StringDict {
+	return starlark.StringDict{
+		"text": starlark.NewBuiltin("text", func(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			var (
+				str              string
+				x, y             number
+				color            = hexColor("fff")
+				anchorX, anchorY number
+			)
+
+			if err := starlark.UnpackArgs(fn.Name(), args, kwargs, "s", &str, "x", &x, "y", &y, "color?", &color, "anchor_x?", &anchorX, "anchor_y?", &anchorY); err != nil {
+				return nil, err
+			}
+
+			dc, err := context(thread, fn.Name())
+			if err != nil {
+				return nil, err
+			}
+
+			dc.SetHexColor(string(color))
+			dc.DrawStringAnchored(str, float64(x), float64(y), float64(anchorX), float64(anchorY))
+			return starlark.None, nil
+		}),
+
+		"measure": starlark.NewBuiltin("measure", func(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			var str string
+			if err := starlark.UnpackArgs(fn.Name(), args, kwargs, "s", &str); err != nil {
+				return nil, err
+			}
+
+			dc, err := context(thread, fn.Name())
+			if err != nil {
+				return nil, err
+			}
+
+			w, h := dc.MeasureString(str)
+			return starlark.Tuple{starlark.Float(w), starlark.Float(h)}, nil
+		}),
+
+		"rect": starlark.NewBuiltin("rect", func(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			var (
+				x, y, w, h number
+				color      = hexColor("fff")
+				fill       = true
+				width      = number(1)
+				radius     number
+			)
+
+			if err := starlark.UnpackArgs(fn.Name(), args, kwargs, "x", &x, "y", &y, "width", &w, "height", &h, "color?", &color, "fill?", &fill, "line_width?", &width, "radius?", &radius); err != nil {
+				return nil, err
+			}
+
+			dc, err := context(thread, fn.Name())
+			if err != nil {
+				return nil, err
+			}
+
+			dc.DrawRoundedRectangle(float64(x), float64(y), float64(w), float64(h), float64(radius))
+			paint(dc, color, fill, width)
+			return starlark.None, nil
+		}),
+
+		"circle": starlark.NewBuiltin("circle", func(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			var (
+				x, y, r number
+				color   = hexColor("fff")
+				fill    = true
+				width   = number(1)
+			)
+
+			if err := starlark.UnpackArgs(fn.Name(), args, kwargs, "x", &x, "y", &y, "radius", &r, "color?", &color, "fill?", &fill, "line_width?", &width); err != nil {
+				return nil, err
+			}
+
+			dc, err := context(thread, fn.Name())
+			if err != nil {
+				return nil, err
+			}
+
+			dc.DrawCircle(float64(x), float64(y), float64(r))
+			paint(dc, color, fill, width)
+			return starlark.None, nil
+		}),
+
+		"line": starlark.NewBuiltin("line", func(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			var (
+				x1, y1, x2, y2 number
+				color          = hexColor("fff")
+				width          = number(1)
+			)
+
+			if err := starlark.UnpackArgs(fn.Name(), args, kwargs, "x1", &x1, "y1", &y1, "x2", &x2, "y2", &y2, "color?", &color, "line_width?", &width); err != nil {
+				return nil, err
+			}
+
+			dc, err := context(thread, fn.Name())
+			if err != nil {
+				return nil, err
+			}
+
+			dc.DrawLine(float64(x1), float64(y1), float64(x2), float64(y2))
+			paint(dc, color, false, width)
+			return starlark.None, nil
+		}),
+
+		"image": starlark.NewBuiltin("image", func(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			var (
+				path string
+				x, y number
+				w, h number
+			)
+
+			if err := starlark.UnpackArgs(fn.Name(), args, kwargs, "path", &path, "x", &x, "y", &y, "width?", &w, "height?", &h); err != nil {
+				return nil, err
+			}
+
+			dc, err := context(thread, fn.Name())
+			if err != nil {
+				return nil, err
+			}
+
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(dir, path)
+			}
+
+			image, err := gg.LoadImage(path)
+			if err != nil {
+				return nil, fmt.Errorf("%s: failed to load image: %w", fn.Name(), err)
+			}
+
+			// Scale the image to the requested size, keeping the aspect ratio
+			// in case only one of width and height is provided
+			bounds := image.Bounds()
+			sx, sy := 1.0, 1.0
+			switch {
+			case w > 0 && h > 0:
+				sx, sy = float64(w)/float64(bounds.Dx()), float64(h)/float64(bounds.Dy())
+
+			case w > 0:
+				sx = float64(w) / float64(bounds.Dx())
+				sy = sx
+
+			case h > 0:
+				sy = float64(h) / float64(bounds.Dy())
+				sx = sy
+			}
+
+			dc.Push()
+			dc.Translate(float64(x), float64(y))
+			dc.Scale(sx, sy)
+			dc.DrawImage(image, 0, 0)
+			dc.Pop()
+
+			return starlark.None, nil
+		}),
+	}
+}
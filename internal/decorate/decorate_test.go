@@ -0,0 +1,90 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package decorate_test
+
+import (
+	"image"
+	"image/color"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/homeport/termshot/internal/decorate"
+	"github.com/homeport/termshot/pkg/img"
+)
+
+func render(script string) (image.Image, error) {
+	decoration, err := Compile("test.star", []byte(script))
+	if err != nil {
+		return nil, err
+	}
+
+	scaffold := img.NewImageCreator()
+	scaffold.SetColumns(80)
+	if err := scaffold.AddContent(strings.NewReader("foo\nbar\n")); err != nil {
+		return nil, err
+	}
+
+	scaffold.AddDecoration(decoration)
+	return scaffold.Image()
+}
+
+var _ = Describe("Decoration scripts", func() {
+	It("should draw on top of the screenshot", func() {
+		image, err := render(`
+def decorate(layout):
+    rect(0, 0, 10, 10, color = "#ff0000")
+`)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(color.RGBAModel.Convert(image.At(5, 5))).To(Equal(color.RGBA{R: 0xff, A: 0xff}))
+	})
+
+	It("should provide the layout of the screenshot", func() {
+		_, err := render(`
+def decorate(layout):
+    if layout.rows != 2 or layout.columns != 80:
+        fail("unexpected layout %d x %d" % (layout.columns, layout.rows))
+
+    if layout.content.x <= layout.window.x or layout.cell.width <= 0:
+        fail("unexpected areas")
+`)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should fail when the script does not define a decorate function", func() {
+		_, err := Compile("test.star", []byte(`x = 1`))
+		Expect(err).To(MatchError(ContainSubstring("does not define a decorate function")))
+	})
+
+	It("should fail when the script uses an invalid color", func() {
+		_, err := render(`
+def decorate(layout):
+    circle(10, 10, 5, color = "red")
+`)
+		Expect(err).To(MatchError(ContainSubstring("invalid color")))
+	})
+
+	It("should not allow drawing outside of the decorate function", func() {
+		_, err := Compile("test.star", []byte(`line(0, 0, 1, 1)`))
+		Expect(err).To(MatchError(ContainSubstring("can only be used inside of the decorate function")))
+	})
+})
@@ -0,0 +1,190 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package diff implements a line based comparison of two texts
+package diff
+
+// Operation describes how a line differs between two texts
+type Operation int
+
+const (
+	// Unchanged marks a line that is part of both texts
+	Unchanged Operation = iota
+
+	// Removed marks a line that is only part of the first text
+	Removed
+
+	// Added marks a line that is only part of the second text
+	Added
+)
+
+// Line is one line of a comparison result
+type Line struct {
+	Operation Operation
+	Text      string
+
+	// A is the index of the line in the first text, or -1 if it is not part of it
+	A int
+
+	// B is the index of the line in the second text, or -1 if it is not part of it
+	B int
+}
+
+// Lines compares both lists of lines and returns the shortest list of line
+// operations to turn the first list into the second, based on the linear
+// space variant of the algorithm described in "An O(ND) Difference Algorithm
+// and Its Variations" by Myers, which splits the texts at the middle of the
+// shortest path instead of recording all states of the search
+func Lines(a, b []string) []Line {
+	c := comparison{a: a, b: b}
+	c.compare(0, len(a), 0, len(b))
+	return c.result
+}
+
+// comparison collects the line operations of both texts in order
+type comparison struct {
+	a, b   []string
+	result []Line
+}
+
+// compare adds the line operations of the lines from aLo to aHi of the first
+// text and the lines from bLo to bHi of the second text
+func (c *comparison) compare(aLo, aHi, bLo, bHi int) {
+	for aLo < aHi && bLo < bHi && c.a[aLo] == c.b[bLo] {
+		c.unchanged(aLo, bLo)
+		aLo, bLo = aLo+1, bLo+1
+	}
+
+	var suffix int
+	for aLo < aHi-suffix && bLo < bHi-suffix && c.a[aHi-suffix-1] == c.b[bHi-suffix-1] {
+		suffix++
+	}
+
+	aHi, bHi = aHi-suffix, bHi-suffix
+	if aLo == aHi || bLo == bHi {
+		c.replace(aLo, aHi, bLo, bHi)
+
+	} else if x, y, ok := c.middle(aLo, aHi, bLo, bHi); ok {
+		c.compare(aLo, x, bLo, y)
+		c.compare(x, aHi, y, bHi)
+
+	} else {
+		c.replace(aLo, aHi, bLo, bHi)
+	}
+
+	for i := 0; i < suffix; i++ {
+		c.unchanged(aHi+i, bHi+i)
+	}
+}
+
+// middle returns the end of the snake in the middle of the shortest path,
+// which is searched from both ends at the same time, until the paths overlap
+func (c *comparison) middle(aLo, aHi, bLo, bHi int) (int, int, bool) {
+	n, m := aHi-aLo, bHi-bLo
+	maxD := (n + m + 1) / 2
+	offset, size := maxD, 2*maxD+2
+
+	// Furthest reaching x of each diagonal, from the start and from the end
+	forward, backward := make([]int, size), make([]int, size)
+	for i := range forward {
+		forward[i], backward[i] = -1, -1
+	}
+
+	forward[offset+1], backward[offset+1] = 0, 0
+
+	delta := n - m
+	odd := delta%2 != 0
+
+	// Diagonals that leave the texts are skipped from the start or end
+	var fStart, fEnd, bStart, bEnd int
+	for d := 0; d < maxD; d++ {
+		for k := -d + fStart; k <= d-fEnd; k += 2 {
+			var x int
+			if k == -d || (k != d && forward[offset+k-1] < forward[offset+k+1]) {
+				x = forward[offset+k+1]
+			} else {
+				x = forward[offset+k-1] + 1
+			}
+
+			y := x - k
+			for x < n && y < m && c.a[aLo+x] == c.b[bLo+y] {
+				x, y = x+1, y+1
+			}
+
+			forward[offset+k] = x
+			switch r := offset + delta - k; {
+			case x > n:
+				fEnd += 2
+
+			case y > m:
+				fStart += 2
+
+			case odd && r >= 0 && r < size && backward[r] != -1 && x >= n-backward[r]:
+				return aLo + x, bLo + y, true
+			}
+		}
+
+		for k := -d + bStart; k <= d-bEnd; k += 2 {
+			var x int
+			if k == -d || (k != d && backward[offset+k-1] < backward[offset+k+1]) {
+				x = backward[offset+k+1]
+			} else {
+				x = backward[offset+k-1] + 1
+			}
+
+			y := x - k
+			for x < n && y < m && c.a[aHi-x-1] == c.b[bHi-y-1] {
+				x, y = x+1, y+1
+			}
+
+			backward[offset+k] = x
+			switch f := offset + delta - k; {
+			case x > n:
+				bEnd += 2
+
+			case y > m:
+				bStart += 2
+
+			case !odd && f >= 0 && f < size && forward[f] != -1 && forward[f] >= n-x:
+				fx := forward[f]
+				return aLo + fx, bLo + fx - (f - offset), true
+			}
+		}
+	}
+
+	return 0, 0, false
+}
+
+// unchanged adds the line that is part of both texts
+func (c *comparison) unchanged(x, y int) {
+	c.result = append(c.result, Line{Operation: Unchanged, Text: c.a[x], A: x, B: y})
+}
+
+// replace adds the lines of the first text as removed and the lines of the
+// second text as added
+func (c *comparison) replace(aLo, aHi, bLo, bHi int) {
+	for x := aLo; x < aHi; x++ {
+		c.result = append(c.result, Line{Operation: Removed, Text: c.a[x], A: x, B: -1})
+	}
+
+	for y := bLo; y < bHi; y++ {
+		c.result = append(c.result, Line{Operation: Added, Text: c.b[y], A: -1, B: y})
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package diff_test
+
+import (
+	"math/rand/v2"
+	"strconv"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/homeport/termshot/internal/diff"
+)
+
+var _ = Describe("Comparing lines", func() {
+	It("should report no changes for identical input", func() {
+		Expect(Lines([]string{"foo", "bar"}, []string{"foo", "bar"})).To(Equal([]Line{
+			{Operation: Unchanged, Text: "foo", A: 0, B: 0},
+			{Operation: Unchanged, Text: "bar", A: 1, B: 1},
+		}))
+	})
+
+	It("should report added and removed lines", func() {
+		Expect(Lines([]string{"foo", "bar", "baz"}, []string{"foo", "qux", "baz", "quux"})).To(Equal([]Line{
+			{Operation: Unchanged, Text: "foo", A: 0, B: 0},
+			{Operation: Removed, Text: "bar", A: 1, B: -1},
+			{Operation: Added, Text: "qux", A: -1, B: 1},
+			{Operation: Unchanged, Text: "baz", A: 2, B: 2},
+			{Operation: Added, Text: "quux", A: -1, B: 3},
+		}))
+	})
+
+	It("should handle empty input", func() {
+		Expect(Lines(nil, nil)).To(BeEmpty())
+		Expect(Lines(nil, []string{"foo"})).To(Equal([]Line{{Operation: Added, Text: "foo", A: -1, B: 0}}))
+		Expect(Lines([]string{"foo"}, nil)).To(Equal([]Line{{Operation: Removed, Text: "foo", A: 0, B: -1}}))
+	})
+
+	It("should find the shortest list of operations", func() {
+		// The length of the longest common subsequence by dynamic programming
+		var common = func(a, b []string) int {
+			lengths := make([][]int, len(a)+1)
+			for i := range lengths {
+				lengths[i] = make([]int, len(b)+1)
+			}
+
+			for i := len(a) - 1; i >= 0; i-- {
+				for j := len(b) - 1; j >= 0; j-- {
+					if a[i] == b[j] {
+						lengths[i][j] = lengths[i+1][j+1] + 1
+					} else {
+						lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+					}
+				}
+			}
+
+			return lengths[0][0]
+		}
+
+		random := rand.New(rand.NewPCG(1, 2))
+		for range 500 {
+			var a, b []string
+			for range random.IntN(30) {
+				a = append(a, strconv.Itoa(random.IntN(4)))
+			}
+
+			for range random.IntN(30) {
+				b = append(b, strconv.Itoa(random.IntN(4)))
+			}
+
+			var before, after []string
+			var unchanged int
+			for _, line := range Lines(a, b) {
+				if line.Operation != Added {
+					Expect(line.Text).To(Equal(a[line.A]))
+					before = append(before, line.Text)
+				}
+
+				if line.Operation != Removed {
+					Expect(line.Text).To(Equal(b[line.B]))
+					after = append(after, line.Text)
+				}
+
+				if line.Operation == Unchanged {
+					unchanged++
+				}
+			}
+
+			Expect(before).To(Equal(a))
+			Expect(after).To(Equal(b))
+			Expect(unchanged).To(Equal(common(a, b)), "%v %v", a, b)
+		}
+	})
+})
+
+var _ = Describe("Coloring patches", func() {
+	It("should color file headers, hunk headers, and changed lines", func() {
+		patch := "diff --git a/foo b/foo\n" +
+			"--- a/foo\n" +
+			"+++ b/foo\n" +
+			"@@ -1,2 +1,2 @@ func foo()\n" +
+			" foo\n" +
+			"-bar\n" +
+			"+baz\n"
+
+		Expect(Colorize(patch)).To(Equal("\x1b[1mdiff --git a/foo b/foo\x1b[0m\n" +
+			"\x1b[1m--- a/foo\x1b[0m\n" +
+			"\x1b[1m+++ b/foo\x1b[0m\n" +
+			"\x1b[36m@@ -1,2 +1,2 @@\x1b[0m func foo()\n" +
+			" foo\n" +
+			"\x1b[31m-bar\x1b[0m\n" +
+			"\x1b[32m+baz\x1b[0m\n"))
+	})
+
+	It("should not mistake changed lines starting with dashes for file headers", func() {
+		patch := "@@ -1 +1 @@\n" +
+			"--- foo\n" +
+			"+++ bar\n" +
+			"--- a/bar\n"
+
+		Expect(Colorize(patch)).To(Equal("\x1b[36m@@ -1 +1 @@\x1b[0m\n" +
+			"\x1b[31m--- foo\x1b[0m\n" +
+			"\x1b[32m+++ bar\x1b[0m\n" +
+			"\x1b[1m--- a/bar\x1b[0m\n"))
+	})
+
+	It("should leave text outside of hunks as-is", func() {
+		Expect(Colorize("Fix the -foo flag\r\n+ more\r\n")).To(Equal("Fix the -foo flag\r\n+ more\r\n"))
+	})
+})
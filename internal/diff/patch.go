@@ -0,0 +1,125 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package diff
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	colorReset   = "\x1b[0m"
+	colorBold    = "\x1b[1m"
+	colorRemoved = "\x1b[31m"
+	colorAdded   = "\x1b[32m"
+	colorHunk    = "\x1b[36m"
+)
+
+// hunkHeader matches the header of a hunk with the start and number of lines
+// of both files, which is followed by optional context like a function name
+var hunkHeader = regexp.MustCompile(`^@@ -\d+(?:,(\d+))? \+\d+(?:,(\d+))? @@`)
+
+// fileHeaders are the prefixes of lines that describe the files of a patch
+var fileHeaders = []string{
+	"diff ",
+	"index ",
+	"--- ",
+	"+++ ",
+	"new file mode ",
+	"deleted file mode ",
+	"old mode ",
+	"new mode ",
+	"similarity index ",
+	"rename from ",
+	"rename to ",
+	"Binary files ",
+}
+
+// Colorize adds the colors of a terminal to a patch in the unified diff
+// format, like git does: file headers are bold, hunk headers cyan, and
+// removed and added lines red and green. The number of lines of each hunk
+// is tracked, so that removed lines starting with dashes are not mistaken
+// for file headers, and text outside of hunks, like a commit message, is
+// left as-is.
+func Colorize(patch string) string {
+	var sb strings.Builder
+	var removed, added int
+
+	lines := strings.SplitAfter(patch, "\n")
+	for _, line := range lines {
+		text := strings.TrimRight(line, "\r\n")
+		eol := line[len(text):]
+
+		switch {
+		case removed > 0 || added > 0:
+			switch {
+			case strings.HasPrefix(text, "-"):
+				removed--
+				text = colorRemoved + text + colorReset
+
+			case strings.HasPrefix(text, "+"):
+				added--
+				text = colorAdded + text + colorReset
+
+			case strings.HasPrefix(text, `\`):
+				// no newline at end of file, which is not part of the count
+
+			default:
+				removed--
+				added--
+			}
+
+		case hunkHeader.MatchString(text):
+			match := hunkHeader.FindStringSubmatch(text)
+			removed, added = hunkLines(match[1]), hunkLines(match[2])
+			text = colorHunk + match[0] + colorReset + text[len(match[0]):]
+
+		case hasAnyPrefix(text, fileHeaders):
+			text = colorBold + text + colorReset
+		}
+
+		sb.WriteString(text + eol)
+	}
+
+	return sb.String()
+}
+
+// hunkLines returns the number of lines of a hunk header, which is one in
+// case it is omitted
+func hunkLines(count string) int {
+	if count == "" {
+		return 1
+	}
+
+	lines, _ := strconv.Atoi(count)
+	return lines
+}
+
+func hasAnyPrefix(text string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(text, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,93 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package prompt detects shell prompts in transcripts of terminal sessions
+package prompt
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/homeport/termshot/internal/ansi"
+)
+
+// DefaultPatterns are regular expressions matching the most common shell
+// prompts, used in case no custom patterns are provided
+var DefaultPatterns = []string{
+	`^(\S+@\S+[: ]\S*\s?)?[$#%](\s+|$)`, // sh, bash, zsh, e.g. user@host:~$
+	`^(\S+\s)?[❯➜λ›](\s+|$)`,            // starship, oh-my-zsh, and similar
+	`^PS [^>]*>(\s+|$)`,                 // PowerShell
+}
+
+// Detector detects lines that start with a shell prompt
+type Detector struct {
+	patterns []*regexp.Regexp
+}
+
+// New creates a detector for the given regular expressions, which are
+// matched against the text of a line without escape sequences. The command
+// is either the named group "command" of the expression, or the remainder
+// of the line after the match. Without any patterns, the default patterns
+// are used.
+func New(patterns ...string) (*Detector, error) {
+	if len(patterns) == 0 {
+		patterns = DefaultPatterns
+	}
+
+	var detector Detector
+	for _, pattern := range patterns {
+		regex, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid prompt pattern %q: %w", pattern, err)
+		}
+
+		detector.patterns = append(detector.patterns, regex)
+	}
+
+	return &detector, nil
+}
+
+// Command returns the command of the line in case it starts with a prompt
+func (d *Detector) Command(line []byte) (string, bool) {
+	var text strings.Builder
+	for _, token := range ansi.Tokenize(line) {
+		if token.Kind == ansi.Text || (token.Kind == ansi.Control && token.Rune == '\t') {
+			text.WriteRune(token.Rune)
+		}
+	}
+
+	plain := text.String()
+	for _, regex := range d.patterns {
+		match := regex.FindStringSubmatchIndex(plain)
+		if match == nil {
+			continue
+		}
+
+		command := plain[match[1]:]
+		if idx := regex.SubexpIndex("command"); idx > 0 && match[2*idx] >= 0 {
+			command = plain[match[2*idx]:match[2*idx+1]]
+		}
+
+		return strings.TrimRight(command, " \t\r"), true
+	}
+
+	return "", false
+}
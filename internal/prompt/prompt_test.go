@@ -0,0 +1,90 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package prompt_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/homeport/termshot/internal/prompt"
+)
+
+var _ = Describe("Prompt detection", func() {
+	Context("using the default patterns", func() {
+		var detector *Detector
+
+		BeforeEach(func() {
+			var err error
+			detector, err = New()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		DescribeTable("detecting prompts",
+			func(line string, expected string) {
+				command, ok := detector.Command([]byte(line))
+				Expect(ok).To(BeTrue())
+				Expect(command).To(Equal(expected))
+			},
+			Entry("plain prompt", "$ ls -a", "ls -a"),
+			Entry("root prompt", "# whoami", "whoami"),
+			Entry("user and host prompt", "user@host:~/src$ git status", "git status"),
+			Entry("prompt without command", "user@host:~$ ", ""),
+			Entry("colored prompt", "\x1b[32muser@host\x1b[0m:\x1b[34m~\x1b[0m$ echo foo", "echo foo"),
+			Entry("arrow prompt", "❯ make test", "make test"),
+			Entry("PowerShell prompt", `PS C:\Users\foo> dir`, "dir"),
+		)
+
+		It("should not detect regular output", func() {
+			for _, line := range []string{"total 42", "drwxr-xr-x  2 user user 4096 .", "Price: 5$"} {
+				_, ok := detector.Command([]byte(line))
+				Expect(ok).To(BeFalse(), line)
+			}
+		})
+	})
+
+	Context("using custom patterns", func() {
+		It("should use the remainder of the line as the command", func() {
+			detector, err := New(`^\[\w+\] > `)
+			Expect(err).ToNot(HaveOccurred())
+
+			command, ok := detector.Command([]byte("[prod] > kubectl get pods"))
+			Expect(ok).To(BeTrue())
+			Expect(command).To(Equal("kubectl get pods"))
+
+			_, ok = detector.Command([]byte("$ ls"))
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should use the named command group if present", func() {
+			detector, err := New(`^>>> (?P<command>.*?)\s*# `)
+			Expect(err).ToNot(HaveOccurred())
+
+			command, ok := detector.Command([]byte(">>> print(42)  # answer"))
+			Expect(ok).To(BeTrue())
+			Expect(command).To(Equal("print(42)"))
+		})
+
+		It("should fail for invalid patterns", func() {
+			_, err := New(`^(`)
+			Expect(err).To(MatchError(ContainSubstring("invalid prompt pattern")))
+		})
+	})
+})
@@ -28,7 +28,10 @@ import (
 	"os/exec"
 	"os/signal"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"syscall"
+	"time"
 
 	"github.com/creack/pty"
 	"github.com/mattn/go-isatty"
@@ -47,7 +50,21 @@ type PseudoTerminal struct {
 	rows   uint16
 	resize bool
 
+	stdin  *os.File
 	stdout io.Writer
+
+	// stderrPrefix and stderrSuffix enclose the output of the standard error
+	// in case it is captured separately from the pseudo terminal
+	separateStderr             bool
+	stderrPrefix, stderrSuffix string
+
+	// timeout is the time after which the command and its process group are
+	// killed, zero means the command can run as long as it needs
+	timeout  time.Duration
+	timedOut atomic.Bool
+
+	exitCode int
+	duration time.Duration
 }
 
 // New creates a new pseudo terminal builder
@@ -55,6 +72,7 @@ func New() *PseudoTerminal {
 	return &PseudoTerminal{
 		shell:  "/bin/sh",
 		resize: true,
+		stdin:  os.Stdin,
 		stdout: os.Stdout,
 	}
 }
@@ -71,12 +89,35 @@ func (c *PseudoTerminal) Rows(rows uint16) *PseudoTerminal {
 	return c
 }
 
+// Stdin sets the file to be used for the standard input, which is put into
+// raw mode if it is a terminal, nil means the command gets no input
+func (c *PseudoTerminal) Stdin(stdin *os.File) *PseudoTerminal {
+	c.stdin = stdin
+	return c
+}
+
 // Stdout sets the writer to be used for the standard output
 func (c *PseudoTerminal) Stdout(stdout io.Writer) *PseudoTerminal {
 	c.stdout = stdout
 	return c
 }
 
+// Stderr configures the command to write its standard error to a separate
+// pipe instead of the pseudo terminal, so that it can be told apart from the
+// standard output, it is part of the output enclosed in prefix and suffix
+func (c *PseudoTerminal) Stderr(prefix, suffix string) *PseudoTerminal {
+	c.separateStderr = true
+	c.stderrPrefix, c.stderrSuffix = prefix, suffix
+	return c
+}
+
+// Timeout sets the time after which the command and all processes of its
+// process group are killed, the output captured so far is kept
+func (c *PseudoTerminal) Timeout(timeout time.Duration) *PseudoTerminal {
+	c.timeout = timeout
+	return c
+}
+
 // Command sets the command and arguments to be used
 func (c *PseudoTerminal) Command(name string, args ...string) *PseudoTerminal {
 	c.name = name
@@ -84,6 +125,22 @@ func (c *PseudoTerminal) Command(name string, args ...string) *PseudoTerminal {
 	return c
 }
 
+// ExitCode returns the exit code of the last command that was run
+func (c *PseudoTerminal) ExitCode() int {
+	return c.exitCode
+}
+
+// TimedOut returns whether the last command that was run was killed, since
+// it did not finish within the timeout
+func (c *PseudoTerminal) TimedOut() bool {
+	return c.timedOut.Load()
+}
+
+// Duration returns the wall-clock time the last command that was run took
+func (c *PseudoTerminal) Duration() time.Duration {
+	return c.duration
+}
+
 // Run runs the provided command/script with the given arguments in a pseudo
 // terminal (PTY) so that the behavior is the same if it would be executed
 // in a terminal
@@ -106,32 +163,88 @@ func (c *PseudoTerminal) Run() ([]byte, error) {
 	}
 
 	// Set RAW mode for Stdin
-	if isTerminal(os.Stdin) {
-		oldState, rawErr := term.MakeRaw(int(os.Stdin.Fd()))
+	if c.stdin != nil && isTerminal(c.stdin) {
+		oldState, rawErr := term.MakeRaw(int(c.stdin.Fd()))
 		if rawErr != nil {
 			return nil, fmt.Errorf("failed to enable RAW mode for Stdin: %w", rawErr)
 		}
 
 		// And make sure to restore the original mode eventually
-		defer func() { _ = term.Restore(int(os.Stdin.Fd()), oldState) }()
+		defer func() { _ = term.Restore(int(c.stdin.Fd()), oldState) }()
 	}
 
 	// collect all errors along the way
 	var errors = []error{}
 
+	var buf bytes.Buffer
+	out := &syncWriter{w: io.MultiWriter(c.stdout, &buf)}
+
 	// #nosec G204 -- since this is exactly what we want, arbitrary commands
-	pt, err := c.pseudoTerminal(exec.Command(c.name, c.args...))
+	cmd := exec.Command(c.name, c.args...)
+
+	// The pseudo terminal is only used for streams that are not set
+	if c.stdin == nil {
+		devNull, openErr := os.Open(os.DevNull)
+		if openErr != nil {
+			return nil, fmt.Errorf("failed to open %s for Stdin: %w", os.DevNull, openErr)
+		}
+
+		// The pseudo terminal on the standard output becomes the controlling
+		// terminal instead of the standard input
+		cmd.Stdin = devNull
+		cmd.SysProcAttr = &syscall.SysProcAttr{Ctty: 1}
+		defer func() { _ = devNull.Close() }()
+	}
+
+	stderrDone := make(chan struct{})
+	if c.separateStderr {
+		reader, writer, pipeErr := os.Pipe()
+		if pipeErr != nil {
+			return nil, fmt.Errorf("failed to create pipe for Stderr: %w", pipeErr)
+		}
+
+		cmd.Stderr = writer
+		defer func() { _ = reader.Close() }()
+
+		go func() {
+			defer close(stderrDone)
+			c.copyStderr(out, &buf, reader)
+		}()
+
+	} else {
+		close(stderrDone)
+	}
+
+	start := time.Now()
+	pt, err := c.pseudoTerminal(cmd)
+
+	// The write end of the pipe belongs to the command once it is started
+	if c.separateStderr {
+		_ = cmd.Stderr.(*os.File).Close()
+	}
+
 	if err != nil {
 		return nil, err
 	}
 
+	// The command is the leader of a new session and process group in the
+	// pseudo terminal, so that it can be killed with all of its children
+	var timer *time.Timer
+	c.timedOut.Store(false)
+	if c.timeout > 0 {
+		timer = time.AfterFunc(c.timeout, func() {
+			c.timedOut.Store(true)
+			_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+		})
+	}
+
 	// Support terminal resizing
-	if c.resize && isTerminal(os.Stdin) {
+	if c.resize && c.stdin != nil && isTerminal(c.stdin) {
 		ch := make(chan os.Signal, 1)
 		signal.Notify(ch, syscall.SIGWINCH)
 		go func() {
 			for range ch {
-				if ptyErr := pty.InheritSize(os.Stdin, pt); ptyErr != nil {
+				if ptyErr := pty.InheritSize(c.stdin, pt); ptyErr != nil {
 					errors = append(errors, fmt.Errorf("error resizing PTY: %w", ptyErr))
 				}
 			}
@@ -144,19 +257,52 @@ func (c *PseudoTerminal) Run() ([]byte, error) {
 		}()
 	}
 
-	go func() {
+	if c.stdin != nil {
+		go func() {
+			defer func() { _ = pt.Close() }()
+			_, copyErr := io.Copy(pt, c.stdin)
+			if copyErr != nil {
+				errors = append(errors, copyErr)
+			}
+		}()
+
+	} else {
 		defer func() { _ = pt.Close() }()
-		_, copyErr := io.Copy(pt, os.Stdin)
-		if copyErr != nil {
-			errors = append(errors, copyErr)
-		}
-	}()
+	}
 
-	var buf bytes.Buffer
-	if err = copy(io.MultiWriter(c.stdout, &buf), pt); err != nil {
+	if err = copy(out, pt); err != nil {
 		return nil, err
 	}
 
+	// Keep track of the exit code, a non-zero exit code is not considered
+	// to be an error, since the output is what matters
+	c.exitCode = 0
+
+	// The timer is stopped before the command is reaped, so that the process
+	// group that is killed cannot be one of another process
+	if timer != nil {
+		timer.Stop()
+	}
+
+	err = cmd.Wait()
+	c.duration = time.Since(start)
+	if err != nil {
+		exitErr, ok := err.(*exec.ExitError)
+		if !ok {
+			return nil, err
+		}
+
+		c.exitCode = exitErr.ExitCode()
+	}
+
+	// A command that was killed reports the same exit code as it would
+	// with the timeout command
+	if c.TimedOut() {
+		c.exitCode = 124
+	}
+
+	<-stderrDone
+
 	if len(errors) > 0 {
 		fmt.Fprintf(os.Stderr, "issues in background tasks:\n")
 		for _, err := range errors {
@@ -167,6 +313,40 @@ func (c *PseudoTerminal) Run() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// copyStderr writes the standard error as is to the standard error of the
+// current process, and enclosed in the prefix and suffix to the output
+func (c *PseudoTerminal) copyStderr(out *syncWriter, buf *bytes.Buffer, stderr io.Reader) {
+	chunk := make([]byte, 4096)
+	for {
+		n, err := stderr.Read(chunk)
+		if n > 0 {
+			out.Lock()
+			_, _ = os.Stderr.Write(chunk[:n])
+			buf.WriteString(c.stderrPrefix)
+			buf.Write(chunk[:n])
+			buf.WriteString(c.stderrSuffix)
+			out.Unlock()
+		}
+
+		if err != nil {
+			return
+		}
+	}
+}
+
+// syncWriter serializes the writes of the standard output and error, which
+// are read concurrently
+type syncWriter struct {
+	sync.Mutex
+	w io.Writer
+}
+
+func (w *syncWriter) Write(p []byte) (int, error) {
+	w.Lock()
+	defer w.Unlock()
+	return w.w.Write(p)
+}
+
 func (c *PseudoTerminal) pseudoTerminal(cmd *exec.Cmd) (*os.File, error) {
 	if c.cols == 0 && c.rows == 0 {
 		return pty.Start(cmd)
@@ -21,6 +21,8 @@
 package ptexec_test
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -52,5 +54,60 @@ var _ = Describe("Pseudo Terminal Execute Suite", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(trimmed(out)).To(Equal("12 40"))
 		})
+
+		It("should report the exit code of the command", func() {
+			pt := New().Stdout(GinkgoWriter)
+
+			_, err := pt.Command("exit 0").Run()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pt.ExitCode()).To(Equal(0))
+
+			_, err = pt.Command("exit 42").Run()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pt.ExitCode()).To(Equal(42))
+		})
+
+		It("should run a command without standard input", func() {
+			out, err := New().Stdout(GinkgoWriter).Stdin(nil).
+				Command("cat").
+				Run()
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(trimmed(out)).To(BeEmpty())
+		})
+
+		It("should report the duration of the command", func() {
+			pt := New().Stdout(GinkgoWriter)
+
+			_, err := pt.Command("sleep 0.2").Run()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pt.Duration()).To(BeNumerically(">=", 200*time.Millisecond))
+		})
+
+		It("should kill the command and its children after the timeout", func() {
+			pt := New().Stdout(GinkgoWriter).Stdin(nil).Timeout(200 * time.Millisecond)
+
+			out, err := pt.Command("echo started; sleep 5 & sleep 5; echo finished").Run()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(trimmed(out)).To(Equal("started"))
+			Expect(pt.TimedOut()).To(BeTrue())
+			Expect(pt.ExitCode()).To(Equal(124))
+			Expect(pt.Duration()).To(BeNumerically("<", 2*time.Second))
+
+			_, err = pt.Command("exit 0").Run()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pt.TimedOut()).To(BeFalse())
+			Expect(pt.ExitCode()).To(Equal(0))
+		})
+
+		It("should enclose the standard error if it is captured separately", func() {
+			out, err := New().Stdout(GinkgoWriter).
+				Stderr("<", ">").
+				Command("echo failed >&2").
+				Run()
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(trimmed(out)).To(Equal("<failed\n>"))
+		})
 	})
 })
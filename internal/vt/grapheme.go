@@ -0,0 +1,176 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package vt
+
+import (
+	"unicode/utf8"
+
+	"golang.org/x/text/width"
+)
+
+// Grapheme clusters of multiple runes, for example emoji sequences, flags,
+// or characters with combining marks, are stored as one rune of a private
+// use area in the cell, which refers to the text of the cluster in the
+// table of the content, see [Table.Grapheme]
+const (
+	clusterBase  = 0xFE000
+	clusterLimit = 0xFFF00
+)
+
+// continuation is the content of the cell after a wide character, which is
+// not part of the content
+const continuation = -1
+
+// Grapheme returns the text of the character, which is the grapheme cluster
+// in case the rune refers to one of the table
+func (t *Table) Grapheme(r rune) string {
+	if t == nil || !isClusterRune(r) {
+		return string(r)
+	}
+
+	if i := int(r - clusterBase); i < len(t.clusters) {
+		return t.clusters[i]
+	}
+
+	return string(r)
+}
+
+// Width returns the number of cells the character takes, which is two for
+// wide characters like most emoji or East Asian characters, and one for all
+// other characters
+func (t *Table) Width(r rune) int {
+	if r < 0x1100 {
+		return 1
+	}
+
+	text := t.Grapheme(r)
+	first, size := utf8.DecodeRuneInString(text)
+
+	switch kind := width.LookupRune(first).Kind(); {
+	case kind == width.EastAsianWide || kind == width.EastAsianFullwidth:
+		return 2
+
+	// Emoji presentation selector
+	case containsRune(text[size:], '\uFE0F'):
+		return 2
+
+	// Flags are a pair of regional indicators
+	case isRegionalIndicator(first) && utf8.RuneCountInString(text) > 1:
+		return 2
+	}
+
+	return 1
+}
+
+func containsRune(text string, r rune) bool {
+	for _, c := range text {
+		if c == r {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+func isClusterRune(r rune) bool {
+	return r >= clusterBase && r < clusterLimit
+}
+
+// cluster returns the rune of the grapheme cluster, which is added to the
+// table if needed, or false in case there are too many different clusters
+// already, single runes are their own rune unless they are from the private
+// use area of the clusters
+func (t *Table) cluster(text string) (rune, bool) {
+	if r, size := utf8.DecodeRuneInString(text); size == len(text) && !isClusterRune(r) {
+		return r, true
+	}
+
+	if r, ok := t.clusterRunes[text]; ok {
+		return r, true
+	}
+
+	if len(t.clusters) >= clusterLimit-clusterBase {
+		return 0, false
+	}
+
+	if t.clusterRunes == nil {
+		t.clusterRunes = map[string]rune{}
+	}
+
+	r := clusterBase + rune(len(t.clusters))
+	t.clusters = append(t.clusters, text)
+	t.clusterRunes[text] = r
+	return r, true
+}
+
+// extend adds the rune to the character before the cursor, in case it
+// continues its grapheme cluster, and returns whether it did
+func (s *Screen) extend(r rune) bool {
+	// Only runes from combining marks on can continue a cluster, which
+	// keeps the common case fast
+	if r < 0x300 {
+		return false
+	}
+
+	b := s.active
+	x := b.x - 1
+	if b.wrapPending {
+		x = b.x
+	}
+
+	line := s.line(b.y)
+	if x >= 0 && x < len(line) && line[x].Symbol == continuation {
+		x--
+	}
+
+	if x < 0 || x >= len(line) {
+		return false
+	}
+
+	text := []rune(s.table.Grapheme(line[x].Symbol) + string(r))
+	s.segmenter.Init(text)
+	graphemes := s.segmenter.GraphemeIterator()
+	if !graphemes.Next() || len(graphemes.Grapheme().Text) != len(text) {
+		return false
+	}
+
+	symbol, ok := s.table.cluster(string(text))
+	if !ok {
+		// The rune is dropped, since there is no room for another cluster,
+		// which is better than showing the parts of the cluster one by one
+		return true
+	}
+
+	before := s.table.Width(line[x].Symbol)
+	line[x].Symbol = symbol
+
+	// A character that becomes wide, like an emoji with the emoji
+	// presentation selector, takes the next cell as well if possible
+	if before == 1 && s.table.Width(symbol) == 2 && !b.wrapPending {
+		s.put(continuation)
+	}
+
+	return true
+}
@@ -0,0 +1,382 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package vt
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Bits of the settings of a bunt.ColoredRune
+const (
+	fgMask        = 0x01
+	bgMask        = 0x02
+	boldMask      = 0x04
+	italicMask    = 0x08
+	underlineMask = 0x10
+	reverseMask   = 0x20
+	strikeMask    = 0x40
+	dimMask       = 0x80
+
+	// Colors of the palette are stored as a reference to the palette, see
+	// [PaletteIndex]
+	fgPaletteMask = 1 << 56
+	bgPaletteMask = 1 << 57
+
+	blinkMask = 1 << 58
+
+	// The color bits include the flag of colors of the palette
+	fgColorMask = 0xFFFFFF<<8 | fgPaletteMask
+	bgColorMask = 0xFFFFFF<<32 | bgPaletteMask
+)
+
+// standardColors are the colors of the SGR parameters 30-37 and 90-97 (and
+// their background counterparts), the same ones as used by bunt
+var standardColors = [16][3]uint8{
+	{1, 1, 1},
+	{222, 56, 43},
+	{57, 181, 74},
+	{255, 199, 6},
+	{0, 111, 184},
+	{118, 38, 113},
+	{44, 181, 233},
+	{204, 204, 204},
+	{128, 128, 128},
+	{255, 0, 0},
+	{0, 255, 0},
+	{255, 255, 0},
+	{0, 0, 255},
+	{255, 0, 255},
+	{0, 255, 255},
+	{255, 255, 255},
+}
+
+// paletteColors are the first 16 colors of the 8-bit color palette, the same
+// ones as used by bunt
+var paletteColors = [16][3]uint8{
+	{0, 0, 0},
+	{170, 0, 0},
+	{0, 170, 0},
+	{229, 229, 16},
+	{0, 0, 170},
+	{170, 0, 170},
+	{0, 170, 170},
+	{229, 229, 229},
+	{85, 85, 85},
+	{255, 85, 85},
+	{85, 255, 85},
+	{255, 255, 85},
+	{85, 85, 255},
+	{255, 85, 255},
+	{85, 255, 255},
+	{255, 255, 255},
+}
+
+var sgrNames = map[int]string{
+	8:  "conceal",
+	20: "fraktur",
+	26: "proportional spacing",
+	51: "framed",
+	52: "encircled",
+	53: "overline",
+	60: "ideogram underline",
+}
+
+// palette8bit are the colors of the 8-bit color palette
+var palette8bit = func() [256][3]uint8 {
+	var palette [256][3]uint8
+	for n := range palette {
+		switch {
+		case n < 16:
+			palette[n] = paletteColors[n]
+
+		case n < 232:
+			i := n - 16
+			palette[n] = [3]uint8{uint8(i / 36 * 51), uint8(i / 6 % 6 * 51), uint8(i % 6 * 51)} // #nosec G115 -- at most 255
+
+		default:
+			value := uint8(float32(n-232) * (255.0 / 23.0))
+			palette[n] = [3]uint8{value, value, value}
+		}
+	}
+
+	return palette
+}()
+
+// paletteRef is the first reference to a color of the 8-bit color palette,
+// colors of the palette are stored as a reference instead of the RGB values,
+// where the 16 standard colors come first, since they have other values than
+// the first 16 colors of the 8-bit color palette, but the same index
+const paletteRef = 16
+
+// Color returns the foreground or background color of the settings, if it
+// has one
+func Color(settings uint64, background bool) (r, g, b uint8, ok bool) {
+	flag, palette, shift := colorBits(background)
+	if settings&flag == 0 {
+		return 0, 0, 0, false
+	}
+
+	value := settings >> shift & 0xFFFFFF
+	if settings&palette == 0 {
+		return uint8(value), uint8(value >> 8), uint8(value >> 16), true // #nosec G115 -- masked
+	}
+
+	c := standardColors[0]
+	switch ref := int(value); { // #nosec G115 -- masked
+	case ref >= paletteRef && ref-paletteRef < len(palette8bit):
+		c = palette8bit[ref-paletteRef]
+
+	case ref < len(standardColors):
+		c = standardColors[ref]
+	}
+
+	return c[0], c[1], c[2], true
+}
+
+// PaletteIndex returns the index of the 8-bit color palette of the foreground
+// or background color of the settings, if it was set with an 8-bit color, or
+// with one of the 16 standard colors, which have the same index in the palette
+func PaletteIndex(settings uint64, background bool) (int, bool) {
+	flag, palette, shift := colorBits(background)
+	if settings&flag == 0 || settings&palette == 0 {
+		return -1, false
+	}
+
+	ref := int(settings >> shift & 0xFFFFFF) // #nosec G115 -- masked
+	if ref >= paletteRef {
+		return ref - paletteRef, true
+	}
+
+	return ref, true
+}
+
+func colorBits(background bool) (flag, palette uint64, shift int) {
+	if background {
+		return bgMask, bgPaletteMask, 32
+	}
+
+	return fgMask, fgPaletteMask, 8
+}
+
+// Blink returns whether the text of the settings is blinking
+func Blink(settings uint64) bool {
+	return settings&blinkMask != 0
+}
+
+// fgColor returns the settings of the foreground color, which is either the
+// RGB value, or a reference to a color of the palette
+func fgColor(value uint32, palette bool) uint64 {
+	settings := fgMask | uint64(value)<<8
+	if palette {
+		settings |= fgPaletteMask
+	}
+
+	return settings
+}
+
+// bgColor returns the settings of the background color, see [fgColor]
+func bgColor(value uint32, palette bool) uint64 {
+	settings := bgMask | uint64(value)<<32
+	if palette {
+		settings |= bgPaletteMask
+	}
+
+	return settings
+}
+
+func rgb(r, g, b uint8) uint32 {
+	return uint32(r) | uint32(g)<<8 | uint32(b)<<16
+}
+
+// graphicRendition applies the parameters of a select graphic rendition
+// sequence to the current settings, where unlike the replacing semantics of
+// bunt each parameter only changes the attribute it refers to, like it is in
+// a terminal
+func graphicRendition(settings uint64, params string, table *Table, report func(string, ...interface{})) uint64 {
+	if params == "" {
+		return 0
+	}
+
+	// Parameters are separated by semicolons, sub-parameters of one parameter
+	// by colons, e.g. 38:2::255:0:0 is the same as 38;2;255;0;0
+	var values [][]int
+	for _, param := range strings.Split(params, ";") {
+		var value []int
+		for _, sub := range strings.Split(param, ":") {
+			number, err := strconv.Atoi(sub)
+			if sub != "" && err != nil {
+				report("graphic rendition parameter %q is invalid and ignored", param)
+				return settings
+			}
+
+			value = append(value, number)
+		}
+
+		values = append(values, value)
+	}
+
+	for i := 0; i < len(values); i++ {
+		switch n := values[i][0]; {
+		case n == 0:
+			settings = 0
+
+		case n == 1:
+			settings |= boldMask
+
+		case n == 2:
+			settings |= dimMask
+
+		case n == 3:
+			settings |= italicMask
+
+		case n == 4:
+			style := UnderlineSingle
+			if len(values[i]) > 1 && values[i][1] >= 0 && values[i][1] <= int(UnderlineDashed) {
+				style = UnderlineStyle(values[i][1])
+			}
+
+			settings = table.underlineStyle(settings, style)
+
+		case n == 9:
+			settings |= strikeMask
+
+		case n == 22:
+			settings &^= boldMask | dimMask
+
+		case n == 23:
+			settings &^= italicMask
+
+		case n == 5, n == 6:
+			settings |= blinkMask
+
+		case n == 7:
+			settings |= reverseMask
+
+		case n == 21:
+			settings = table.underlineStyle(settings, UnderlineDouble)
+
+		case n == 24:
+			settings = table.underlineStyle(settings, UnderlineNone)
+
+		case n == 27:
+			settings &^= reverseMask
+
+		case n == 29:
+			settings &^= strikeMask
+
+		case n == 25:
+			settings &^= blinkMask
+
+		case n == 28, n == 50, n == 54, n == 55, n == 65:
+			// turns off an attribute that is not supported
+
+		case n >= 30 && n <= 37, n >= 90 && n <= 97:
+			ref := n%10 + 8*(n/90)
+			settings = settings&^(fgMask|fgColorMask) | fgColor(uint32(ref), true) // #nosec G115 -- at most 15
+
+		case n >= 40 && n <= 47, n >= 100 && n <= 107:
+			ref := n%10 + 8*(n/100)
+			settings = settings&^(bgMask|bgColorMask) | bgColor(uint32(ref), true) // #nosec G115 -- at most 15
+
+		case n == 39:
+			settings &^= fgMask | fgColorMask
+
+		case n == 49:
+			settings &^= bgMask | bgColorMask
+
+		case n == 59:
+			settings = table.underlineColor(settings, 0)
+
+		case n == 38 || n == 48 || n == 58:
+			var args []int
+			switch {
+			case len(values[i]) > 1: // colon separated sub-parameters
+				args = values[i][1:]
+				if len(args) == 5 && args[0] == 2 { // with color space identifier
+					args = append([]int{2}, args[2:]...)
+				}
+
+			default:
+				for _, value := range values[i+1:] {
+					args = append(args, value[0])
+				}
+			}
+
+			value, palette, consumed, err := extendedColor(args)
+			if err != nil {
+				report("%v", err)
+				return settings
+			}
+
+			if len(values[i]) == 1 {
+				i += consumed
+			}
+
+			switch n {
+			case 38:
+				settings = settings&^(fgMask|fgColorMask) | fgColor(value, palette)
+
+			case 48:
+				settings = settings&^(bgMask|bgColorMask) | bgColor(value, palette)
+
+			case 58:
+				settings = table.underlineColor(settings, fgColor(value, palette))
+			}
+
+		default:
+			name, ok := sgrNames[n]
+			if !ok {
+				name = "unknown"
+			}
+
+			report("graphic rendition parameter %d (%s) is not supported and ignored", n, name)
+		}
+	}
+
+	return settings
+}
+
+// extendedColor reads the arguments of an extended color selection, which
+// are either 5 and the index of the 8-bit palette, or 2 and the RGB values,
+// where the color is either the reference to the palette, or the RGB value
+func extendedColor(args []int) (value uint32, palette bool, consumed int, err error) {
+	inRange := func(values ...int) bool {
+		for _, value := range values {
+			if value < 0 || value > 255 {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	switch {
+	case len(args) >= 2 && args[0] == 5 && inRange(args[1]):
+		return uint32(paletteRef + args[1]), true, 2, nil // #nosec G115 -- range checked
+
+	case len(args) >= 4 && args[0] == 2 && inRange(args[1:4]...):
+		return rgb(uint8(args[1]), uint8(args[2]), uint8(args[3])), false, 4, nil // #nosec G115 -- range checked
+	}
+
+	return 0, false, 0, fmt.Errorf("color selection with parameters %v is invalid and ignored", args)
+}
@@ -0,0 +1,156 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package vt
+
+import "slices"
+
+// UnderlineStyle is the style of the line drawn under underlined text
+type UnderlineStyle int
+
+// Underline styles of the SGR parameter 4 with sub-parameters, e.g. 4:3
+const (
+	UnderlineNone UnderlineStyle = iota
+	UnderlineSingle
+	UnderlineDouble
+	UnderlineCurly
+	UnderlineDotted
+	UnderlineDashed
+)
+
+// The style and color of an underline are an entry of the table, which is
+// referenced by the settings, where the reference 0 is a single underline
+// with the color of the text
+const (
+	underlineShift   = 59
+	underlineRefMask = 0x1F << underlineShift
+)
+
+// underline is the style and color of underlines of the table, where the
+// color is stored like a foreground color of the settings, and zero means
+// that the color of the text is used
+type underline struct {
+	style UnderlineStyle
+	color uint64
+}
+
+func (t *Table) underline(settings uint64) underline {
+	ref := int(settings & underlineRefMask >> underlineShift) // #nosec G115 -- at most 31
+	if t == nil || ref == 0 || ref > len(t.underlines) {
+		return underline{style: UnderlineSingle}
+	}
+
+	return t.underlines[ref-1]
+}
+
+// Underline returns the style of the underline of the settings
+func (t *Table) Underline(settings uint64) UnderlineStyle {
+	if settings&underlineMask == 0 {
+		return UnderlineNone
+	}
+
+	return t.underline(settings).style
+}
+
+// UnderlineColor returns the color of the underline of the settings, if it
+// has a color other than the color of the text, which is returned as the
+// foreground color of settings, see [Color] and [PaletteIndex]
+func (t *Table) UnderlineColor(settings uint64) (uint64, bool) {
+	c := t.underline(settings).color
+	return c, c != 0
+}
+
+// underlineStyle returns the settings with the style of the underline, where
+// the color of the underline is kept even if the underline is turned off
+func (t *Table) underlineStyle(settings uint64, style UnderlineStyle) uint64 {
+	if style == UnderlineNone {
+		return settings &^ underlineMask
+	}
+
+	return t.setUnderline(settings|underlineMask, underline{style: style, color: t.underline(settings).color})
+}
+
+// underlineColor returns the settings with the color of the underline, which
+// is stored like a foreground color, or zero for the color of the text
+func (t *Table) underlineColor(settings uint64, color uint64) uint64 {
+	return t.setUnderline(settings, underline{style: t.underline(settings).style, color: color})
+}
+
+// setUnderline returns the settings with the reference to the underline,
+// which is added to the table if needed, or in case all references are in
+// use, the closest known underline is used instead
+func (t *Table) setUnderline(settings uint64, entry underline) uint64 {
+	var ref int
+	if entry != (underline{style: UnderlineSingle}) {
+		ref = slices.Index(t.underlines, entry) + 1
+		switch {
+		case ref > 0:
+
+		case len(t.underlines) < underlineRefMask>>underlineShift:
+			t.underlines = append(t.underlines, entry)
+			ref = len(t.underlines)
+
+		default:
+			ref = t.closestUnderline(entry)
+		}
+	}
+
+	return settings&^underlineRefMask | uint64(ref)<<underlineShift // #nosec G115 -- at most 31
+}
+
+// closestUnderline returns the reference of the underline with the same style
+// and the most similar color, or otherwise the one with the most similar color
+func (t *Table) closestUnderline(entry underline) int {
+	distance := func(c underline) int {
+		d := colorDistance(c.color, entry.color)
+		if c.style != entry.style {
+			d += 1 << 20
+		}
+
+		return d
+	}
+
+	ref, closest := 0, distance(underline{style: UnderlineSingle})
+	for i, c := range t.underlines {
+		if d := distance(c); d < closest {
+			ref, closest = i+1, d
+		}
+	}
+
+	return ref
+}
+
+// colorDistance returns how different two colors stored like foreground
+// colors of the settings are, where the color of the text is different
+// from all other colors
+func colorDistance(a, b uint64) int {
+	if a == 0 || b == 0 {
+		if a == b {
+			return 0
+		}
+
+		return 3 * 256 * 256
+	}
+
+	r1, g1, b1, _ := Color(a, false)
+	r2, g2, b2, _ := Color(b, false)
+	dr, dg, db := int(r1)-int(r2), int(g1)-int(g2), int(b1)-int(b2)
+	return dr*dr + dg*dg + db*db
+}
@@ -0,0 +1,995 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package vt emulates the screen of a terminal, so that output which uses
+// carriage returns, cursor movements, or erase sequences, for example
+// progress bars or spinners, results in the text that is visible on the
+// screen instead of the raw sequence of characters
+package vt
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/go-text/typesetting/segmenter"
+	"github.com/gonvenience/bunt"
+
+	"github.com/homeport/termshot/internal/ansi"
+)
+
+var controlSequenceNames = map[byte]string{
+	'b': "repeat character",
+	'c': "device attributes",
+	'g': "tab clear",
+	'n': "device status report",
+	'q': "load LEDs",
+	't': "window manipulation",
+	'y': "confidence test",
+}
+
+// decSpecialGraphics maps the characters of the DEC special graphics
+// character set, which is used for line drawing, to Unicode
+var decSpecialGraphics = map[rune]rune{
+	'`': '◆', 'a': '▒', 'b': '␉', 'c': '␌', 'd': '␍', 'e': '␊', 'f': '°',
+	'g': '±', 'h': '␤', 'i': '␋', 'j': '┘', 'k': '┐', 'l': '┌', 'm': '└',
+	'n': '┼', 'o': '⎺', 'p': '⎻', 'q': '─', 'r': '⎼', 's': '⎽', 't': '├',
+	'u': '┤', 'v': '┴', 'w': '┬', 'x': '│', 'y': '≤', 'z': '≥', '{': 'π',
+	'|': '≠', '}': '£', '~': '·',
+}
+
+type cursor struct {
+	x, y     int
+	settings uint64
+	graphics bool
+}
+
+type buffer struct {
+	lines [][]bunt.ColoredRune
+
+	// x and y are the cursor position, where y is the index of the line
+	// including lines that were scrolled out of the screen
+	x, y int
+
+	// wrapPending is set once a character was written into the last
+	// column, the next character will then be written into the next line
+	wrapPending bool
+
+	// wrapped is set while the cursor is in the line wrapEnd, into which the
+	// line starting at wrapStart was wrapped
+	wrapped            bool
+	wrapStart, wrapEnd int
+
+	// scrollTop and scrollBottom are the scrolling region as screen rows
+	scrollTop, scrollBottom int
+
+	saved cursor
+}
+
+// Table holds the parts of content that do not fit into the runes and the
+// settings, which refer to them instead, so content is only meaningful
+// together with the table of the screen the content comes from
+type Table struct {
+	// clusters are the texts of the grapheme clusters, which are referenced
+	// by runes of a private use area, and their runes
+	clusters     []string
+	clusterRunes map[string]rune
+
+	// underlines are the styles and colors of underlines, which are
+	// referenced by their position in the settings
+	underlines []underline
+}
+
+// Screen is the screen of a terminal with a fixed number of columns, and
+// either a fixed number of rows, or an unlimited number of rows, where the
+// screen grows with the content
+type Screen struct {
+	width  int
+	height int
+
+	main      buffer
+	alternate buffer
+	active    *buffer
+
+	settings uint64
+	graphics bool
+	autowrap bool
+	tabWidth int
+
+	logicalReturn bool
+	scrollback    int
+
+	pending []byte
+	offset  int
+	issues  []ansi.Issue
+
+	segmenter segmenter.Segmenter
+	table     *Table
+}
+
+// New creates a screen with the provided size, where a width of zero means
+// that lines are never wrapped, and a height of zero means that the screen
+// grows with the content
+func New(width, height int) *Screen {
+	s := &Screen{
+		width:    width,
+		height:   height,
+		autowrap: true,
+		tabWidth: 8,
+		table:    &Table{},
+	}
+
+	s.main = s.newBuffer()
+	s.active = &s.main
+
+	return s
+}
+
+// SetTabWidth sets the number of columns between the tab stops, which are
+// every eight columns by default
+func (s *Screen) SetTabWidth(width int) { s.tabWidth = max(1, width) }
+
+// SetScrollback sets the number of lines scrolled out of the top of a screen
+// with a fixed number of rows, that are kept as part of the content, where a
+// negative number keeps all of them, by default only the screen is kept
+func (s *Screen) SetScrollback(lines int) { s.scrollback = lines }
+
+// SetLogicalReturn configures whether a carriage return moves the cursor to
+// the start of a line that was wrapped, instead of the start of the last row
+// it was wrapped into, so that lines that are redrawn after a carriage
+// return, like progress bars, only show their final state, even if they are
+// wider than the screen
+func (s *Screen) SetLogicalReturn(value bool) { s.logicalReturn = value }
+
+// SetTable sets the table the settings of the content refer to, so that the
+// content of several screens can be combined, see [Screen.Table]
+func (s *Screen) SetTable(table *Table) { s.table = table }
+
+// Table returns the table the settings of the content refer to, which has to
+// be used to interpret the settings, see [Table.UnderlineColor]
+func (s *Screen) Table() *Table { return s.table }
+
+func (s *Screen) newBuffer() buffer {
+	return buffer{
+		lines:        make([][]bunt.ColoredRune, max(1, s.height)),
+		scrollBottom: s.height - 1,
+	}
+}
+
+// Write processes the terminal output, sequences that are split across
+// multiple writes are supported
+func (s *Screen) Write(p []byte) (int, error) {
+	data := append(s.pending, p...)
+	start := s.offset - len(s.pending)
+	s.pending = nil
+
+	// A character that is split across writes is kept for the next write
+	var partial []byte
+	for i := len(data) - 1; i >= 0 && i >= len(data)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(data[i]) {
+			if !utf8.FullRune(data[i:]) {
+				data, partial = data[:i], data[i:]
+			}
+
+			break
+		}
+	}
+
+	tokens := ansi.Tokenize(data)
+	for i, token := range tokens {
+		if i == len(tokens)-1 && token.Unterminated {
+			s.pending = append([]byte{}, token.Raw...)
+			break
+		}
+
+		token.Offset += start
+		s.process(token)
+	}
+
+	s.pending = append(s.pending, partial...)
+	s.offset += len(p)
+	return len(p), nil
+}
+
+// Issues returns all sequences and control characters of the processed
+// output that are not supported, and therefore rendered differently than
+// in a terminal
+func (s *Screen) Issues() []ansi.Issue {
+	issues := s.issues
+	if len(s.pending) > 0 {
+		issues = append(issues, ansi.Issue{
+			Offset:   s.offset - len(s.pending),
+			Sequence: string(s.pending),
+			Message:  "sequence is not terminated and ignored",
+		})
+	}
+
+	return issues
+}
+
+// Content returns the text on the screen, where lines are separated by
+// newlines, and trailing whitespace without background color is removed
+func (s *Screen) Content() bunt.String {
+	b := s.active
+	top := s.start()
+
+	last := b.y
+	for i := len(b.lines) - 1; i > last; i-- {
+		if len(trimLine(b.lines[i])) > 0 {
+			last = i
+			break
+		}
+	}
+
+	var result bunt.String
+	for i := top; i <= last && i < len(b.lines); i++ {
+		if i > top {
+			result = append(result, bunt.ColoredRune{Symbol: '\n'})
+		}
+
+		for _, cr := range trimLine(b.lines[i]) {
+			if cr.Symbol != continuation {
+				result = append(result, cr)
+			}
+		}
+	}
+
+	return result
+}
+
+// Cursor returns the position of the cursor, where y is the index of the
+// line of the content, see [Screen.Content]
+func (s *Screen) Cursor() (x, y int) {
+	b := s.active
+
+	// The cells after wide characters are not part of the content
+	x = b.x
+	for _, cr := range b.lines[b.y][:min(b.x, len(b.lines[b.y]))] {
+		if cr.Symbol == continuation {
+			x--
+		}
+	}
+
+	return x, b.y - s.start()
+}
+
+// String returns the text on the screen including the escape sequences for
+// colors and text attributes
+func (s *Screen) String() string {
+	return Render(s.Content(), s.table)
+}
+
+// Render converts the text into a string with escape sequences, where each
+// change of the colors or text attributes is written as a complete graphic
+// rendition, so that parsing it again results in the same text, the table is
+// the one the settings of the text refer to
+func Render(text bunt.String, table *Table) string {
+	var sb strings.Builder
+	var current uint64
+	for _, cr := range text {
+		if cr.Settings != current {
+			sb.WriteString(renderSettings(cr.Settings, current != 0, table))
+			current = cr.Settings
+		}
+
+		sb.WriteString(table.Grapheme(cr.Symbol))
+	}
+
+	if current != 0 {
+		sb.WriteString(renderSettings(0, false, table))
+	}
+
+	return sb.String()
+}
+
+func renderSettings(settings uint64, reset bool, table *Table) string {
+	if settings == 0 {
+		return "\x1b[0m"
+	}
+
+	var params []string
+	if reset {
+		params = append(params, "0")
+	}
+
+	for _, attribute := range []struct {
+		mask  uint64
+		param string
+	}{
+		{boldMask, "1"},
+		{dimMask, "2"},
+		{italicMask, "3"},
+		{blinkMask, "5"},
+		{underlineMask, "4"},
+		{reverseMask, "7"},
+		{strikeMask, "9"},
+	} {
+		if settings&attribute.mask != 0 {
+			params = append(params, attribute.param)
+		}
+	}
+
+	if style := table.Underline(settings); style > UnderlineSingle {
+		params = append(params, fmt.Sprintf("4:%d", style))
+	}
+
+	if c, ok := table.UnderlineColor(settings); ok {
+		params = append(params, colorParams(c, false, 0, "58"))
+	}
+
+	if settings&fgMask != 0 {
+		params = append(params, colorParams(settings, false, 30, "38"))
+	}
+
+	if settings&bgMask != 0 {
+		params = append(params, colorParams(settings, true, 40, "48"))
+	}
+
+	return "\x1b[" + strings.Join(params, ";") + "m"
+}
+
+// colorParams returns the parameters of the foreground or background color
+// of the settings, where colors of the palette are written with their index
+func colorParams(settings uint64, background bool, standard int, extended string) string {
+	_, palette, shift := colorBits(background)
+	value := int(settings >> shift & 0xFFFFFF) // #nosec G115 -- masked
+	switch {
+	case settings&palette == 0:
+		return fmt.Sprintf("%s;2;%d;%d;%d", extended, value&0xFF, value>>8&0xFF, value>>16&0xFF)
+
+	case value >= paletteRef:
+		return fmt.Sprintf("%s;5;%d", extended, value-paletteRef)
+
+	case value < 8:
+		return strconv.Itoa(standard + value)
+	}
+
+	return strconv.Itoa(standard + 60 + value - 8)
+}
+
+// trimLine removes trailing whitespace without background color
+func trimLine(line []bunt.ColoredRune) []bunt.ColoredRune {
+	end := len(line)
+	for end > 0 && line[end-1].Symbol == ' ' && line[end-1].Settings&(bgMask|reverseMask) == 0 {
+		end--
+	}
+
+	return line[:end]
+}
+
+// Lint processes the terminal output and returns all sequences and control
+// characters that are not supported
+func Lint(data []byte) []ansi.Issue {
+	screen := New(0, 0)
+	_, _ = screen.Write(data)
+	return screen.Issues()
+}
+
+func (s *Screen) report(token ansi.Token, format string, a ...interface{}) {
+	s.issues = append(s.issues, ansi.Issue{
+		Offset:   token.Offset,
+		Sequence: string(token.Raw),
+		Message:  fmt.Sprintf(format, a...),
+	})
+}
+
+func (s *Screen) process(token ansi.Token) {
+	switch token.Kind {
+	case ansi.Text:
+		s.put(token.Rune)
+
+	case ansi.Invalid:
+		s.report(token, "is not valid UTF-8")
+		s.put(utf8.RuneError)
+
+	case ansi.Control:
+		s.control(token)
+
+	case ansi.CSI:
+		s.controlSequence(token)
+
+	case ansi.OSC:
+		if strings.HasPrefix(token.Params, "8;") {
+			s.report(token, "hyperlink is ignored, only the link text is rendered")
+		}
+
+	case ansi.String:
+		s.report(token, "string sequence is ignored")
+
+	case ansi.Escape:
+		s.escape(token)
+	}
+}
+
+func (s *Screen) control(token ansi.Token) {
+	b := s.active
+	switch token.Rune {
+	case '\n', '\v', '\f':
+		// Output without a pseudo terminal usually only contains line feeds,
+		// therefore a line feed also returns to the start of the line
+		b.x = 0
+		s.lineFeed()
+
+	case '\r':
+		if s.logicalReturn && b.wrapped && b.wrapEnd == b.y && b.wrapStart >= s.top() {
+			b.y = b.wrapStart
+		}
+
+		b.x = 0
+		b.wrapPending = false
+		b.wrapped = false
+
+	case '\b':
+		b.x = max(0, b.x-1)
+		b.wrapPending = false
+
+	case '\t':
+		s.tab()
+
+	case '\a', 0x00, 0x7f:
+		// no visible effect
+
+	default:
+		s.report(token, "control character is not supported and ignored")
+	}
+}
+
+func (s *Screen) escape(token ansi.Token) {
+	b := s.active
+	switch {
+	case token.Intermediate == "(":
+		s.graphics = token.Final == '0'
+
+	case token.Intermediate != "":
+		// other character sets and encodings are not supported, which does
+		// not matter for UTF-8 output
+
+	case token.Final == '7':
+		b.saved = cursor{x: b.x, y: b.y - s.top(), settings: s.settings, graphics: s.graphics}
+
+	case token.Final == '8':
+		s.moveTo(b.saved.x, b.saved.y)
+		s.settings, s.graphics = b.saved.settings, b.saved.graphics
+
+	case token.Final == 'D':
+		s.lineFeed()
+
+	case token.Final == 'E':
+		b.x = 0
+		s.lineFeed()
+
+	case token.Final == 'M':
+		s.reverseIndex()
+
+	case token.Final == 'c':
+		s.main = s.newBuffer()
+		s.active = &s.main
+		s.settings, s.graphics, s.autowrap = 0, false, true
+
+	case token.Final == '=', token.Final == '>':
+		// keypad modes have no visible effect
+
+	default:
+		s.report(token, "escape sequence is not supported and ignored")
+	}
+}
+
+func (s *Screen) controlSequence(token ansi.Token) {
+	b := s.active
+
+	params := strings.TrimLeft(token.Params, "?>=<")
+	private := params != token.Params
+
+	var values []int
+	if params != "" {
+		for _, param := range strings.Split(params, ";") {
+			value, _ := strconv.Atoi(strings.SplitN(param, ":", 2)[0])
+			values = append(values, value)
+		}
+	}
+
+	// arg returns the parameter with the index, or the default value in
+	// case it is missing or zero
+	arg := func(i int, fallback int) int {
+		if i < len(values) && values[i] > 0 {
+			return values[i]
+		}
+
+		return fallback
+	}
+
+	if token.Intermediate != "" {
+		// e.g. cursor style, no visible effect
+		return
+	}
+
+	row := b.y - s.top()
+	switch token.Final {
+	case 'm':
+		if private {
+			return
+		}
+
+		s.settings = graphicRendition(s.settings, token.Params, s.table, func(format string, a ...interface{}) {
+			s.report(token, format, a...)
+		})
+
+	case 'A':
+		s.moveTo(b.x, max(row-arg(0, 1), 0))
+
+	case 'B', 'e':
+		s.moveTo(b.x, row+arg(0, 1))
+
+	case 'C', 'a':
+		s.moveTo(b.x+arg(0, 1), row)
+
+	case 'D':
+		s.moveTo(b.x-arg(0, 1), row)
+
+	case 'E':
+		s.moveTo(0, row+arg(0, 1))
+
+	case 'F':
+		s.moveTo(0, max(row-arg(0, 1), 0))
+
+	case 'G', '`':
+		s.moveTo(arg(0, 1)-1, row)
+
+	case 'd':
+		s.moveTo(b.x, arg(0, 1)-1)
+
+	case 'H', 'f':
+		s.moveTo(arg(1, 1)-1, arg(0, 1)-1)
+
+	case 'J':
+		s.eraseInDisplay(arg(0, 0))
+
+	case 'K':
+		s.eraseInLine(arg(0, 0))
+
+	case 'X':
+		s.line(b.y)
+		s.fill(b.y, b.x, b.x+arg(0, 1))
+
+	case 'P':
+		line := s.line(b.y)
+		if b.x < len(line) {
+			n := min(arg(0, 1), len(line)-b.x)
+			b.lines[b.y] = append(line[:b.x], line[b.x+n:]...)
+		}
+
+	case '@':
+		line := s.line(b.y)
+		if b.x < len(line) {
+			blanks := make([]bunt.ColoredRune, arg(0, 1))
+			for i := range blanks {
+				blanks[i] = s.blank()
+			}
+
+			line = append(line[:b.x], append(blanks, line[b.x:]...)...)
+			if s.width > 0 && len(line) > s.width {
+				line = line[:s.width]
+			}
+
+			b.lines[b.y] = line
+		}
+
+	case 'L':
+		s.insertLines(b.y, arg(0, 1))
+
+	case 'M':
+		s.deleteLines(b.y, arg(0, 1))
+
+	case 'S':
+		s.scrollUp(arg(0, 1))
+
+	case 'T':
+		s.scrollDown(arg(0, 1))
+
+	case 'r':
+		if private {
+			return
+		}
+
+		if s.height > 0 {
+			top, bottom := arg(0, 1)-1, min(arg(1, s.height), s.height)-1
+			if top < bottom {
+				b.scrollTop, b.scrollBottom = top, bottom
+				s.moveTo(0, 0)
+			}
+		}
+
+	case 's':
+		b.saved = cursor{x: b.x, y: row, settings: s.settings, graphics: s.graphics}
+
+	case 'u':
+		s.moveTo(b.saved.x, b.saved.y)
+
+	case 'h', 'l':
+		if private {
+			for _, value := range values {
+				s.setMode(value, token.Final == 'h')
+			}
+		}
+
+	default:
+		name, ok := controlSequenceNames[token.Final]
+		if !ok {
+			name = "unknown"
+		}
+
+		s.report(token, "control sequence (%s) is not supported and ignored", name)
+	}
+}
+
+func (s *Screen) setMode(mode int, enable bool) {
+	switch mode {
+	case 7:
+		s.autowrap = enable
+
+	case 47, 1047, 1049:
+		if enable == (s.active == &s.alternate) {
+			return
+		}
+
+		if enable {
+			if mode == 1049 {
+				s.main.saved = cursor{x: s.main.x, y: s.main.y - s.top(), settings: s.settings, graphics: s.graphics}
+			}
+
+			s.alternate = s.newBuffer()
+			s.active = &s.alternate
+			return
+		}
+
+		s.active = &s.main
+		if mode == 1049 {
+			s.moveTo(s.main.saved.x, s.main.saved.y)
+			s.settings, s.graphics = s.main.saved.settings, s.main.saved.graphics
+		}
+	}
+}
+
+// top returns the index of the first line that is on the screen
+func (s *Screen) top() int {
+	if s.height == 0 {
+		return 0
+	}
+
+	return len(s.active.lines) - s.height
+}
+
+// start returns the index of the first line of the content, which is the
+// first line of the screen, or of the scrollback lines above it
+func (s *Screen) start() int {
+	top := s.top()
+	if s.scrollback < 0 {
+		return 0
+	}
+
+	return max(0, top-s.scrollback)
+}
+
+// line returns the line with the index, adding lines if needed
+func (s *Screen) line(y int) []bunt.ColoredRune {
+	b := s.active
+	for len(b.lines) <= y {
+		b.lines = append(b.lines, nil)
+	}
+
+	return b.lines[y]
+}
+
+// blank is an empty cell using the current background color
+func (s *Screen) blank() bunt.ColoredRune {
+	return bunt.ColoredRune{Symbol: ' ', Settings: s.settings & (bgMask | bgColorMask)}
+}
+
+// fill replaces the cells of the line from start to end with blanks
+func (s *Screen) fill(y, start, end int) {
+	b := s.active
+	line := s.line(y)
+	if s.width > 0 {
+		end = min(end, s.width)
+	}
+
+	if end <= start {
+		return
+	}
+
+	// Erasing the end of the line with the default background does not
+	// need any cells
+	if end >= len(line) && s.settings&bgMask == 0 {
+		b.lines[y] = line[:min(start, len(line))]
+		return
+	}
+
+	for len(line) < end {
+		line = append(line, bunt.ColoredRune{Symbol: ' '})
+	}
+
+	for i := start; i < end; i++ {
+		line[i] = s.blank()
+	}
+
+	b.lines[y] = line
+}
+
+// moveTo moves the cursor to the column and row of the screen
+func (s *Screen) moveTo(x, row int) {
+	b := s.active
+	if s.width > 0 {
+		x = min(x, s.width-1)
+	}
+
+	if s.height > 0 {
+		row = min(row, s.height-1)
+	}
+
+	b.x, b.y = max(x, 0), s.top()+max(row, 0)
+	b.wrapPending = false
+	b.wrapped = false
+	s.line(b.y)
+}
+
+func (s *Screen) put(r rune) {
+	b := s.active
+	if s.graphics {
+		if replacement, ok := decSpecialGraphics[r]; ok {
+			r = replacement
+		}
+	}
+
+	// A rune that continues the grapheme cluster of the previous character
+	// is added to its cell
+	w := 1
+	if r != continuation {
+		if s.extend(r) {
+			return
+		}
+
+		// Runes of the private use area of the grapheme clusters in the
+		// output are stored like a cluster, so that they keep their meaning
+		if isClusterRune(r) {
+			var ok bool
+			if r, ok = s.table.cluster(string(r)); !ok {
+				r = utf8.RuneError
+			}
+		}
+
+		w = s.table.Width(r)
+	}
+
+	if b.wrapPending && s.autowrap {
+		b.x = 0
+		s.wrapLine()
+	}
+
+	// A wide character that does not fit into the line anymore is written
+	// into the next line
+	if w == 2 && s.autowrap && s.width > 1 && b.x+2 > s.width {
+		s.fill(b.y, b.x, s.width)
+		b.x = 0
+		s.wrapLine()
+	}
+
+	line := s.line(b.y)
+	for len(line) <= b.x {
+		line = append(line, bunt.ColoredRune{Symbol: ' '})
+	}
+
+	line[b.x] = bunt.ColoredRune{Symbol: r, Settings: s.settings}
+	b.lines[b.y] = line
+
+	switch {
+	case s.width > 0 && b.x+1 >= s.width:
+		b.wrapPending = true
+
+	default:
+		b.x++
+	}
+
+	// The cell after a wide character is part of the character
+	if w == 2 && !b.wrapPending {
+		s.put(continuation)
+	}
+}
+
+// tab moves the cursor to the next tab stop, or to the last column if there
+// is none, without changing the cells it passes over
+func (s *Screen) tab() {
+	b := s.active
+	if b.wrapPending {
+		return
+	}
+
+	b.x = (b.x/s.tabWidth + 1) * s.tabWidth
+	if s.width > 0 {
+		b.x = min(b.x, s.width-1)
+	}
+}
+
+// wrapLine moves the cursor to the next line like a line feed, and keeps
+// track of the line where the wrapped line starts
+func (s *Screen) wrapLine() {
+	b := s.active
+	start := b.y
+	if b.wrapped && b.wrapEnd == b.y {
+		start = b.wrapStart
+	}
+
+	// In case the lines of the scrolling region were moved, the cursor stays
+	// in the same line, and the start of the wrapped line is not known
+	y := b.y
+	s.lineFeed()
+	if b.y != y {
+		b.wrapped, b.wrapStart, b.wrapEnd = true, start, b.y
+	}
+}
+
+// lineFeed moves the cursor to the next line, and scrolls the screen in case
+// the cursor is at the bottom of the scrolling region
+func (s *Screen) lineFeed() {
+	b := s.active
+	b.wrapPending = false
+	b.wrapped = false
+
+	if s.height == 0 {
+		b.y++
+		s.line(b.y)
+		return
+	}
+
+	row := b.y - s.top()
+	switch {
+	case row == b.scrollBottom:
+		s.scrollUp(1)
+
+	case row < s.height-1:
+		b.y++
+	}
+}
+
+func (s *Screen) reverseIndex() {
+	b := s.active
+	b.wrapPending = false
+
+	if row := b.y - s.top(); row == b.scrollTop && s.height > 0 {
+		s.scrollDown(1)
+	} else if row > 0 {
+		b.y--
+	}
+}
+
+// scrollUp moves the lines of the scrolling region up, lines that leave the
+// full screen of the main buffer are kept above the screen
+func (s *Screen) scrollUp(n int) {
+	b := s.active
+	if s.height == 0 {
+		return
+	}
+
+	top := s.top()
+	if b == &s.main && b.scrollTop == 0 && b.scrollBottom == s.height-1 {
+		for i := 0; i < n; i++ {
+			b.lines = append(b.lines, nil)
+		}
+
+		b.y += n
+		return
+	}
+
+	s.deleteLines(top+b.scrollTop, n)
+}
+
+// scrollDown moves the lines of the scrolling region down
+func (s *Screen) scrollDown(n int) {
+	if s.height == 0 {
+		return
+	}
+
+	s.insertLines(s.top()+s.active.scrollTop, n)
+}
+
+// insertLines inserts empty lines at the line, moving the lines below down
+// within the scrolling region
+func (s *Screen) insertLines(y, n int) {
+	b := s.active
+	bottom := s.regionBottom()
+	if y > bottom {
+		return
+	}
+
+	n = min(n, bottom-y+1)
+	lines := append(make([][]bunt.ColoredRune, n), b.lines[y:bottom+1-n]...)
+	copy(b.lines[y:bottom+1], lines)
+}
+
+// deleteLines removes lines at the line, moving the lines below up within
+// the scrolling region
+func (s *Screen) deleteLines(y, n int) {
+	b := s.active
+	bottom := s.regionBottom()
+	if y > bottom {
+		return
+	}
+
+	n = min(n, bottom-y+1)
+	lines := append(append([][]bunt.ColoredRune{}, b.lines[y+n:bottom+1]...), make([][]bunt.ColoredRune, n)...)
+	copy(b.lines[y:bottom+1], lines)
+}
+
+// regionBottom returns the index of the last line of the scrolling region
+func (s *Screen) regionBottom() int {
+	if s.height == 0 {
+		return len(s.active.lines) - 1
+	}
+
+	return s.top() + s.active.scrollBottom
+}
+
+func (s *Screen) eraseInDisplay(mode int) {
+	b := s.active
+	top := s.top()
+
+	switch mode {
+	case 0:
+		s.fill(b.y, b.x, len(s.line(b.y)))
+		for y := b.y + 1; y < len(b.lines); y++ {
+			s.fill(y, 0, max(len(b.lines[y]), s.width))
+		}
+
+	case 1:
+		for y := top; y < b.y; y++ {
+			s.fill(y, 0, max(len(b.lines[y]), s.width))
+		}
+
+		s.fill(b.y, 0, b.x+1)
+
+	case 2:
+		for y := top; y < len(b.lines); y++ {
+			s.fill(y, 0, max(len(b.lines[y]), s.width))
+		}
+
+	case 3:
+		if s.height > 0 {
+			b.lines = b.lines[top:]
+			b.y -= top
+			b.wrapped = false
+		}
+	}
+}
+
+func (s *Screen) eraseInLine(mode int) {
+	b := s.active
+	line := s.line(b.y)
+
+	switch mode {
+	case 0:
+		s.fill(b.y, b.x, max(len(line), s.width))
+
+	case 1:
+		s.fill(b.y, 0, b.x+1)
+
+	case 2:
+		s.fill(b.y, 0, max(len(line), s.width))
+	}
+}
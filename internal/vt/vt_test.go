@@ -0,0 +1,349 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package vt_test
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gonvenience/bunt"
+
+	. "github.com/homeport/termshot/internal/vt"
+)
+
+var _ = Describe("Terminal emulation", func() {
+	var screen = func(width, height int, output ...string) *Screen {
+		s := New(width, height)
+		for _, o := range output {
+			_, err := s.Write([]byte(o))
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		return s
+	}
+
+	var text = func(s *Screen) string {
+		var sb strings.Builder
+		for _, cr := range s.Content() {
+			sb.WriteRune(cr.Symbol)
+		}
+
+		return sb.String()
+	}
+
+	Context("processing text and control characters", func() {
+		It("should overwrite text after a carriage return", func() {
+			Expect(text(screen(0, 0, "progress 10%\rprogress 100%\n"))).To(Equal("progress 100%\n"))
+			Expect(text(screen(0, 0, "foobar\rbaz"))).To(Equal("bazbar"))
+		})
+
+		It("should return to the start of wrapped lines when configured", func() {
+			logical := func(input string) string {
+				screen := New(10, 0)
+				screen.SetLogicalReturn(true)
+				_, err := screen.Write([]byte(input))
+				Expect(err).ToNot(HaveOccurred())
+				return text(screen)
+			}
+
+			Expect(text(screen(10, 0, "[====    ] 50%\r[========] 100%\n"))).To(Equal("[====    ]\n[========]\n 100%\n"))
+			Expect(logical("[====    ] 50%\r[========] 100%\n")).To(Equal("[========]\n 100%\n"))
+			Expect(logical("foo\nbar 10%\rbar 100%\n")).To(Equal("foo\nbar 100%\n"))
+		})
+
+		It("should wrap lines after the number of columns", func() {
+			Expect(text(screen(4, 0, "foobar"))).To(Equal("foob\nar"))
+			Expect(text(screen(4, 0, "foob\nar"))).To(Equal("foob\nar"))
+		})
+
+		It("should report the position of the cursor", func() {
+			x, y := screen(0, 0, "foo\nbar\x1b[2D").Cursor()
+			Expect([]int{x, y}).To(Equal([]int{1, 1}))
+
+			x, y = screen(0, 2, "foo\nbar\nbaz\n").Cursor()
+			Expect([]int{x, y}).To(Equal([]int{0, 1}))
+		})
+
+		It("should move the cursor back with backspace", func() {
+			Expect(text(screen(0, 0, "foo\b\bx"))).To(Equal("fxo"))
+		})
+
+		It("should keep empty lines and trailing line feeds", func() {
+			Expect(text(screen(0, 0, "foo\n\nbar\n\n"))).To(Equal("foo\n\nbar\n\n"))
+		})
+
+		It("should handle sequences that are split across writes", func() {
+			Expect(screen(0, 0, "\x1b[3", "1mfoo\xe2\x9e", "\x9c").String()).To(Equal("\x1b[31mfoo➜\x1b[0m"))
+		})
+
+		It("should keep grapheme clusters in one cell", func() {
+			s := screen(0, 0, "e\u0301x🇩🇪👨\u200d👩\u200d👧")
+			content := s.Content()
+			Expect(content).To(HaveLen(4))
+			Expect(s.Table().Grapheme(content[0].Symbol)).To(Equal("e\u0301"))
+			Expect(s.Table().Grapheme(content[2].Symbol)).To(Equal("🇩🇪"))
+			Expect(s.Table().Grapheme(content[3].Symbol)).To(Equal("👨\u200d👩\u200d👧"))
+			Expect(screen(0, 0, "e\u0301x🇩🇪").String()).To(Equal("e\u0301x🇩🇪"))
+		})
+
+		It("should keep the grapheme clusters of each screen separately", func() {
+			first, second := screen(0, 0, "e\u0301"), screen(0, 0, "a\u0301")
+			Expect(first.Content()[0].Symbol).To(Equal(second.Content()[0].Symbol))
+			Expect(first.String()).To(Equal("e\u0301"))
+			Expect(second.String()).To(Equal("a\u0301"))
+		})
+
+		It("should keep runes of the private use area of the grapheme clusters", func() {
+			s := screen(0, 0, "\U000FE000e\u0301\U000FE000")
+			content := s.Content()
+			Expect(content).To(HaveLen(3))
+			Expect(s.Table().Grapheme(content[0].Symbol)).To(Equal("\U000FE000"))
+			Expect(s.Table().Grapheme(content[1].Symbol)).To(Equal("e\u0301"))
+			Expect(s.String()).To(Equal("\U000FE000e\u0301\U000FE000"))
+		})
+
+		It("should count wide characters as two cells when wrapping lines", func() {
+			Expect(text(screen(4, 0, "日本語"))).To(Equal("日本\n語"))
+			Expect(text(screen(5, 0, "a日本語"))).To(Equal("a日本\n語"))
+			Expect(text(screen(4, 0, "abc日"))).To(Equal("abc\n日"))
+
+			x, y := screen(0, 0, "日本x").Cursor()
+			Expect([]int{x, y}).To(Equal([]int{3, 0}))
+		})
+
+		It("should move to the next tab stop for tabs", func() {
+			Expect(text(screen(0, 0, "a\tb\nabcdefgh\tc\n\t\td"))).To(Equal("a       b\nabcdefgh        c\n                d"))
+			Expect(text(screen(10, 0, "abcdefgh\tc\td"))).To(Equal("abcdefgh c\nd"))
+
+			tabs := New(0, 0)
+			tabs.SetTabWidth(4)
+			_, _ = tabs.Write([]byte("ab\tc"))
+			Expect(text(tabs)).To(Equal("ab  c"))
+		})
+
+		It("should translate the line drawing character set", func() {
+			Expect(text(screen(0, 0, "\x1b(0lqk\x1b(Bq"))).To(Equal("┌─┐q"))
+		})
+	})
+
+	Context("processing cursor movements and erase sequences", func() {
+		It("should update lines above the cursor", func() {
+			Expect(text(screen(0, 0, "foo\nbar\n\x1b[2A\x1b[2Kbaz\n"))).To(Equal("baz\nbar"))
+		})
+
+		It("should position the cursor", func() {
+			Expect(text(screen(10, 0, "\x1b[2;3Hx\x1b[1;1Hy\x1b[5Gz"))).To(Equal("y   z\n  x"))
+		})
+
+		It("should clear the screen", func() {
+			Expect(text(screen(0, 0, "foo\nbar\n\x1b[H\x1b[2Jbaz"))).To(Equal("baz"))
+		})
+
+		It("should erase parts of a line", func() {
+			Expect(text(screen(0, 0, "foobar\x1b[3D\x1b[K"))).To(Equal("foo"))
+			Expect(text(screen(0, 0, "foobar\x1b[3D\x1b[1K"))).To(Equal("    ar"))
+			Expect(text(screen(0, 0, "foobar\x1b[1G\x1b[2X"))).To(Equal("  obar"))
+			Expect(text(screen(0, 0, "foobar\x1b[1G\x1b[2P"))).To(Equal("obar"))
+		})
+
+		It("should keep erased cells with a background color", func() {
+			content := screen(0, 0, "foo\x1b[44m\x1b[K\x1b[0m").Content()
+			Expect(content).To(HaveLen(3))
+
+			content = screen(5, 0, "foo\x1b[44m\x1b[K\x1b[0m").Content()
+			Expect(content).To(HaveLen(5))
+			Expect(content[4].Symbol).To(Equal(' '))
+			Expect(content[4].Settings & 0x02).ToNot(BeZero())
+		})
+	})
+
+	Context("processing a screen with fixed size", func() {
+		It("should scroll lines out of the screen", func() {
+			Expect(text(screen(10, 3, "1\n2\n3\n4\n5"))).To(Equal("3\n4\n5"))
+		})
+
+		It("should keep the configured number of lines scrolled out of the screen", func() {
+			s := New(10, 3)
+			s.SetScrollback(1)
+			_, err := s.Write([]byte("1\n2\n3\n4\n5"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(text(s)).To(Equal("2\n3\n4\n5"))
+
+			x, y := s.Cursor()
+			Expect(x).To(Equal(1))
+			Expect(y).To(Equal(3))
+		})
+
+		It("should keep all lines scrolled out of the screen when configured", func() {
+			s := New(10, 3)
+			s.SetScrollback(-1)
+			_, err := s.Write([]byte("1\n2\n3\n4\n5"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(text(s)).To(Equal("1\n2\n3\n4\n5"))
+		})
+
+		It("should only scroll the scrolling region", func() {
+			Expect(text(screen(10, 4, "header\n\x1b[2;3r\x1b[2;1H1\n2\n3\x1b[4;1Hfooter"))).To(Equal("header\n2\n3\nfooter"))
+		})
+
+		It("should restore the main screen after using the alternate screen", func() {
+			s := screen(10, 3, "foo\n\x1b[?1049h\x1b[Hbar")
+			Expect(text(s)).To(Equal("bar"))
+
+			_, _ = s.Write([]byte("\x1b[?1049l"))
+			Expect(text(s)).To(Equal("foo\n"))
+		})
+	})
+
+	Context("processing graphic renditions", func() {
+		It("should add attributes to the previously set ones", func() {
+			content := screen(0, 0, "\x1b[1mf\x1b[31mo\x1b[22mo\x1b[39mb").Content()
+			Expect(content).To(Equal(bunt.String{
+				{Symbol: 'f', Settings: 0x04},
+				{Symbol: 'o', Settings: 0x04 | 0x01 | 1<<56 | 1<<8},
+				{Symbol: 'o', Settings: 0x01 | 1<<56 | 1<<8},
+				{Symbol: 'b'},
+			}))
+		})
+
+		It("should support colon separated sub-parameters", func() {
+			Expect(screen(0, 0, "\x1b[38:2::1:2:3mx").Content()).To(Equal(bunt.String{
+				{Symbol: 'x', Settings: 0x01 | 1<<8 | 2<<16 | 3<<24},
+			}))
+		})
+
+		It("should render content that results in the same content when processed again", func() {
+			original := screen(0, 0, "\x1b[1;31mfoo\x1b[39m \x1b[9;5mbar\x1b[0m\n\x1b[48;5;21mbaz\x1b[0m")
+			Expect(screen(0, 0, original.String()).Content()).To(Equal(original.Content()))
+		})
+
+		It("should keep the palette index of colors of the 8-bit palette", func() {
+			for n := 0; n < 256; n++ {
+				content := screen(0, 0, "\x1b[38;5;"+strconv.Itoa(n)+";48;5;"+strconv.Itoa(255-n)+"mx").Content()
+				index, ok := PaletteIndex(content[0].Settings, false)
+				Expect(ok).To(BeTrue())
+				Expect(index).To(Equal(n))
+
+				index, ok = PaletteIndex(content[0].Settings, true)
+				Expect(ok).To(BeTrue())
+				Expect(index).To(Equal(255 - n))
+			}
+
+			content := screen(0, 0, "\x1b[91mx").Content()
+			index, ok := PaletteIndex(content[0].Settings, false)
+			Expect(ok).To(BeTrue())
+			Expect(index).To(Equal(9))
+
+			r, g, b, ok := Color(content[0].Settings, false)
+			Expect(ok).To(BeTrue())
+			Expect([]uint8{r, g, b}).To(Equal([]uint8{255, 0, 0}))
+		})
+
+		It("should keep the exact values of colors of the palette", func() {
+			content := screen(0, 0, "\x1b[38;5;16mx\x1b[38;5;231my\x1b[38;2;0;0;0mz").Content()
+			for i, expected := range [][]uint8{{0, 0, 0}, {255, 255, 255}, {0, 0, 0}} {
+				r, g, b, ok := Color(content[i].Settings, false)
+				Expect(ok).To(BeTrue())
+				Expect([]uint8{r, g, b}).To(Equal(expected))
+			}
+
+			_, ok := PaletteIndex(content[2].Settings, false)
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should turn off bold and dim text together", func() {
+			Expect(screen(0, 0, "\x1b[1;2mx\x1b[22my").Content()).To(Equal(bunt.String{
+				{Symbol: 'x', Settings: 0x04 | 0x80},
+				{Symbol: 'y'},
+			}))
+		})
+
+		It("should track the style and color of underlines", func() {
+			s := screen(0, 0, "\x1b[4:3;58;2;255;0;0mx\x1b[21;59my\x1b[4:0mz")
+			content := s.Content()
+			Expect(content).To(HaveLen(3))
+			Expect(s.Table().Underline(content[0].Settings)).To(Equal(UnderlineCurly))
+			Expect(s.Table().Underline(content[1].Settings)).To(Equal(UnderlineDouble))
+			Expect(s.Table().Underline(content[2].Settings)).To(Equal(UnderlineNone))
+
+			c, ok := s.Table().UnderlineColor(content[0].Settings)
+			Expect(ok).To(BeTrue())
+			r, g, b, _ := Color(c, false)
+			Expect([]uint8{r, g, b}).To(Equal([]uint8{255, 0, 0}))
+
+			_, ok = s.Table().UnderlineColor(content[1].Settings)
+			Expect(ok).To(BeFalse())
+
+			Expect(screen(0, 0, screen(0, 0, "\x1b[4:5;58:5:21mx").String()).Content()).To(Equal(screen(0, 0, "\x1b[4:5;58:5:21mx").Content()))
+		})
+
+		It("should keep the underline colors of each screen separately", func() {
+			var output strings.Builder
+			for i := 0; i < 40; i++ {
+				fmt.Fprintf(&output, "\x1b[4;58;2;%d;0;0mx", i*6)
+			}
+
+			first := screen(0, 0, output.String())
+			content := first.Content()
+			c, ok := first.Table().UnderlineColor(content[len(content)-1].Settings)
+			Expect(ok).To(BeTrue())
+			r, g, b, _ := Color(c, false)
+			Expect([]uint8{r, g, b}).To(Equal([]uint8{180, 0, 0}))
+
+			second := screen(0, 0, "\x1b[4;58;2;0;0;255mx")
+			c, ok = second.Table().UnderlineColor(second.Content()[0].Settings)
+			Expect(ok).To(BeTrue())
+			r, g, b, _ = Color(c, false)
+			Expect([]uint8{r, g, b}).To(Equal([]uint8{0, 0, 255}))
+		})
+
+		It("should track reverse video until it is turned off", func() {
+			content := screen(0, 0, "\x1b[7;31mx\x1b[27my \x1b[7m \x1b[0m").Content()
+			Expect(content).To(Equal(bunt.String{
+				{Symbol: 'x', Settings: 0x20 | 0x01 | 1<<56 | 1<<8},
+				{Symbol: 'y', Settings: 0x01 | 1<<56 | 1<<8},
+				{Symbol: ' ', Settings: 0x01 | 1<<56 | 1<<8},
+				{Symbol: ' ', Settings: 0x20 | 0x01 | 1<<56 | 1<<8},
+			}))
+		})
+	})
+
+	Context("linting output", func() {
+		It("should not report supported sequences", func() {
+			Expect(Lint([]byte("\x1b[1mbold\x1b[0m \x1b[38;5;123mcolor\x1b[0m\r\n\x1b[2J\x1b[5Gfoo\x07"))).To(BeEmpty())
+		})
+
+		It("should report unsupported sequences with their offset", func() {
+			issues := Lint([]byte("foo\x1b[53mbar\x1b[0m\x1bPq\x1b\\\x1b[38;5m\x0e\x1b["))
+			Expect(issues).To(HaveLen(5))
+			Expect(issues[0].Offset).To(Equal(3))
+			Expect(issues[0].Message).To(ContainSubstring("overline"))
+			Expect(issues[1].Message).To(ContainSubstring("string sequence"))
+			Expect(issues[2].Message).To(ContainSubstring("color selection"))
+			Expect(issues[3].Message).To(ContainSubstring("control character"))
+			Expect(issues[4].Message).To(ContainSubstring("not terminated"))
+		})
+	})
+})
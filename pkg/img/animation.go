@@ -0,0 +1,300 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package img
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/HugoSmits86/nativewebp"
+)
+
+// Frame is a single image of an animation with the time it is shown
+type Frame struct {
+	Image image.Image
+	Delay time.Duration
+}
+
+// WriteGIF writes the frames as an animated GIF into the provided writer,
+// using one shared palette for all frames. Since GIF does not support
+// partial transparency, pixels are either fully transparent or opaque.
+func WriteGIF(w io.Writer, frames []Frame) error {
+	if len(frames) == 0 {
+		return fmt.Errorf("failed to write GIF: no frames")
+	}
+
+	var bounds image.Rectangle
+	for _, frame := range frames {
+		bounds = bounds.Union(frame.Image.Bounds().Sub(frame.Image.Bounds().Min))
+	}
+
+	palette := gifPalette(frames)
+	lookup := map[color.NRGBA]uint8{}
+
+	animation := gif.GIF{
+		Image:    make([]*image.Paletted, len(frames)),
+		Delay:    make([]int, len(frames)),
+		Disposal: make([]byte, len(frames)),
+		Config: image.Config{
+			ColorModel: palette,
+			Width:      bounds.Dx(),
+			Height:     bounds.Dy(),
+		},
+	}
+
+	for i, frame := range frames {
+		paletted := image.NewPaletted(bounds, palette)
+		src := frame.Image.Bounds()
+		for y := src.Min.Y; y < src.Max.Y; y++ {
+			for x := src.Min.X; x < src.Max.X; x++ {
+				c := gifColor(frame.Image.At(x, y))
+				index, ok := lookup[c]
+				if !ok {
+					index = uint8(palette.Index(c)) // #nosec G115 -- palette has at most 256 colors
+					lookup[c] = index
+				}
+
+				paletted.SetColorIndex(x-src.Min.X, y-src.Min.Y, index)
+			}
+		}
+
+		animation.Image[i] = paletted
+		animation.Delay[i] = max(2, int(frame.Delay.Round(10*time.Millisecond)/(10*time.Millisecond)))
+		animation.Disposal[i] = gif.DisposalBackground
+	}
+
+	return gif.EncodeAll(w, &animation)
+}
+
+// WriteAnimatedWebP writes the frames as an animated lossless WebP into the
+// provided writer. Each frame after the first one only contains the area
+// that changed compared to the previous frame, which keeps the file small.
+func WriteAnimatedWebP(w io.Writer, frames []Frame, opts WebPOptions) error {
+	if len(frames) == 0 {
+		return fmt.Errorf("failed to write WebP: no frames")
+	}
+
+	var bounds image.Rectangle
+	for _, frame := range frames {
+		bounds = bounds.Union(frame.Image.Bounds().Sub(frame.Image.Bounds().Min))
+	}
+
+	var body bytes.Buffer
+	body.WriteString("WEBP")
+
+	// Extended header with the animation and alpha flags, and the canvas size
+	vp8x := make([]byte, 10)
+	vp8x[0] = 0x10 | 0x02
+	putUint24(vp8x[4:], bounds.Dx()-1)
+	putUint24(vp8x[7:], bounds.Dy()-1)
+	writeRIFFChunk(&body, "VP8X", vp8x)
+
+	// Transparent background color and an endless loop
+	writeRIFFChunk(&body, "ANIM", make([]byte, 6))
+
+	var previous *image.RGBA
+	for _, frame := range frames {
+		canvas := image.NewRGBA(bounds)
+		draw.Draw(canvas, bounds, frame.Image, frame.Image.Bounds().Min, draw.Src)
+
+		area := bounds
+		if previous != nil {
+			area = changedArea(previous, canvas)
+		}
+
+		previous = canvas
+
+		var img image.Image = canvas.SubImage(area)
+		if opts.Quality > 0 && opts.Quality < 100 {
+			img = paletted(img)
+		}
+
+		var encoded bytes.Buffer
+		if err := nativewebp.Encode(&encoded, img, nil); err != nil {
+			return fmt.Errorf("failed to write WebP: %w", err)
+		}
+
+		// The frame header is followed by the image data chunk of the
+		// encoded image, which comes after the RIFF header of the file
+		anmf := make([]byte, 16, 16+encoded.Len()-12)
+		putUint24(anmf[0:], area.Min.X/2)
+		putUint24(anmf[3:], area.Min.Y/2)
+		putUint24(anmf[6:], area.Dx()-1)
+		putUint24(anmf[9:], area.Dy()-1)
+		putUint24(anmf[12:], min(int(frame.Delay.Milliseconds()), 1<<24-1))
+		anmf[15] = 0x02 // replace the area instead of blending
+		anmf = append(anmf, encoded.Bytes()[12:]...)
+		writeRIFFChunk(&body, "ANMF", anmf)
+	}
+
+	header := make([]byte, 8)
+	copy(header, "RIFF")
+	binary.LittleEndian.PutUint32(header[4:], uint32(body.Len())) // #nosec G115 -- size of the encoded frames
+
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+
+	_, err := body.WriteTo(w)
+	return err
+}
+
+// changedArea returns the area of all pixels that differ between the two
+// images, starting at even coordinates as required for WebP frames, or the
+// first pixel in case both images are the same
+func changedArea(a, b *image.RGBA) image.Rectangle {
+	var area image.Rectangle
+	bounds := a.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			i := a.PixOffset(x, y)
+			if !bytes.Equal(a.Pix[i:i+4], b.Pix[i:i+4]) {
+				area = area.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+
+	if area.Empty() {
+		return image.Rect(bounds.Min.X, bounds.Min.Y, bounds.Min.X+1, bounds.Min.Y+1)
+	}
+
+	area.Min.X -= area.Min.X % 2
+	area.Min.Y -= area.Min.Y % 2
+	return area
+}
+
+// writeRIFFChunk writes a RIFF chunk with the identifier and data, which is
+// padded to an even size
+func writeRIFFChunk(w *bytes.Buffer, id string, data []byte) {
+	size := make([]byte, 4)
+	binary.LittleEndian.PutUint32(size, uint32(len(data))) // #nosec G115 -- size of an encoded frame
+
+	w.WriteString(id)
+	w.Write(size)
+	w.Write(data)
+	if len(data)%2 != 0 {
+		w.WriteByte(0)
+	}
+}
+
+// putUint24 writes the value as 24 bit little endian number
+func putUint24(b []byte, value int) {
+	b[0] = byte(value)
+	b[1] = byte(value >> 8)
+	b[2] = byte(value >> 16)
+}
+
+// gifColor converts the color into an opaque color, or into the fully
+// transparent color for mostly transparent colors
+func gifColor(c color.Color) color.NRGBA {
+	nrgba := color.NRGBAModel.Convert(c).(color.NRGBA)
+	if nrgba.A < 0x80 {
+		return color.NRGBA{}
+	}
+
+	nrgba.A = 0xFF
+	return nrgba
+}
+
+// gifPalette creates a palette with the transparent color and the most used
+// colors of all frames, where similar colors are combined in case there are
+// more colors than a GIF palette can hold
+func gifPalette(frames []Frame) color.Palette {
+	counts := map[color.NRGBA]int{}
+	for _, frame := range frames {
+		bounds := frame.Image.Bounds()
+		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+			for x := bounds.Min.X; x < bounds.Max.X; x++ {
+				if c := gifColor(frame.Image.At(x, y)); c.A > 0 {
+					counts[c]++
+				}
+			}
+		}
+	}
+
+	type bucket struct{ r, g, b, count int }
+	buckets := map[[3]uint8]*bucket{}
+	for c, count := range counts {
+		key := [3]uint8{c.R, c.G, c.B}
+		if len(counts) > 255 {
+			key = [3]uint8{c.R >> 3, c.G >> 3, c.B >> 3}
+		}
+
+		if _, ok := buckets[key]; !ok {
+			buckets[key] = &bucket{}
+		}
+
+		buckets[key].r += int(c.R) * count
+		buckets[key].g += int(c.G) * count
+		buckets[key].b += int(c.B) * count
+		buckets[key].count += count
+	}
+
+	type entry struct {
+		color color.NRGBA
+		count int
+	}
+
+	entries := make([]entry, 0, len(buckets))
+	for _, b := range buckets {
+		entries = append(entries, entry{
+			color: color.NRGBA{
+				R: uint8(b.r / b.count), // #nosec G115 -- average of uint8 values
+				G: uint8(b.g / b.count), // #nosec G115 -- average of uint8 values
+				B: uint8(b.b / b.count), // #nosec G115 -- average of uint8 values
+				A: 0xFF,
+			},
+			count: b.count,
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		switch {
+		case a.count != b.count:
+			return a.count > b.count
+
+		case a.color.R != b.color.R:
+			return a.color.R < b.color.R
+
+		case a.color.G != b.color.G:
+			return a.color.G < b.color.G
+
+		default:
+			return a.color.B < b.color.B
+		}
+	})
+
+	palette := color.Palette{color.NRGBA{}}
+	for _, e := range entries[:min(len(entries), 255)] {
+		palette = append(palette, e.color)
+	}
+
+	return palette
+}
@@ -0,0 +1,146 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package img
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/draw"
+	"io"
+)
+
+// WriteAPNG writes the frames as an animated PNG into the provided writer,
+// which keeps all colors and the transparency of the images. Each frame
+// after the first one only contains the area that changed compared to the
+// previous frame. Viewers without animation support show the first frame.
+func WriteAPNG(w io.Writer, frames []Frame) error {
+	if len(frames) == 0 {
+		return fmt.Errorf("failed to write APNG: no frames")
+	}
+
+	var bounds image.Rectangle
+	for _, frame := range frames {
+		bounds = bounds.Union(frame.Image.Bounds().Sub(frame.Image.Bounds().Min))
+	}
+
+	var buf bytes.Buffer
+	buf.Write(pngSignature)
+
+	// All frames use 8 bit RGBA, since the image data of the frames has to
+	// match the header of the image
+	ihdr := make([]byte, 13)
+	binary.BigEndian.PutUint32(ihdr[0:], uint32(bounds.Dx())) // #nosec G115
+	binary.BigEndian.PutUint32(ihdr[4:], uint32(bounds.Dy())) // #nosec G115
+	ihdr[8], ihdr[9] = 8, 6
+	writeChunk(&buf, "IHDR", ihdr)
+
+	// Number of frames and an endless loop
+	actl := make([]byte, 8)
+	binary.BigEndian.PutUint32(actl[0:], uint32(len(frames))) // #nosec G115
+	writeChunk(&buf, "acTL", actl)
+
+	var sequence uint32
+	var previous *image.RGBA
+	for i, frame := range frames {
+		canvas := image.NewRGBA(bounds)
+		draw.Draw(canvas, bounds, frame.Image, frame.Image.Bounds().Min, draw.Src)
+
+		area := bounds
+		if previous != nil {
+			area = changedArea(previous, canvas)
+		}
+
+		previous = canvas
+
+		// Frame control with the area and delay of the frame, which replaces
+		// the area of the previous frame and is kept as-is afterwards
+		delay := min(frame.Delay.Milliseconds(), 0xFFFF)
+
+		fctl := make([]byte, 26)
+		binary.BigEndian.PutUint32(fctl[0:], sequence)
+		binary.BigEndian.PutUint32(fctl[4:], uint32(area.Dx()))   // #nosec G115
+		binary.BigEndian.PutUint32(fctl[8:], uint32(area.Dy()))   // #nosec G115
+		binary.BigEndian.PutUint32(fctl[12:], uint32(area.Min.X)) // #nosec G115
+		binary.BigEndian.PutUint32(fctl[16:], uint32(area.Min.Y)) // #nosec G115
+		binary.BigEndian.PutUint16(fctl[20:], uint16(delay))      // #nosec G115
+		binary.BigEndian.PutUint16(fctl[22:], 1000)
+		writeChunk(&buf, "fcTL", fctl)
+		sequence++
+
+		data, err := pngImageData(canvas.SubImage(area).(*image.RGBA))
+		if err != nil {
+			return fmt.Errorf("failed to write APNG: %w", err)
+		}
+
+		if i == 0 {
+			writeChunk(&buf, "IDAT", data)
+			continue
+		}
+
+		fdat := make([]byte, 4, 4+len(data))
+		binary.BigEndian.PutUint32(fdat, sequence)
+		writeChunk(&buf, "fdAT", append(fdat, data...))
+		sequence++
+	}
+
+	writeChunk(&buf, "IEND", nil)
+
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// pngImageData returns the compressed image data of the image encoded as
+// 8 bit RGBA, which is the content of the image data chunks of a PNG image
+func pngImageData(img *image.RGBA) ([]byte, error) {
+	bounds := img.Bounds()
+
+	var encoded bytes.Buffer
+	enc, err := newPNGEncoder(&encoded, bounds.Dx(), bounds.Dy(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		offset := img.PixOffset(bounds.Min.X, y)
+		if err := enc.writeRow(img.Pix[offset : offset+4*bounds.Dx()]); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := enc.close(); err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	png := encoded.Bytes()
+	for offset := len(pngSignature); offset+12 <= len(png); {
+		length := int(binary.BigEndian.Uint32(png[offset:]))
+		if string(png[offset+4:offset+8]) == "IDAT" {
+			data = append(data, png[offset+8:offset+8+length]...)
+		}
+
+		offset += 12 + length
+	}
+
+	return data, nil
+}
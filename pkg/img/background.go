@@ -0,0 +1,118 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package img
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/fogleman/gg"
+	"golang.org/x/image/draw"
+)
+
+// Background draws the backdrop of the canvas behind the window and its
+// shadow, the canvas has the given size in pixels
+type Background func(dc *gg.Context, width, height float64) error
+
+// SetBackground configures the background of the canvas, which is
+// transparent by default, use nil to remove the background again. Since the
+// canvas is no longer transparent, the canvas cannot be clipped.
+func (s *Scaffold) SetBackground(background Background) { s.background = background }
+
+// SolidBackground returns a background with a single color
+func SolidBackground(c color.Color) Background {
+	return func(dc *gg.Context, width, height float64) error {
+		dc.DrawRectangle(0, 0, width, height)
+		dc.SetColor(c)
+		dc.Fill()
+		return nil
+	}
+}
+
+// LinearGradientBackground returns a background with the colors evenly
+// distributed along a linear gradient, where the angle in degrees defines the
+// direction of the gradient like in CSS (90 is from left to right, 180 is from
+// top to bottom)
+func LinearGradientBackground(angle float64, colors ...color.Color) Background {
+	return func(dc *gg.Context, width, height float64) error {
+		// The gradient line goes through the center of the canvas and is
+		// long enough so that the corners get the first and last color
+		rad := angle * math.Pi / 180
+		dx, dy := math.Sin(rad), -math.Cos(rad)
+		length := math.Abs(width*dx) + math.Abs(height*dy)
+
+		cx, cy := width/2, height/2
+		gradient := gg.NewLinearGradient(cx-dx*length/2, cy-dy*length/2, cx+dx*length/2, cy+dy*length/2)
+		addColorStops(gradient, colors)
+
+		dc.DrawRectangle(0, 0, width, height)
+		dc.SetFillStyle(gradient)
+		dc.Fill()
+		return nil
+	}
+}
+
+// RadialGradientBackground returns a background with the colors evenly
+// distributed from the center of the canvas to its corners
+func RadialGradientBackground(colors ...color.Color) Background {
+	return func(dc *gg.Context, width, height float64) error {
+		cx, cy := width/2, height/2
+		gradient := gg.NewRadialGradient(cx, cy, 0, cx, cy, math.Hypot(cx, cy))
+		addColorStops(gradient, colors)
+
+		dc.DrawRectangle(0, 0, width, height)
+		dc.SetFillStyle(gradient)
+		dc.Fill()
+		return nil
+	}
+}
+
+// ImageBackground returns a background with the image scaled to cover the
+// whole canvas, parts of the image that do not fit are cut off evenly
+func ImageBackground(img image.Image) Background {
+	return func(dc *gg.Context, width, height float64) error {
+		bounds := img.Bounds()
+		scale := math.Max(width/float64(bounds.Dx()), height/float64(bounds.Dy()))
+
+		// Source area that covers the canvas with the aspect ratio of the canvas
+		srcWidth, srcHeight := width/scale, height/scale
+		x := bounds.Min.X + int((float64(bounds.Dx())-srcWidth)/2)
+		y := bounds.Min.Y + int((float64(bounds.Dy())-srcHeight)/2)
+		src := image.Rect(x, y, x+int(math.Round(srcWidth)), y+int(math.Round(srcHeight)))
+
+		scaled := image.NewRGBA(image.Rect(0, 0, int(width), int(height)))
+		draw.CatmullRom.Scale(scaled, scaled.Bounds(), img, src, draw.Src, nil)
+		dc.DrawImage(scaled, 0, 0)
+		return nil
+	}
+}
+
+func addColorStops(gradient gg.Gradient, colors []color.Color) {
+	for i, c := range colors {
+		var offset float64
+		if len(colors) > 1 {
+			offset = float64(i) / float64(len(colors)-1)
+		}
+
+		gradient.AddColorStop(offset, c)
+	}
+}
@@ -0,0 +1,383 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package img
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"io"
+	"math"
+	"sort"
+
+	"github.com/fogleman/gg"
+)
+
+// bandedPixels is the image size in pixels from which on PNG images are
+// rendered and encoded in bands, so that very large captures do not need
+// the whole image in memory
+const bandedPixels = 1 << 26
+
+// defaultBandHeight is the height in pixels of the bands used when PNG
+// images are rendered in bands automatically
+const defaultBandHeight = 512
+
+// idatSize is the amount of compressed image data written per PNG chunk
+const idatSize = 1 << 16
+
+// WritePNGBanded writes the scaffold content as PNG like [Scaffold.WritePNG],
+// but renders and encodes the image in horizontal bands of the given height,
+// so that the memory needed is bounded by the width of the image and the band
+// height, regardless of the length of the content. Custom backgrounds,
+// decorations, and watermarks can draw anywhere on the canvas, therefore the
+// image is rendered at once when any of them is used.
+func (s *Scaffold) WritePNGBanded(w io.Writer, bandHeight int) error {
+	if bandHeight <= 0 {
+		return fmt.Errorf("band height must be positive, got %d", bandHeight)
+	}
+
+	chunks, err := s.pngChunks()
+	if err != nil {
+		return err
+	}
+
+	if !s.bandable() {
+		return s.writePNG(context.Background(), w, chunks)
+	}
+
+	return s.writePNGBanded(context.Background(), w, s.frame(), bandHeight, chunks)
+}
+
+// bandable returns whether the image can be rendered in bands, which is not
+// the case for elements that need the whole canvas to be drawn
+func (s *Scaffold) bandable() bool {
+	return s.background == nil && len(s.decorations) == 0 && s.watermark == nil && s.cursorStyle == CursorNone && s.cardWidth == 0
+}
+
+func (s *Scaffold) writePNGBanded(ctx context.Context, w io.Writer, fr frame, bandHeight int, chunks []pngChunk) error {
+	bounds := image.Rect(0, 0, int(fr.width), int(fr.height))
+
+	// Optional: Clip image to the window and its shadow, which is known from
+	// the layout, so that only the bands in the area have to be rendered
+	//
+	if s.clipCanvas {
+		bounds = bounds.Intersect(s.visibleArea(fr).bounds())
+	}
+
+	enc, err := newPNGEncoder(w, bounds.Dx(), bounds.Dy(), chunks)
+	if err != nil {
+		return err
+	}
+
+	if err := s.renderBands(ctx, fr, bounds, bandHeight, func(band *image.RGBA) error {
+		for y := band.Rect.Min.Y; y < band.Rect.Max.Y; y++ {
+			if err := enc.writeRow(band.Pix[band.PixOffset(bounds.Min.X, y):band.PixOffset(bounds.Max.X, y)]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	return enc.close()
+}
+
+// renderBands renders the rows of the bounds in bands of the given height and
+// calls the function for each band, which uses the coordinates of the canvas;
+// the band image is reused for the next band
+func (s *Scaffold) renderBands(ctx context.Context, fr frame, bounds image.Rectangle, bandHeight int, fn func(band *image.RGBA) error) error {
+	width := int(fr.width)
+	lineHeight := s.fontHeight() * s.lineSpacing
+
+	// Shapes reaching above the drawn area are rasterized slightly different
+	// close to the edge, therefore some rows above each band are drawn, too
+	slack := int(math.Ceil(4 * lineHeight))
+
+	// All glyphs whose characters, backgrounds, or blurred redactions can
+	// reach into the drawn area are needed to draw it
+	reach := 2*float64(slack) + s.factor*3
+
+	img := image.NewRGBA(image.Rect(0, 0, width, bandHeight+slack))
+	numbers := s.lineNumberGlyphs(fr)
+	layout := s.glyphLayout(fr)
+	next, more := layout.next()
+
+	var glyphs []glyph
+	for y0 := bounds.Min.Y; y0 < bounds.Max.Y; y0 += bandHeight {
+		y1 := min(y0+bandHeight, bounds.Max.Y)
+		area := image.Rect(0, max(0, y0-slack), width, y1)
+		lo, hi := float64(area.Min.Y)-reach, float64(area.Max.Y)+reach
+
+		// Glyphs are placed line by line, therefore complete lines are
+		// dropped and added, which keeps redacted runs of a line together
+		drop := 0
+		for drop < len(glyphs) && glyphs[drop].y < lo {
+			drop++
+		}
+
+		glyphs = glyphs[drop:]
+		for ; more && next.y <= hi; next, more = layout.next() {
+			if next.y >= lo {
+				glyphs = append(glyphs, next)
+			}
+		}
+
+		first := sort.Search(len(numbers), func(i int) bool { return numbers[i].y >= lo })
+		last := sort.Search(len(numbers), func(i int) bool { return numbers[i].y > hi })
+
+		clear(img.Pix)
+		dc := gg.NewContextForRGBA(img.SubImage(image.Rect(0, 0, width, area.Dy())).(*image.RGBA))
+		if err := s.draw(ctx, dc, fr, area, glyphs, numbers[first:last]); err != nil {
+			return err
+		}
+
+		offset := img.PixOffset(0, y0-area.Min.Y)
+		band := &image.RGBA{
+			Pix:    img.Pix[offset : offset+(y1-y0)*img.Stride],
+			Stride: img.Stride,
+			Rect:   image.Rect(0, y0, width, y1),
+		}
+
+		if err := fn(band); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// drawRoundedRectangle adds a rounded rectangle to the path, where a plain
+// rectangle is used when the visible area only shows the straight sides,
+// since rasterizing the full shape would process all of its rows again for
+// each area of a very tall image
+func drawRoundedRectangle(dc *gg.Context, a Area, r float64, visible image.Rectangle) {
+	top, bottom := float64(visible.Min.Y), float64(visible.Max.Y)
+	if r == 0 {
+		dc.DrawRectangle(a.X, a.Y, a.Width, a.Height)
+		return
+	}
+
+	if top > a.Y+r+2 && bottom < a.Y+a.Height-r-2 {
+		dc.DrawRectangle(a.X, top-8, a.Width, bottom-top+16)
+		return
+	}
+
+	dc.DrawRoundedRectangle(a.X, a.Y, a.Width, a.Height, r)
+}
+
+// pngEncoder writes a PNG image row by row, with the compressed image data
+// split into multiple chunks, so that the image never has to be complete in
+// memory; rows are filtered using the same heuristic as the standard library
+type pngEncoder struct {
+	w    io.Writer
+	zw   *zlib.Writer
+	idat idatWriter
+
+	prev, cur []byte
+	filtered  [5][]byte
+}
+
+func newPNGEncoder(w io.Writer, width, height int, chunks []pngChunk) (*pngEncoder, error) {
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("invalid image size %dx%d", width, height)
+	}
+
+	header := make([]byte, 13)
+	binary.BigEndian.PutUint32(header[0:], uint32(width))  // #nosec G115
+	binary.BigEndian.PutUint32(header[4:], uint32(height)) // #nosec G115
+	header[8] = 8                                          // bit depth
+	header[9] = 6                                          // true color with alpha
+
+	var buf bytes.Buffer
+	buf.Write(pngSignature)
+	writeChunk(&buf, "IHDR", header)
+	for _, chunk := range chunks {
+		writeChunk(&buf, chunk.kind, chunk.data)
+	}
+
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return nil, err
+	}
+
+	enc := &pngEncoder{
+		w:    w,
+		idat: idatWriter{w: w},
+		prev: make([]byte, 4*width),
+		cur:  make([]byte, 4*width),
+	}
+
+	for i := range enc.filtered {
+		enc.filtered[i] = make([]byte, 1+4*width)
+		enc.filtered[i][0] = byte(i)
+	}
+
+	enc.zw = zlib.NewWriter(&enc.idat)
+	return enc, nil
+}
+
+// writeRow writes the next row of alpha-premultiplied pixels, which are
+// converted the same way as the standard library converts them
+func (e *pngEncoder) writeRow(pix []byte) error {
+	for i := 0; i+3 < len(pix) && i+3 < len(e.cur); i += 4 {
+		switch a := pix[i+3]; a {
+		case 0x00:
+			clear(e.cur[i : i+4])
+
+		case 0xFF:
+			copy(e.cur[i:i+4], pix[i:i+4])
+
+		default:
+			const m = 0x101 * 0xFFFF
+			a16 := uint32(a) * 0x101
+			e.cur[i+0] = uint8((uint32(pix[i+0]) * m / a16) >> 8)
+			e.cur[i+1] = uint8((uint32(pix[i+1]) * m / a16) >> 8)
+			e.cur[i+2] = uint8((uint32(pix[i+2]) * m / a16) >> 8)
+			e.cur[i+3] = a
+		}
+	}
+
+	if _, err := e.zw.Write(e.filter()); err != nil {
+		return err
+	}
+
+	e.prev, e.cur = e.cur, e.prev
+	return nil
+}
+
+// filter applies all filter types to the current row and returns the one
+// with the smallest sum of absolute differences
+func (e *pngEncoder) filter() []byte {
+	const bpp = 4
+
+	cur, prev := e.cur, e.prev
+	best, bestSum := 0, math.MaxInt
+	for ft, out := range e.filtered {
+		sum := 0
+		for i := range cur {
+			var left, upLeft byte
+			if i >= bpp {
+				left, upLeft = cur[i-bpp], prev[i-bpp]
+			}
+
+			var d byte
+			switch ft {
+			case 0:
+				d = cur[i]
+			case 1:
+				d = cur[i] - left
+			case 2:
+				d = cur[i] - prev[i]
+			case 3:
+				d = cur[i] - uint8((int(left)+int(prev[i]))/2)
+			case 4:
+				d = cur[i] - paeth(left, prev[i], upLeft)
+			}
+
+			out[i+1] = d
+			sum += abs(int(int8(d)))
+		}
+
+		if sum < bestSum {
+			best, bestSum = ft, sum
+		}
+	}
+
+	return e.filtered[best]
+}
+
+func (e *pngEncoder) close() error {
+	if err := e.zw.Close(); err != nil {
+		return err
+	}
+
+	if err := e.idat.flush(); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	writeChunk(&buf, "IEND", nil)
+	_, err := e.w.Write(buf.Bytes())
+	return err
+}
+
+// idatWriter collects compressed image data and writes it as image data
+// chunks of limited size
+type idatWriter struct {
+	w   io.Writer
+	buf []byte
+}
+
+func (iw *idatWriter) Write(p []byte) (int, error) {
+	iw.buf = append(iw.buf, p...)
+	for len(iw.buf) >= idatSize {
+		if err := iw.writeChunk(iw.buf[:idatSize]); err != nil {
+			return 0, err
+		}
+
+		iw.buf = append(iw.buf[:0], iw.buf[idatSize:]...)
+	}
+
+	return len(p), nil
+}
+
+func (iw *idatWriter) flush() error {
+	if len(iw.buf) == 0 {
+		return nil
+	}
+
+	err := iw.writeChunk(iw.buf)
+	iw.buf = iw.buf[:0]
+	return err
+}
+
+func (iw *idatWriter) writeChunk(data []byte) error {
+	var buf bytes.Buffer
+	writeChunk(&buf, "IDAT", data)
+	_, err := iw.w.Write(buf.Bytes())
+	return err
+}
+
+// paeth is the Paeth predictor of the PNG specification
+func paeth(a, b, c byte) byte {
+	p := int(a) + int(b) - int(c)
+	pa, pb, pc := abs(p-int(a)), abs(p-int(b)), abs(p-int(c))
+	switch {
+	case pa <= pb && pa <= pc:
+		return a
+	case pb <= pc:
+		return b
+	default:
+		return c
+	}
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+
+	return x
+}
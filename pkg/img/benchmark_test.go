@@ -0,0 +1,68 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package img_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	. "github.com/homeport/termshot/pkg/img"
+)
+
+// largeContent returns content with the given number of lines, where each
+// line has the given number of characters
+func largeContent(lines, columns int) string {
+	line := strings.Repeat("the quick brown fox jumps over the lazy dog ", columns/44+1)[:columns]
+	return strings.Repeat(line+"\n", lines)
+}
+
+func BenchmarkImageShadow(b *testing.B) {
+	scaffold := NewImageCreator()
+	scaffold.SetColumns(160)
+	if err := scaffold.AddContent(strings.NewReader(largeContent(200, 160))); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for range b.N {
+		if _, err := scaffold.Image(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkImageRedactBlur(b *testing.B) {
+	scaffold := NewImageCreator()
+	scaffold.SetColumns(160)
+	scaffold.DrawShadow(false)
+	scaffold.Redact(regexp.MustCompile(`fox`), RedactBlur)
+	if err := scaffold.AddContent(strings.NewReader(largeContent(200, 160))); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for range b.N {
+		if _, err := scaffold.Image(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
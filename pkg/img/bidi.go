@@ -0,0 +1,199 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package img
+
+import (
+	"slices"
+	"unicode/utf8"
+
+	"github.com/gonvenience/bunt"
+	"golang.org/x/text/unicode/bidi"
+
+	"github.com/homeport/termshot/internal/vt"
+)
+
+// visualOrder returns the content with the characters of lines that contain
+// right-to-left text, e.g. Arabic or Hebrew, rearranged from the order they
+// were written in to the order they are displayed in, so that every line is
+// drawn from left to right like before
+func visualOrder(text bunt.String, table *vt.Table) bunt.String {
+	if !slices.ContainsFunc(text, func(cr bunt.ColoredRune) bool { return isRightToLeft(firstRune(cr.Symbol, table)) }) {
+		return text
+	}
+
+	result := slices.Clone(text)
+	for start := 0; start < len(result); start++ {
+		end := start
+		for end < len(result) && result[end].Symbol != '\n' {
+			end++
+		}
+
+		reorderLine(result[start:end], table)
+		start = end
+	}
+
+	return result
+}
+
+// reorderLine rearranges the characters of one line in place based on the
+// embedding levels of the bidirectional algorithm, the trailing whitespace
+// keeps its place at the end of the line like the empty cells of a terminal
+func reorderLine(line bunt.String, table *vt.Table) {
+	for len(line) > 0 && line[len(line)-1].Symbol == ' ' {
+		line = line[:len(line)-1]
+	}
+
+	// Each cell is represented by the first rune of its grapheme cluster,
+	// which determines the direction of the cluster
+	runes := make([]rune, len(line))
+	for i, cr := range line {
+		runes[i] = firstRune(cr.Symbol, table)
+	}
+
+	if !slices.ContainsFunc(runes, isRightToLeft) {
+		return
+	}
+
+	var paragraph bidi.Paragraph
+	if n, err := paragraph.SetString(string(runes)); err != nil || n != len(string(runes)) {
+		return
+	}
+
+	order, err := paragraph.Order()
+	if err != nil {
+		return
+	}
+
+	levels := embeddingLevels(runes, order)
+	for i, level := range levels {
+		if level%2 == 1 && line[i].Symbol < 0x10000 {
+			line[i].Symbol, _ = utf8.DecodeRuneInString(bidi.ReverseString(string(line[i].Symbol)))
+		}
+	}
+
+	// Reverse every sequence of characters on the same or a higher level,
+	// starting with the highest level, see rule L2 of the algorithm
+	for level := slices.Max(levels); level > 0; level-- {
+		for i := 0; i < len(levels); i++ {
+			if levels[i] < level {
+				continue
+			}
+
+			j := i
+			for j < len(levels) && levels[j] >= level {
+				j++
+			}
+
+			slices.Reverse(line[i:j])
+			slices.Reverse(levels[i:j])
+			i = j
+		}
+	}
+}
+
+// embeddingLevels returns the level of each rune, which is derived from the
+// direction of the runs, since the ordering does not expose the levels: in
+// a right-to-left paragraph left-to-right runs are embedded on level two,
+// in a left-to-right paragraph only numbers that follow right-to-left text
+func embeddingLevels(runes []rune, order bidi.Ordering) []int {
+	base := 0
+	for _, r := range runes {
+		if class := bidiClass(r); class == bidi.L {
+			break
+		} else if class == bidi.R || class == bidi.AL {
+			base = 1
+			break
+		}
+	}
+
+	levels := make([]int, len(runes))
+	for i := 0; i < order.NumRuns(); i++ {
+		run := order.Run(i)
+		start, end := run.Pos()
+
+		if run.Direction() == bidi.RightToLeft {
+			for j := start; j <= end; j++ {
+				levels[j] = 1
+			}
+
+			continue
+		}
+
+		afterRightToLeft := i > 0
+		for j := start; j <= end; j++ {
+			switch {
+			case base == 1:
+				levels[j] = 2
+
+			case bidiClass(runes[j]) == bidi.L:
+				afterRightToLeft = false
+
+			case afterRightToLeft && isNumeric(runes, j):
+				levels[j] = 2
+			}
+		}
+	}
+
+	return levels
+}
+
+// isNumeric reports whether the rune at the position is a digit, or a
+// separator between digits, which are kept in their order in any direction
+func isNumeric(runes []rune, i int) bool {
+	digit := func(i int) bool {
+		if i < 0 || i >= len(runes) {
+			return false
+		}
+
+		class := bidiClass(runes[i])
+		return class == bidi.EN || class == bidi.AN
+	}
+
+	switch bidiClass(runes[i]) {
+	case bidi.EN, bidi.AN:
+		return true
+
+	case bidi.ES, bidi.CS:
+		return digit(i-1) && digit(i+1)
+
+	case bidi.ET:
+		return digit(i-1) || digit(i+1)
+	}
+
+	return false
+}
+
+// isRightToLeft reports whether the rune is a strong right-to-left character
+func isRightToLeft(r rune) bool {
+	class := bidiClass(r)
+	return class == bidi.R || class == bidi.AL
+}
+
+// firstRune returns the first rune of the grapheme cluster of the symbol
+func firstRune(symbol rune, table *vt.Table) rune {
+	r, _ := utf8.DecodeRuneInString(table.Grapheme(symbol))
+	return r
+}
+
+func bidiClass(r rune) bidi.Class {
+	properties, _ := bidi.LookupRune(r)
+	return properties.Class()
+}
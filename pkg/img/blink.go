@@ -0,0 +1,108 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package img
+
+import (
+	"time"
+
+	"github.com/homeport/termshot/internal/vt"
+)
+
+// BlinkStyle defines how blinking text is shown in a static image
+type BlinkStyle int
+
+const (
+	// BlinkBold shows blinking text in bold
+	BlinkBold BlinkStyle = iota
+
+	// BlinkItalic shows blinking text in italic
+	BlinkItalic
+
+	// BlinkNone shows blinking text like any other text
+	BlinkNone
+)
+
+// blinkInterval is the time blinking text is shown and hidden in animations
+const blinkInterval = 500 * time.Millisecond
+
+// SetBlinkStyle sets how blinking text is shown in static images, animations
+// created using [Scaffold.BlinkFrames] show and hide it instead
+func (s *Scaffold) SetBlinkStyle(style BlinkStyle) { s.blinkStyle = style }
+
+// HasBlinkingText returns whether the content contains blinking text
+func (s *Scaffold) HasBlinkingText() bool {
+	for _, cr := range s.content {
+		if vt.Blink(cr.Settings) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// BlinkFrames renders the scaffold content as frames of an animation, where
+// blinking text is alternately shown and hidden, content without blinking
+// text results in a single frame
+func (s *Scaffold) BlinkFrames() ([]Frame, error) {
+	if !s.HasBlinkingText() {
+		image, err := s.Image()
+		if err != nil {
+			return nil, err
+		}
+
+		return []Frame{{Image: image, Delay: blinkInterval}}, nil
+	}
+
+	var frames []Frame
+	for _, hidden := range []bool{false, true} {
+		frame := *s
+		frame.blinkStyle = BlinkNone
+		frame.blinkHidden = hidden
+
+		image, err := frame.Image()
+		if err != nil {
+			return nil, err
+		}
+
+		frames = append(frames, Frame{Image: image, Delay: blinkInterval})
+	}
+
+	return frames, nil
+}
+
+// blinkSettings returns the settings with the attribute of the blink style
+// added in case the text is blinking
+func (s *Scaffold) blinkSettings(settings uint64) uint64 {
+	if !vt.Blink(settings) {
+		return settings
+	}
+
+	switch s.blinkStyle {
+	case BlinkBold:
+		return settings | 0x04
+
+	case BlinkItalic:
+		return settings | 0x08
+
+	default:
+		return settings
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package img
+
+import (
+	"image"
+	"image/draw"
+	"math"
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/esimov/stackblur-go"
+	"github.com/fogleman/gg"
+)
+
+// drawBlurred paints onto a transparent layer, blurs it, and draws it onto
+// the drawing context, where only the painted bounds extended by the blur
+// radius are processed; the covered area is drawn over opaquely afterwards,
+// so that it is skipped and only the strips around it are blurred, and when
+// only an area of the canvas is drawn, only the pixels affecting it are
+// painted, which are provided to the paint function as the visible area
+func drawBlurred(dc *gg.Context, canvas, area, bounds, covered image.Rectangle, radius uint32, paint func(bc *gg.Context, visible image.Rectangle)) error {
+	margin := int(radius) + 1
+	region := bounds.Inset(-margin).Intersect(canvas).Intersect(area.Inset(-margin))
+	if region.Empty() {
+		return nil
+	}
+
+	bc := gg.NewContext(region.Dx(), region.Dy())
+	bc.Translate(-float64(region.Min.X), -float64(region.Min.Y))
+	paint(bc, region)
+	layer := bc.Image().(*image.RGBA)
+
+	covered = covered.Intersect(region)
+	strips := []image.Rectangle{region}
+	if !covered.Empty() {
+		strips = []image.Rectangle{
+			image.Rect(region.Min.X, region.Min.Y, region.Max.X, covered.Min.Y),
+			image.Rect(region.Min.X, covered.Max.Y, region.Max.X, region.Max.Y),
+			image.Rect(region.Min.X, covered.Min.Y, covered.Min.X, covered.Max.Y),
+			image.Rect(covered.Max.X, covered.Min.Y, region.Max.X, covered.Max.Y),
+		}
+	}
+
+	for _, strip := range strips {
+		if strip = strip.Intersect(area); strip.Empty() {
+			continue
+		}
+
+		// The strip is blurred including its surroundings, so that the
+		// result is the same as blurring the whole layer
+		extended := strip.Inset(-margin).Intersect(region).Sub(region.Min)
+		blurred, err := blurBands(layer.SubImage(extended).(*image.RGBA), radius)
+		if err != nil {
+			return err
+		}
+
+		at := region.Min.Add(extended.Min)
+		dc.DrawImage(blurred.SubImage(strip.Sub(at)), at.X, at.Y)
+	}
+
+	return nil
+}
+
+// blurBands blurs the image in horizontal bands in parallel, each band
+// overlaps its neighbours by more than the blur radius, which gives the same
+// result as blurring the image at once, since the blur of a pixel only
+// depends on the pixels within the blur radius
+func blurBands(src *image.RGBA, radius uint32) (*image.NRGBA, error) {
+	bounds := src.Bounds()
+	height := bounds.Dy()
+	overlap := int(radius) + 1
+
+	bands := runtime.GOMAXPROCS(0)
+	if minHeight := 4 * overlap; height/bands < minHeight {
+		bands = max(1, height/minHeight)
+	}
+
+	if bands == 1 {
+		return stackblur.Process(src, radius)
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, bounds.Dx(), height))
+	bandHeight := (height + bands - 1) / bands
+
+	var wg sync.WaitGroup
+	errs := make([]error, bands)
+	for i := range bands {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			y0, y1 := i*bandHeight, min((i+1)*bandHeight, height)
+			top, bottom := max(0, y0-overlap), min(height, y1+overlap)
+
+			band := src.SubImage(image.Rect(bounds.Min.X, bounds.Min.Y+top, bounds.Max.X, bounds.Min.Y+bottom))
+			blurred, err := stackblur.Process(band, radius)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+
+			draw.Draw(dst, image.Rect(0, y0, dst.Bounds().Dx(), y1), blurred, image.Pt(0, y0-top), draw.Src)
+		}()
+	}
+
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return dst, nil
+}
+
+// bounds returns the pixels that are at least partially covered by the area
+func (a Area) bounds() image.Rectangle {
+	return image.Rect(
+		int(math.Floor(a.X)), int(math.Floor(a.Y)),
+		int(math.Ceil(a.X+a.Width)), int(math.Ceil(a.Y+a.Height)),
+	)
+}
+
+// groupBlurredAreas groups the areas, where areas whose blur does not affect
+// each other, i.e. that are farther apart than twice the margin, are in
+// separate groups, so that they can be blurred separately; the areas of each
+// group keep their order
+func groupBlurredAreas(areas []blurredArea, margin int) [][]blurredArea {
+	type group struct {
+		bounds  image.Rectangle
+		indices []int
+	}
+
+	var groups []group
+	for i, area := range areas {
+		merged := group{bounds: area.bounds().Inset(-margin), indices: []int{i}}
+
+		// All groups the area is close to are merged with the area
+		var remaining []group
+		for _, g := range groups {
+			if g.bounds.Overlaps(merged.bounds) {
+				merged.bounds = merged.bounds.Union(g.bounds)
+				merged.indices = append(merged.indices, g.indices...)
+				continue
+			}
+
+			remaining = append(remaining, g)
+		}
+
+		groups = append(remaining, merged)
+	}
+
+	result := make([][]blurredArea, len(groups))
+	for i, g := range groups {
+		sort.Ints(g.indices)
+		for _, index := range g.indices {
+			result[i] = append(result[i], areas[index])
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,81 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package img
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+)
+
+// defaultBorderColor is the color of the window outline
+var defaultBorderColor = color.RGBA{R: 0x40, G: 0x40, B: 0x40, A: 0xFF} // #404040
+
+// BorderStyle is the outline of the window, which optionally has a second
+// line with the inner color directly inside of it for a two-tone border
+type BorderStyle struct {
+	Color      color.Color
+	InnerColor color.Color
+
+	// Width is the width of each line in pixels (before scaling)
+	Width float64
+}
+
+// borderLine is one line of the border drawn along the area
+type borderLine struct {
+	area   Area
+	radius float64
+	color  color.Color
+}
+
+// SetBorderStyle sets the color and width of the window outline, use
+// [Scaffold.DrawBorder] to not draw the outline at all
+func (s *Scaffold) SetBorderStyle(style BorderStyle) error {
+	if style.Width <= 0 || math.IsInf(style.Width, 0) || math.IsNaN(style.Width) {
+		return fmt.Errorf("invalid border width %v, expected a positive number", style.Width)
+	}
+
+	if style.Color == nil {
+		style.Color = defaultBorderColor
+	}
+
+	s.borderColor = style.Color
+	s.borderInnerColor = style.InnerColor
+	s.borderWidth = s.factor * style.Width
+	return nil
+}
+
+// borderLines returns the lines of the border, where the outer line is
+// centered on the edge of the window and the inner line is right inside
+func (s *Scaffold) borderLines(fr frame) []borderLine {
+	lines := []borderLine{{area: fr.window, radius: fr.corner, color: s.borderColor}}
+
+	if s.borderInnerColor != nil {
+		w := s.borderWidth
+		lines = append(lines, borderLine{
+			area:   Area{X: fr.window.X + w, Y: fr.window.Y + w, Width: fr.window.Width - 2*w, Height: fr.window.Height - 2*w},
+			radius: math.Max(fr.corner-w, 0),
+			color:  s.borderInnerColor,
+		})
+	}
+
+	return lines
+}
@@ -0,0 +1,336 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package img
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+
+	"github.com/fogleman/gg"
+)
+
+// boxArms are the lines of the box-drawing characters U+2500 to U+257F from
+// the center of the cell to its top, right, bottom, and left edge, where 1
+// is a light line, 2 a heavy line, and 3 a double line, characters that are
+// not made of straight lines have no entry
+var boxArms = [0x80]string{
+	"0101", "0202", "1010", "2020", "0101", "0202", "1010", "2020", // ─━│┃┄┅┆┇
+	"0101", "0202", "1010", "2020", "0110", "0210", "0120", "0220", // ┈┉┊┋┌┍┎┏
+	"0011", "0012", "0021", "0022", "1100", "1200", "2100", "2200", // ┐┑┒┓└┕┖┗
+	"1001", "1002", "2001", "2002", "1110", "1210", "2110", "1120", // ┘┙┚┛├┝┞┟
+	"2120", "2210", "1220", "2220", "1011", "1012", "2011", "1021", // ┠┡┢┣┤┥┦┧
+	"2021", "2012", "1022", "2022", "0111", "0112", "0211", "0212", // ┨┩┪┫┬┭┮┯
+	"0121", "0122", "0221", "0222", "1101", "1102", "1201", "1202", // ┰┱┲┳┴┵┶┷
+	"2101", "2102", "2201", "2202", "1111", "1112", "1211", "1212", // ┸┹┺┻┼┽┾┿
+	"2111", "1121", "2121", "2112", "2211", "1122", "1221", "2212", // ╀╁╂╃╄╅╆╇
+	"1222", "2122", "2221", "2222", "0101", "0202", "1010", "2020", // ╈╉╊╋╌╍╎╏
+	"0303", "3030", "0310", "0130", "0330", "0013", "0031", "0033", // ═║╒╓╔╕╖╗
+	"1300", "3100", "3300", "1003", "3001", "3003", "1310", "3130", // ╘╙╚╛╜╝╞╟
+	"3330", "1013", "3031", "3033", "0313", "0131", "0333", "1303", // ╠╡╢╣╤╥╦╧
+	"3101", "3303", "1313", "3131", "3333", "", "", "", // ╨╩╪╫╬╭╮╯
+	"", "", "", "", "0001", "1000", "0100", "0010", // ╰╱╲╳╴╵╶╷
+	"0002", "2000", "0200", "0020", "0201", "1020", "0102", "2010", // ╸╹╺╻╼╽╾╿
+}
+
+// boxDashes are the number of dashes of the dashed box-drawing characters
+var boxDashes = map[rune]int{
+	'┄': 3, '┅': 3, '┆': 3, '┇': 3,
+	'┈': 4, '┉': 4, '┊': 4, '┋': 4,
+	'╌': 2, '╍': 2, '╎': 2, '╏': 2,
+}
+
+// blockQuadrants are the quadrants of the block characters U+2596 to U+259F,
+// where the bits are the upper left, upper right, lower left, and lower right
+// quadrant
+var blockQuadrants = [...]uint8{0b0010, 0b0001, 0b1000, 0b1011, 0b1001, 0b1110, 0b1101, 0b0100, 0b0110, 0b0111}
+
+// boxRune returns the box-drawing or block character of the glyph, if it is
+// one of them
+func boxRune(g glyph) (rune, bool) {
+	if g.shaped {
+		return 0, false
+	}
+
+	runes := []rune(g.text)
+	if len(runes) != 1 || runes[0] < 0x2500 || runes[0] > 0x259F {
+		return 0, false
+	}
+
+	return runes[0], true
+}
+
+// drawText draws the glyph in the color, where box-drawing and block
+// characters are drawn as shapes that fill the cell exactly, since their
+// glyphs in the font often leave gaps between consecutive characters, and
+// icons are fitted into their cell
+func (s *Scaffold) drawText(dc *gg.Context, g glyph, c color.Color) {
+	dst, ok := dc.Image().(*image.RGBA)
+	if !ok {
+		drawGlyph(dc, g, c)
+		return
+	}
+
+	cell := s.cellBounds(dc, g)
+	if r, ok := boxRune(g); ok && !cell.Empty() {
+		mask := image.NewAlpha(cell)
+		light := max(1, int(math.Round(float64(cell.Dx())/8)))
+		switch {
+		case r >= 0x2580:
+			drawBlock(mask, r)
+
+		case boxArms[r-0x2500] == "":
+			drawBoxCurve(mask, r, light)
+
+		default:
+			drawBoxLines(mask, r, light)
+		}
+
+		draw.DrawMask(dst, cell, image.NewUniform(c), image.Point{}, mask, cell.Min, draw.Over)
+		return
+	}
+
+	if r, ok := iconRune(g); ok && !cell.Empty() {
+		if mask, area, ok := s.fitIcon(dc, g, r, cell); ok {
+			draw.DrawMask(dst, area, image.NewUniform(c), image.Point{}, mask, image.Point{}, draw.Over)
+			return
+		}
+	}
+
+	drawGlyph(dc, g, c)
+}
+
+// cellBounds returns the pixel bounds of the cell of the glyph on the image,
+// the cell spans the full line height, so that vertical lines connect to the
+// ones in the next line, the drawing context is at most translated by whole
+// pixels
+func (s *Scaffold) cellBounds(dc *gg.Context, g glyph) image.Rectangle {
+	tx, ty := dc.TransformPoint(0, 0)
+	return s.cell(g).bounds().Add(image.Pt(int(math.Round(tx)), int(math.Round(ty))))
+}
+
+// fillMask sets the alpha value of the rectangle, which is relative to the top
+// left corner of the mask
+func fillMask(mask *image.Alpha, x0, y0, x1, y1 int, alpha uint8) {
+	area := image.Rect(x0, y0, x1, y1).Add(mask.Rect.Min).Intersect(mask.Rect)
+	draw.Draw(mask, area, image.NewUniform(color.Alpha{A: alpha}), image.Point{}, draw.Src)
+}
+
+// drawBoxLines draws the straight lines of a box-drawing character, where a
+// double line is drawn as a band of three light lines with the middle one
+// removed afterwards, which also results in the correct corners and
+// junctions with other double lines
+func drawBoxLines(mask *image.Alpha, r rune, light int) {
+	w, h := mask.Rect.Dx(), mask.Rect.Dy()
+	arms := boxArms[r-0x2500]
+
+	thickness := func(arm byte) int { return int(arm-'0') * light }
+	up, right, down, left := thickness(arms[0]), thickness(arms[1]), thickness(arms[2]), thickness(arms[3])
+	vertical, horizontal := max(up, down), max(left, right)
+
+	// Lines of all thicknesses are centered around the light line
+	midX, midY := (w-light)/2, (h-light)/2
+	startX := func(t int) int { return midX - (t-light)/2 }
+	startY := func(t int) int { return midY - (t-light)/2 }
+
+	// Each line reaches to the far side of the lines it crosses
+	span := func(t, other int, start func(int) int) (int, int) {
+		if other == 0 {
+			other = t
+		}
+
+		return start(other), start(other) + other
+	}
+
+	if up > 0 {
+		_, y1 := span(up, horizontal, startY)
+		fillMask(mask, startX(up), 0, startX(up)+up, y1, 0xFF)
+	}
+
+	if down > 0 {
+		y0, _ := span(down, horizontal, startY)
+		fillMask(mask, startX(down), y0, startX(down)+down, h, 0xFF)
+	}
+
+	if left > 0 {
+		_, x1 := span(left, vertical, startX)
+		fillMask(mask, 0, startY(left), x1, startY(left)+left, 0xFF)
+	}
+
+	if right > 0 {
+		x0, _ := span(right, vertical, startX)
+		fillMask(mask, x0, startY(right), w, startY(right)+right, 0xFF)
+	}
+
+	// Remove the middle of double lines, which stops at the near side of
+	// single lines it crosses, but continues into other double lines
+	double := 3 * light
+	single := func(t int) bool { return t != 0 && t != double }
+
+	if up == double {
+		y1 := midY + light
+		if single(horizontal) {
+			y1 = startY(horizontal)
+		}
+
+		fillMask(mask, midX, 0, midX+light, y1, 0)
+	}
+
+	if down == double {
+		y0 := midY
+		if single(horizontal) {
+			y0 = startY(horizontal) + horizontal
+		}
+
+		fillMask(mask, midX, y0, midX+light, h, 0)
+	}
+
+	if left == double {
+		x1 := midX + light
+		if single(vertical) {
+			x1 = startX(vertical)
+		}
+
+		fillMask(mask, 0, midY, x1, midY+light, 0)
+	}
+
+	if right == double {
+		x0 := midX
+		if single(vertical) {
+			x0 = startX(vertical) + vertical
+		}
+
+		fillMask(mask, x0, midY, w, midY+light, 0)
+	}
+
+	// Dashed lines have gaps at the ends of each dash
+	if n, ok := boxDashes[r]; ok {
+		length := w
+		if vertical > 0 {
+			length = h
+		}
+
+		for i := 0; i < n; i++ {
+			a, b := i*length/n, (i+1)*length/n
+			space := max(1, (b-a)/3)
+			for _, span := range [][2]int{{a, a + space/2}, {b - (space - space/2), b}} {
+				if vertical > 0 {
+					fillMask(mask, 0, span[0], w, span[1], 0)
+				} else {
+					fillMask(mask, span[0], 0, span[1], h, 0)
+				}
+			}
+		}
+	}
+}
+
+// drawBoxCurve draws the rounded corners and diagonal lines of the
+// box-drawing characters U+256D to U+2573, which need antialiasing
+func drawBoxCurve(mask *image.Alpha, r rune, light int) {
+	w, h := mask.Rect.Dx(), mask.Rect.Dy()
+	dc := gg.NewContext(w, h)
+	dc.SetColor(color.White)
+	dc.SetLineWidth(float64(light))
+	dc.SetLineCapButt()
+
+	fx := float64((w-light)/2) + float64(light)/2
+	fy := float64((h-light)/2) + float64(light)/2
+	radius := math.Min(math.Min(fx, float64(w)-fx), math.Min(fy, float64(h)-fy))
+
+	// arc draws a rounded corner from the vertical to the horizontal edge
+	arc := func(edgeY, edgeX float64) {
+		dy, dx := math.Copysign(radius, edgeY-fy), math.Copysign(radius, edgeX-fx)
+		dc.MoveTo(fx, edgeY)
+		dc.LineTo(fx, fy+dy)
+		dc.QuadraticTo(fx, fy, fx+dx, fy)
+		dc.LineTo(edgeX, fy)
+		dc.Stroke()
+	}
+
+	switch r {
+	case '╭':
+		arc(float64(h), float64(w))
+
+	case '╮':
+		arc(float64(h), 0)
+
+	case '╯':
+		arc(0, 0)
+
+	case '╰':
+		arc(0, float64(w))
+
+	case '╱':
+		dc.DrawLine(float64(w), 0, 0, float64(h))
+		dc.Stroke()
+
+	case '╲':
+		dc.DrawLine(0, 0, float64(w), float64(h))
+		dc.Stroke()
+
+	case '╳':
+		dc.DrawLine(float64(w), 0, 0, float64(h))
+		dc.DrawLine(0, 0, float64(w), float64(h))
+		dc.Stroke()
+	}
+
+	draw.Draw(mask, mask.Rect, dc.Image(), image.Point{}, draw.Src)
+}
+
+// drawBlock draws the block characters U+2580 to U+259F, which fill parts
+// of the cell in eighths, quadrants, or as shades
+func drawBlock(mask *image.Alpha, r rune) {
+	w, h := mask.Rect.Dx(), mask.Rect.Dy()
+	x := func(eighths int) int { return int(math.Round(float64(w*eighths) / 8)) }
+	y := func(eighths int) int { return int(math.Round(float64(h*eighths) / 8)) }
+
+	switch {
+	case r == '▀':
+		fillMask(mask, 0, 0, w, y(4), 0xFF)
+
+	case r >= '▁' && r <= '█':
+		fillMask(mask, 0, y(8-int(r-'▀')), w, h, 0xFF)
+
+	case r >= '▉' && r <= '▏':
+		fillMask(mask, 0, 0, x(8-int(r-'█')), h, 0xFF)
+
+	case r == '▐':
+		fillMask(mask, x(4), 0, w, h, 0xFF)
+
+	case r >= '░' && r <= '▓':
+		fillMask(mask, 0, 0, w, h, uint8(0x40*(r-'░'+1)))
+
+	case r == '▔':
+		fillMask(mask, 0, 0, w, y(1), 0xFF)
+
+	case r == '▕':
+		fillMask(mask, x(7), 0, w, h, 0xFF)
+
+	default:
+		quadrants := blockQuadrants[r-'▖']
+		for i, area := range [][4]int{{0, 0, x(4), y(4)}, {x(4), 0, w, y(4)}, {0, y(4), x(4), h}, {x(4), y(4), w, h}} {
+			if quadrants&(0b1000>>i) != 0 {
+				fillMask(mask, area[0], area[1], area[2], area[3], 0xFF)
+			}
+		}
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package img
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/fogleman/gg"
+)
+
+// cardMargin is the minimum space around the window on a card relative to
+// the shorter side of the card
+const cardMargin = 0.06
+
+// defaultCardBackground is used for cards if no background is configured
+var defaultCardBackground = LinearGradientBackground(135,
+	color.RGBA{R: 0x1F, G: 0x2B, B: 0x4D, A: 0xFF}, // #1F2B4D
+	color.RGBA{R: 0x5B, G: 0x3A, B: 0x8C, A: 0xFF}, // #5B3A8C
+)
+
+// SetCardSize configures a card of the given size in pixels, e.g. 1200x630
+// for Open Graph images, where the window is placed centered on top of the
+// background, and scaled down in case it does not fit. Cards only apply to
+// raster images, use a size of zero to remove the card again.
+func (s *Scaffold) SetCardSize(width, height int) error {
+	if width < 0 || height < 0 || (width == 0) != (height == 0) {
+		return fmt.Errorf("invalid card size %dx%d, width and height have to be positive", width, height)
+	}
+
+	s.cardWidth, s.cardHeight = width, height
+	return nil
+}
+
+// cardImage renders the window with its shadow on a transparent canvas and
+// places it in the center of the card
+func (s *Scaffold) cardImage(ctx context.Context) (image.Image, error) {
+	transparent := *s
+	transparent.background = nil
+
+	window, err := transparent.image(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	width, height := float64(s.cardWidth), float64(s.cardHeight)
+	dc := gg.NewContext(s.cardWidth, s.cardHeight)
+
+	background := s.background
+	if background == nil {
+		background = defaultCardBackground
+	}
+
+	if err := background(dc, width, height); err != nil {
+		return nil, err
+	}
+
+	// The window is centered, so that the shadow extends to the bottom right,
+	// the window and its shadow are scaled down if they do not fit
+	fr := transparent.frame()
+	visible := transparent.visibleArea(fr)
+	cx, cy := fr.window.X+fr.window.Width/2, fr.window.Y+fr.window.Height/2
+	halfWidth := math.Max(cx-visible.X, visible.X+visible.Width-cx)
+	halfHeight := math.Max(cy-visible.Y, visible.Y+visible.Height-cy)
+
+	margin := cardMargin * math.Min(width, height)
+	scale := math.Min(1, math.Min(
+		(width/2-margin)/halfWidth,
+		(height/2-margin)/halfHeight,
+	))
+
+	dc.Translate(width/2, height/2)
+	dc.Scale(scale, scale)
+	dc.Translate(-cx, -cy)
+	dc.DrawImage(window, 0, 0)
+
+	return dc.Image(), nil
+}
@@ -0,0 +1,108 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package img
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/homeport/termshot/internal/vt"
+)
+
+// cell is one character of the content with all its rendering attributes
+type cell struct {
+	Rune       string   `json:"rune"`
+	Foreground string   `json:"fg"`
+	Background string   `json:"bg,omitempty"`
+	Underline  string   `json:"ul,omitempty"`
+	Attributes []string `json:"attrs,omitempty"`
+}
+
+// WriteCells writes the parsed content as JSON into the provided writer, with
+// one list of cells per line, where each cell contains the character, the
+// foreground and background color used to render it, and its text attributes
+func (s *Scaffold) WriteCells(w io.Writer) error {
+	var lines = [][]cell{{}}
+	for _, cr := range s.content {
+		if cr.Symbol == '\n' {
+			lines = append(lines, []cell{})
+			continue
+		}
+
+		c := cell{
+			Rune:       s.table.Grapheme(cr.Symbol),
+			Foreground: HexColor(s.foregroundColor(cr)),
+		}
+
+		if bg, ok := s.backgroundColor(cr); ok {
+			c.Background = HexColor(bg)
+		}
+
+		for _, attribute := range []struct {
+			mask uint64
+			name string
+		}{
+			{0x04, "bold"},
+			{0x80, "dim"},
+			{0x08, "italic"},
+			{0x10, "underline"},
+			{0x20, "reverse"},
+			{0x40, "strikethrough"},
+		} {
+			if cr.Settings&attribute.mask == 0 {
+				continue
+			}
+
+			// Underlines other than a single line are named by their style
+			if name, ok := underlineNames[s.table.Underline(cr.Settings)]; ok && attribute.mask == 0x10 {
+				c.Attributes = append(c.Attributes, name+"-"+attribute.name)
+				continue
+			}
+
+			c.Attributes = append(c.Attributes, attribute.name)
+		}
+
+		if vt.Blink(cr.Settings) {
+			c.Attributes = append(c.Attributes, "blink")
+		}
+
+		if ul, ok := s.underlineColor(cr); ok && cr.Settings&0x10 != 0 {
+			c.Underline = HexColor(ul)
+		}
+
+		lines[len(lines)-1] = append(lines[len(lines)-1], c)
+	}
+
+	// Like when rendering, a trailing newline does not start a new line
+	if len(lines) > 1 && len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(struct {
+		Background string   `json:"background"`
+		Lines      [][]cell `json:"lines"`
+	}{
+		Background: HexColor(s.defaultBackgroundColor),
+		Lines:      lines,
+	})
+}
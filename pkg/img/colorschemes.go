@@ -0,0 +1,420 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package img
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"math"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ColorschemeFormat is the file format of a color scheme
+type ColorschemeFormat string
+
+const (
+	// ColorschemeTermshot is the JSON format of termshot color schemes
+	ColorschemeTermshot ColorschemeFormat = "termshot"
+
+	// ColorschemeITerm2 is the property list format of iTerm2 color presets
+	ColorschemeITerm2 ColorschemeFormat = "iterm2"
+
+	// ColorschemeAlacritty is the YAML or TOML configuration of Alacritty
+	ColorschemeAlacritty ColorschemeFormat = "alacritty"
+
+	// ColorschemeKitty is the configuration format of kitty themes
+	ColorschemeKitty ColorschemeFormat = "kitty"
+
+	// ColorschemeWindowsTerminal is the JSON format of color schemes of the
+	// Windows Terminal, either a scheme object or a fragment with schemes
+	ColorschemeWindowsTerminal ColorschemeFormat = "windows-terminal"
+
+	// ColorschemeXresources is the X resources format of xterm and urxvt,
+	// like it is used in ~/.Xresources files
+	ColorschemeXresources ColorschemeFormat = "xresources"
+)
+
+// colorschemeParsers convert the color scheme files to the color scheme
+var colorschemeParsers = map[ColorschemeFormat]func(data []byte) (colorscheme, error){
+	ColorschemeTermshot:        parseColorscheme,
+	ColorschemeITerm2:          parseITermColors,
+	ColorschemeAlacritty:       parseAlacrittyColors,
+	ColorschemeKitty:           parseKittyColors,
+	ColorschemeWindowsTerminal: parseWindowsTerminalColors,
+	ColorschemeXresources:      parseXresourcesColors,
+}
+
+// ColorschemeFormats returns the names of all supported color scheme formats
+// in alphabetical order
+func ColorschemeFormats() []string {
+	var names []string
+	for format := range colorschemeParsers {
+		names = append(names, string(format))
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// ansiColorNames are the names of the eight standard colors, which terminals
+// use in their color schemes, where the bright colors are the colors 8 to 15
+var ansiColorNames = []string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
+
+// detectColorschemeFormat returns the format of the color scheme file based
+// on its file extension, or its content if the extension is not conclusive
+func detectColorschemeFormat(filename string, data []byte) ColorschemeFormat {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".itermcolors":
+		return ColorschemeITerm2
+
+	case ".yml", ".yaml", ".toml":
+		return ColorschemeAlacritty
+
+	case ".conf":
+		return ColorschemeKitty
+
+	case ".xresources", ".xdefaults", ".ad":
+		return ColorschemeXresources
+	}
+
+	trimmed := bytes.TrimSpace(data)
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("<?xml")) || bytes.HasPrefix(trimmed, []byte("<plist")):
+		return ColorschemeITerm2
+
+	case bytes.HasPrefix(trimmed, []byte("{")):
+		// Windows Terminal schemes name the colors directly, instead of
+		// using a colors object like termshot color schemes
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(trimmed, &object); err == nil && object["colors"] == nil && (object["schemes"] != nil || object["black"] != nil) {
+			return ColorschemeWindowsTerminal
+		}
+
+	case xresourcesColor.Match(trimmed):
+		return ColorschemeXresources
+	}
+
+	return ColorschemeTermshot
+}
+
+// xresourcesColor matches a color resource of any or all X clients, for
+// example *color0: #282828 or URxvt.foreground: #ebdbb2
+var xresourcesColor = regexp.MustCompile(`(?m)^\s*[\w.*-]*[.*](foreground|background|color\d+)\s*:\s*(.+?)\s*$`)
+
+// schemeColor converts a color of a terminal color scheme, which may use 0x
+// instead of # as prefix, to the notation of the color scheme
+func schemeColor(value string) string {
+	value = strings.Trim(strings.TrimSpace(value), `"'`)
+	if strings.HasPrefix(value, "0x") || strings.HasPrefix(value, "0X") {
+		return "#" + value[2:]
+	}
+
+	return value
+}
+
+// plistValue is an element of a property list, where dictionaries consist of
+// alternating key elements and values
+type plistValue struct {
+	XMLName xml.Name
+	Text    string       `xml:",chardata"`
+	Values  []plistValue `xml:",any"`
+}
+
+// dict returns the entries of a dictionary by their key
+func (v plistValue) dict() map[string]plistValue {
+	entries := map[string]plistValue{}
+	for i := 0; i+1 < len(v.Values); i += 2 {
+		if v.Values[i].XMLName.Local == "key" {
+			entries[v.Values[i].Text] = v.Values[i+1]
+		}
+	}
+
+	return entries
+}
+
+// parseITermColors converts an iTerm2 color preset, a property list with the
+// color components as numbers from zero to one, to a color scheme
+func parseITermColors(data []byte) (colorscheme, error) {
+	var plist plistValue
+	if err := xml.Unmarshal(data, &plist); err != nil {
+		return colorscheme{}, fmt.Errorf("failed to parse iTerm2 colors: %w", err)
+	}
+
+	if len(plist.Values) == 0 || plist.Values[0].XMLName.Local != "dict" {
+		return colorscheme{}, fmt.Errorf("failed to parse iTerm2 colors: no dictionary of colors")
+	}
+
+	keys := map[string]string{
+		"Foreground Color": "foreground",
+		"Background Color": "background",
+	}
+
+	for i := 0; i < 16; i++ {
+		keys[fmt.Sprintf("Ansi %d Color", i)] = fmt.Sprintf("color%d", i)
+	}
+
+	scheme := colorscheme{Colors: map[string]string{}}
+	for key, value := range plist.Values[0].dict() {
+		name, ok := keys[key]
+		if !ok {
+			continue
+		}
+
+		var rgb [3]uint8
+		components := value.dict()
+		for i, component := range []string{"Red Component", "Green Component", "Blue Component"} {
+			number, err := strconv.ParseFloat(strings.TrimSpace(components[component].Text), 64)
+			if err != nil {
+				return colorscheme{}, fmt.Errorf("invalid %s of %s: %w", strings.ToLower(component), key, err)
+			}
+
+			rgb[i] = uint8(math.Round(math.Max(0, math.Min(1, number)) * 255))
+		}
+
+		scheme.Colors[name] = fmt.Sprintf("#%02x%02x%02x", rgb[0], rgb[1], rgb[2])
+	}
+
+	return scheme, nil
+}
+
+// parseAlacrittyColors converts the colors of an Alacritty configuration in
+// YAML or TOML format to a color scheme
+func parseAlacrittyColors(data []byte) (colorscheme, error) {
+	colors := map[string]string{}
+	if bytes.Contains(data, []byte("[colors")) {
+		colors = parseTOMLStrings(data)
+
+	} else {
+		var config struct {
+			Colors map[string]map[string]string `yaml:"colors"`
+		}
+
+		if err := yaml.Unmarshal(data, &config); err != nil {
+			return colorscheme{}, fmt.Errorf("failed to parse Alacritty colors: %w", err)
+		}
+
+		for table, entries := range config.Colors {
+			for key, value := range entries {
+				colors["colors."+table+"."+key] = value
+			}
+		}
+	}
+
+	scheme := colorscheme{Colors: map[string]string{}}
+	for _, name := range []string{"foreground", "background"} {
+		if value, ok := colors["colors.primary."+name]; ok {
+			scheme.Colors[name] = schemeColor(value)
+		}
+	}
+
+	for i, name := range ansiColorNames {
+		if value, ok := colors["colors.normal."+name]; ok {
+			scheme.Colors[fmt.Sprintf("color%d", i)] = schemeColor(value)
+		}
+
+		if value, ok := colors["colors.bright."+name]; ok {
+			scheme.Colors[fmt.Sprintf("color%d", i+8)] = schemeColor(value)
+		}
+	}
+
+	if len(scheme.Colors) == 0 {
+		return colorscheme{}, fmt.Errorf("failed to parse Alacritty colors: no colors found")
+	}
+
+	return scheme, nil
+}
+
+// parseTOMLStrings returns the string values of a TOML document by their
+// full key, which covers the tables and key value pairs that color schemes
+// use, but not the complete TOML syntax
+func parseTOMLStrings(data []byte) map[string]string {
+	values := map[string]string{}
+
+	var table string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+			continue
+
+		case strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]"):
+			table = strings.TrimSpace(strings.Trim(line, "[]"))
+			continue
+		}
+
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+
+		// Comments after a value are only possible after the closing quote
+		value = strings.TrimSpace(value)
+		if end := strings.LastIndexAny(value, `"'`); end > 0 {
+			value = value[:end+1]
+		}
+
+		key = strings.ReplaceAll(strings.TrimSpace(key), " ", "")
+		if table != "" {
+			key = table + "." + key
+		}
+
+		values[key] = strings.Trim(value, `"'`)
+	}
+
+	return values
+}
+
+// parseKittyColors converts the colors of a kitty theme, which has one
+// setting with its value per line, to a color scheme
+func parseKittyColors(data []byte) (colorscheme, error) {
+	scheme := colorscheme{Colors: map[string]string{}}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		name := fields[0]
+		if index, err := strconv.Atoi(strings.TrimPrefix(name, "color")); name != "foreground" && name != "background" && (err != nil || !strings.HasPrefix(name, "color") || index > 255) {
+			continue
+		}
+
+		scheme.Colors[name] = schemeColor(fields[1])
+	}
+
+	if len(scheme.Colors) == 0 {
+		return colorscheme{}, fmt.Errorf("failed to parse kitty colors: no colors found")
+	}
+
+	return scheme, nil
+}
+
+// parseWindowsTerminalColors converts a color scheme of the Windows Terminal,
+// or the first one of a settings fragment, to a color scheme
+func parseWindowsTerminalColors(data []byte) (colorscheme, error) {
+	var fragment struct {
+		Schemes []map[string]any `json:"schemes"`
+	}
+
+	var colors map[string]any
+	if err := json.Unmarshal(data, &fragment); err == nil && len(fragment.Schemes) > 0 {
+		colors = fragment.Schemes[0]
+
+	} else if err := json.Unmarshal(data, &colors); err != nil {
+		return colorscheme{}, fmt.Errorf("failed to parse Windows Terminal colors: %w", err)
+	}
+
+	names := map[string]string{
+		"foreground": "foreground",
+		"background": "background",
+	}
+
+	for i, name := range ansiColorNames {
+		if name == "magenta" {
+			name = "purple"
+		}
+
+		names[name] = fmt.Sprintf("color%d", i)
+		names["bright"+strings.ToUpper(name[:1])+name[1:]] = fmt.Sprintf("color%d", i+8)
+	}
+
+	scheme := colorscheme{Colors: map[string]string{}}
+	for key, value := range colors {
+		if text, ok := value.(string); ok && names[key] != "" {
+			scheme.Colors[names[key]] = schemeColor(text)
+		}
+	}
+
+	if len(scheme.Colors) == 0 {
+		return colorscheme{}, fmt.Errorf("failed to parse Windows Terminal colors: no colors found")
+	}
+
+	return scheme, nil
+}
+
+// parseXresourcesColors converts the color resources of an X resources file
+// to a color scheme, the values can be macros defined in the same file
+func parseXresourcesColors(data []byte) (colorscheme, error) {
+	defines := map[string]string{}
+	scheme := colorscheme{Colors: map[string]string{}}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if fields := strings.Fields(line); len(fields) >= 3 && fields[0] == "#define" {
+			defines[fields[1]] = fields[2]
+			continue
+		}
+
+		match := xresourcesColor.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		value := match[2]
+		if define, ok := defines[value]; ok {
+			value = define
+		}
+
+		scheme.Colors[match[1]] = xresourcesValue(value)
+	}
+
+	if len(scheme.Colors) == 0 {
+		return colorscheme{}, fmt.Errorf("failed to parse Xresources colors: no colors found")
+	}
+
+	return scheme, nil
+}
+
+// xresourcesValue converts the X11 color notation rgb:rr/gg/bb, which can
+// also use one to four hex digits per component, to the #rrggbb notation
+func xresourcesValue(value string) string {
+	components := strings.Split(strings.TrimPrefix(value, "rgb:"), "/")
+	if !strings.HasPrefix(value, "rgb:") || len(components) != 3 {
+		return value
+	}
+
+	result := "#"
+	for _, component := range components {
+		number, err := strconv.ParseUint(component, 16, 16)
+		if err != nil || len(component) == 0 || len(component) > 4 {
+			return value
+		}
+
+		// Scale the component to eight bit based on the number of digits
+		max := uint64(1)<<(4*len(component)) - 1
+		result += fmt.Sprintf("%02x", (number*255+max/2)/max)
+	}
+
+	return result
+}
@@ -0,0 +1,138 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package img
+
+import (
+	"image/color"
+
+	"github.com/fogleman/gg"
+	imgfont "golang.org/x/image/font"
+)
+
+// CursorStyle defines how the cursor of the terminal is drawn
+type CursorStyle int
+
+const (
+	// CursorNone does not draw a cursor
+	CursorNone CursorStyle = iota
+
+	// CursorBlock draws the cursor as a block on top of the character
+	CursorBlock
+
+	// CursorBar draws the cursor as a vertical bar left of the character
+	CursorBar
+
+	// CursorUnderline draws the cursor as a line under the character
+	CursorUnderline
+)
+
+// SetCursor configures the style of the cursor, which is drawn where the
+// cursor is after the content was added, or after the last character in
+// case that position is not part of the screenshot
+func (s *Scaffold) SetCursor(style CursorStyle) { s.cursorStyle = style }
+
+// cursorPlacement returns the area of the cursor in the window and the glyph
+// of the character under the cursor, if there is one
+func (s *Scaffold) cursorPlacement(fr frame, glyphs []glyph) (Area, glyph, bool) {
+	content, lines, columns := s.visibleContentOffset()
+
+	metrics := s.regular.Metrics()
+	height := float64(metrics.Height) / 64
+	width := float64(imgfont.MeasureString(s.regular, " ") >> 6)
+
+	// place returns the cursor in the line and column, where a negative
+	// column is the position after the last character of the line
+	place := func(line, column int) (Area, glyph, bool) {
+		x := fr.content.X + fr.gutter
+		y := fr.content.Y + s.fontHeight() + float64(line)*height*s.lineSpacing
+
+		var current, n int
+		for _, g := range glyphs {
+			if g.cr.Symbol == '\n' {
+				current++
+				continue
+			}
+
+			if current != line {
+				continue
+			}
+
+			if n == column {
+				return Area{X: g.x, Y: g.y - height + 12, Width: g.width, Height: height}, g, g.text != ""
+			}
+
+			x = g.x + g.width
+			n++
+		}
+
+		x += float64(max(0, column-n)) * width
+		return Area{X: x, Y: y - height + 12, Width: width, Height: height}, glyph{}, false
+	}
+
+	last := max(0, len(splitLines(content))-1)
+	line, column := s.cursorLine-lines, s.cursorColumn-columns
+	if line < 0 || line > last || column < 0 {
+		return place(last, -1)
+	}
+
+	// A cursor outside of the window is shown after the last character, too
+	area, under, found := place(line, column)
+	if area.X+area.Width > fr.content.X+fr.content.Width {
+		return place(last, -1)
+	}
+
+	return area, under, found
+}
+
+// drawCursor draws the cursor in its style on top of the content
+func (s *Scaffold) drawCursor(dc *gg.Context, fr frame, glyphs []glyph) {
+	f := func(value float64) float64 { return s.factor * value }
+
+	area, under, found := s.cursorPlacement(fr, glyphs)
+	dc.SetColor(s.defaultForegroundColor)
+
+	switch s.cursorStyle {
+	case CursorBlock:
+		dc.DrawRectangle(area.X, area.Y, area.Width, area.Height)
+		dc.Fill()
+
+		// The character under the cursor is drawn in the background color,
+		// on its own like terminals do, even if it is part of a ligature
+		if found {
+			under.shaped = false
+
+			var bg color.Color = s.defaultBackgroundColor
+			if c, ok := s.backgroundColor(under.cr); ok {
+				bg = c
+			}
+
+			s.drawText(dc, under, bg)
+		}
+
+	case CursorBar:
+		dc.DrawRectangle(area.X, area.Y, f(2), area.Height)
+		dc.Fill()
+
+	case CursorUnderline:
+		dc.DrawRectangle(area.X, area.Y+area.Height-f(2), area.Width, f(2))
+		dc.Fill()
+	}
+}
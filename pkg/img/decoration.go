@@ -0,0 +1,96 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package img
+
+import (
+	"unicode/utf8"
+
+	"github.com/fogleman/gg"
+	imgfont "golang.org/x/image/font"
+)
+
+// Area is a rectangular area of the screenshot in pixels
+type Area struct {
+	X, Y          float64
+	Width, Height float64
+}
+
+// Layout describes where the elements of the screenshot are located, all
+// values are in pixels of the rendered image
+type Layout struct {
+	Width, Height float64
+
+	Window  Area
+	Content Area
+
+	CellWidth, CellHeight float64
+	Columns, Rows         int
+
+	Scale float64
+}
+
+// Decoration draws custom elements on top of the rendered window
+type Decoration func(dc *gg.Context, layout Layout) error
+
+// AddDecoration adds a decoration, which is drawn after the window and its
+// content, decorations are drawn in the order they were added
+func (s *Scaffold) AddDecoration(decoration Decoration) {
+	s.decorations = append(s.decorations, decoration)
+}
+
+// drawDecorationsOn draws all decorations onto the drawing context
+func (s *Scaffold) drawDecorationsOn(dc *gg.Context, fr frame) error {
+	layout := s.layout(fr)
+
+	dc.SetFontFace(fr.regular)
+	for _, decoration := range s.decorations {
+		if err := decoration(dc, layout); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Scaffold) layout(fr frame) Layout {
+	lines := s.Lines()
+
+	columns := s.columns
+	if columns == 0 || s.wrap == WrapNone {
+		for _, line := range lines {
+			if n := utf8.RuneCountInString(line); n > columns {
+				columns = n
+			}
+		}
+	}
+
+	return Layout{
+		Width:      fr.width,
+		Height:     fr.height,
+		Window:     fr.window,
+		Content:    fr.content,
+		CellWidth:  float64(imgfont.MeasureString(s.regular, "a")) / 64,
+		CellHeight: s.fontHeight() * s.lineSpacing,
+		Columns:    columns,
+		Rows:       len(lines),
+		Scale:      s.factor,
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+/*
+Package img renders terminal output with ANSI escape sequences into an image
+that looks like a terminal window, the same way the termshot command does.
+
+A [Scaffold] holds the content and all settings of the look. Create one with
+[New] and the options to adjust the look, for example [WithColumns],
+[WithTheme], or [WithShadow], add the content with [Scaffold.AddContent],
+and render it using [Scaffold.Image] or one of the encoders, for example
+[Scaffold.WritePNG], [Scaffold.WriteSVG], [Scaffold.WritePDF], or
+[Scaffold.WriteHTML]:
+
+	scaffold, err := img.New(
+		img.WithColumns(80),
+		img.WithTheme("nord"),
+		img.WithShadow(false),
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := scaffold.AddContent(strings.NewReader("\x1b[1mfoobar\x1b[0m")); err != nil {
+		return err
+	}
+
+	return scaffold.WritePNG(w)
+
+Alternatively, [NewImageCreator] creates a scaffold with the default look,
+which can be adjusted with the setters of the scaffold.
+
+Multiple rendered images can be combined using [Grid], or written as an
+animation using [WriteGIF].
+*/
+package img
@@ -0,0 +1,154 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package img
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"io"
+	"sort"
+
+	"github.com/HugoSmits86/nativewebp"
+)
+
+// WebPOptions are the settings of the WebP encoding
+type WebPOptions struct {
+	// Quality from 1 to 100, where 100 (or zero) keeps all colors as-is. The
+	// image is always compressed lossless, therefore any lower quality only
+	// reduces the colors to a palette of the most used colors, which results
+	// in slightly smaller files.
+	Quality int
+}
+
+// WriteJPEG writes the scaffold content as JPEG with the given quality from
+// 1 to 100 into the provided writer. Since JPEG does not support
+// transparency, the image is placed on a white background.
+func (s *Scaffold) WriteJPEG(w io.Writer, quality int) error {
+	img, err := s.Image()
+	if err != nil {
+		return err
+	}
+
+	opaque := image.NewRGBA(img.Bounds())
+	draw.Draw(opaque, opaque.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.Draw(opaque, opaque.Bounds(), img, img.Bounds().Min, draw.Over)
+
+	return jpeg.Encode(w, opaque, &jpeg.Options{Quality: min(max(quality, 1), 100)})
+}
+
+// WriteWebP writes the scaffold content as lossless WebP into the provided
+// writer
+func (s *Scaffold) WriteWebP(w io.Writer, opts WebPOptions) error {
+	img, err := s.Image()
+	if err != nil {
+		return err
+	}
+
+	if opts.Quality > 0 && opts.Quality < 100 {
+		img = paletted(img)
+	}
+
+	return nativewebp.Encode(w, img, nil)
+}
+
+// paletted converts the image into a paletted image using the 256 most used
+// colors, where similar colors are combined in case there are more colors
+func paletted(img image.Image) *image.Paletted {
+	bounds := img.Bounds()
+	counts := map[color.NRGBA]int{}
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			counts[color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)]++
+		}
+	}
+
+	type bucket struct{ r, g, b, a, count int }
+	buckets := map[[4]uint8]*bucket{}
+	for c, count := range counts {
+		key := [4]uint8{c.R, c.G, c.B, c.A}
+		if len(counts) > 4096 {
+			key = [4]uint8{c.R >> 2, c.G >> 2, c.B >> 2, c.A >> 2}
+		}
+
+		if _, ok := buckets[key]; !ok {
+			buckets[key] = &bucket{}
+		}
+
+		buckets[key].r += int(c.R) * count
+		buckets[key].g += int(c.G) * count
+		buckets[key].b += int(c.B) * count
+		buckets[key].a += int(c.A) * count
+		buckets[key].count += count
+	}
+
+	type entry struct {
+		color color.NRGBA
+		count int
+	}
+
+	entries := make([]entry, 0, len(buckets))
+	for _, b := range buckets {
+		entries = append(entries, entry{
+			color: color.NRGBA{
+				R: uint8(b.r / b.count), // #nosec G115 -- average of uint8 values
+				G: uint8(b.g / b.count), // #nosec G115 -- average of uint8 values
+				B: uint8(b.b / b.count), // #nosec G115 -- average of uint8 values
+				A: uint8(b.a / b.count), // #nosec G115 -- average of uint8 values
+			},
+			count: b.count,
+		})
+	}
+
+	// Sort the palette, so that the same image always results in the same file
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.count != b.count {
+			return a.count > b.count
+		}
+
+		return uint32(a.color.R)<<24|uint32(a.color.G)<<16|uint32(a.color.B)<<8|uint32(a.color.A) <
+			uint32(b.color.R)<<24|uint32(b.color.G)<<16|uint32(b.color.B)<<8|uint32(b.color.A)
+	})
+
+	palette := make(color.Palette, 0, 256)
+	for _, e := range entries[:min(len(entries), 256)] {
+		palette = append(palette, e.color)
+	}
+
+	result := image.NewPaletted(bounds, palette)
+	lookup := map[color.NRGBA]uint8{}
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			index, ok := lookup[c]
+			if !ok {
+				index = uint8(palette.Index(c)) // #nosec G115 -- palette has at most 256 colors
+				lookup[c] = index
+			}
+
+			result.SetColorIndex(x, y, index)
+		}
+	}
+
+	return result
+}
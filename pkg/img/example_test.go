@@ -0,0 +1,68 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package img_test
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/homeport/termshot/pkg/img"
+)
+
+func ExampleNew() {
+	scaffold, err := img.New(img.WithColumns(8), img.WithShadow(false))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	if err := scaffold.AddContent(strings.NewReader("foobar baz qux")); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	if err := scaffold.WriteText(os.Stdout); err != nil {
+		fmt.Println(err)
+	}
+
+	// Output:
+	// foobar b
+	// az qux
+}
+
+func ExampleScaffold() {
+	scaffold := img.NewImageCreator()
+	scaffold.SetColumns(8)
+
+	if err := scaffold.AddContent(strings.NewReader("\x1b[1;32mfoobar\x1b[0m baz qux")); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	if err := scaffold.WriteText(os.Stdout); err != nil {
+		fmt.Println(err)
+	}
+
+	// Output:
+	// foobar b
+	// az qux
+}
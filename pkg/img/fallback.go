@@ -0,0 +1,151 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package img
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/draw"
+	"os"
+
+	imgfont "golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// missingRune is a noncharacter that no font has a glyph for, so that it is
+// rendered using the glyph for missing characters (.notdef) of a font
+const missingRune = '\U0010FFFF'
+
+// LoadFallbackFonts loads font files (TTF/OTF) that are used in the provided
+// order for characters the regular fonts have no glyph for, for example
+// emoji or icons, instead of rendering the glyph for missing characters
+func (s *Scaffold) LoadFallbackFonts(fontPaths ...string) error {
+	loaders := make([]faceLoader, 0, len(fontPaths))
+	for _, fontPath := range fontPaths {
+		fontBytes, err := os.ReadFile(fontPath)
+		if err != nil {
+			return fmt.Errorf("failed to read font file %s: %w", fontPath, err)
+		}
+
+		loader, err := fileLoader(fontPath, fontBytes, nil, false)
+		if err != nil {
+			return err
+		}
+
+		loaders = append(loaders, loader)
+	}
+
+	s.fallbackLoaders = loaders
+	return s.loadFaces()
+}
+
+// fallbackFace renders each glyph using the first face that has a glyph for
+// it, starting with the primary face, where the metrics of the primary face
+// are used for the layout
+type fallbackFace struct {
+	imgfont.Face
+	fallbacks []imgfont.Face
+
+	selected map[rune]imgfont.Face
+	notdef   map[imgfont.Face][]byte
+}
+
+func newFallbackFace(primary imgfont.Face, fallbacks []imgfont.Face) *fallbackFace {
+	return &fallbackFace{
+		Face:      primary,
+		fallbacks: fallbacks,
+		selected:  map[rune]imgfont.Face{},
+		notdef:    map[imgfont.Face][]byte{},
+	}
+}
+
+func (f *fallbackFace) Glyph(dot fixed.Point26_6, r rune) (dr image.Rectangle, mask image.Image, maskp image.Point, advance fixed.Int26_6, ok bool) {
+	return f.face(r).Glyph(dot, r)
+}
+
+func (f *fallbackFace) GlyphBounds(r rune) (bounds fixed.Rectangle26_6, advance fixed.Int26_6, ok bool) {
+	return f.face(r).GlyphBounds(r)
+}
+
+func (f *fallbackFace) GlyphAdvance(r rune) (advance fixed.Int26_6, ok bool) {
+	return f.face(r).GlyphAdvance(r)
+}
+
+// face returns the face to render the rune with
+func (f *fallbackFace) face(r rune) imgfont.Face {
+	if face, ok := f.selected[r]; ok {
+		return face
+	}
+
+	// Ligatures are shaped by the primary face and only exist in it
+	face := f.Face
+	if !isLigatureRune(r) && !f.hasGlyph(f.Face, r) {
+		for _, fallback := range f.fallbacks {
+			if f.hasGlyph(fallback, r) {
+				face = fallback
+				break
+			}
+		}
+	}
+
+	f.selected[r] = face
+	return face
+}
+
+// hasGlyph returns whether the face has a glyph for the rune, which is the
+// case if the rune is not rendered the same way as a missing character
+func (f *fallbackFace) hasGlyph(face imgfont.Face, r rune) bool {
+	if r < 0x80 {
+		return true
+	}
+
+	sig, ok := glyphSignature(face, r)
+	if !ok {
+		return false
+	}
+
+	notdef, found := f.notdef[face]
+	if !found {
+		notdef, _ = glyphSignature(face, missingRune)
+		f.notdef[face] = notdef
+	}
+
+	return !bytes.Equal(sig, notdef)
+}
+
+// glyphSignature returns the bounds and mask of the glyph in a form that can
+// be compared with the one of other glyphs
+func glyphSignature(face imgfont.Face, r rune) ([]byte, bool) {
+	dr, mask, maskp, advance, ok := face.Glyph(fixed.Point26_6{}, r)
+	if !ok {
+		return nil, false
+	}
+
+	sig := fmt.Appendf(nil, "%v %v ", dr, advance)
+	if mask != nil {
+		alpha := image.NewAlpha(image.Rect(0, 0, dr.Dx(), dr.Dy()))
+		draw.Draw(alpha, alpha.Bounds(), mask, maskp, draw.Src)
+		sig = append(sig, alpha.Pix...)
+	}
+
+	return sig, true
+}
@@ -0,0 +1,235 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package img
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/draw"
+	"strconv"
+	"strings"
+
+	"github.com/go-text/typesetting/di"
+	gotext "github.com/go-text/typesetting/font"
+	ot "github.com/go-text/typesetting/font/opentype"
+	"github.com/go-text/typesetting/language"
+	"github.com/go-text/typesetting/shaping"
+	imgfont "golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+	"golang.org/x/image/vector"
+)
+
+// parseFontFeatures parses OpenType feature settings, which are either a
+// feature tag like "ss01" to enable it, a tag prefixed with "-" to disable
+// it, or a tag with an explicit value like "cv01=2"
+func parseFontFeatures(settings []string) ([]shaping.FontFeature, error) {
+	var features []shaping.FontFeature
+	for _, setting := range settings {
+		tag, value := strings.TrimPrefix(setting, "+"), uint32(1)
+
+		switch {
+		case strings.HasPrefix(tag, "-"):
+			tag, value = tag[1:], 0
+
+		case strings.Contains(tag, "="):
+			var raw string
+			tag, raw, _ = strings.Cut(tag, "=")
+
+			parsed, err := strconv.ParseUint(raw, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value of font feature %q: %w", setting, err)
+			}
+
+			value = uint32(parsed)
+		}
+
+		if len(tag) != 4 {
+			return nil, fmt.Errorf("invalid font feature %q, expected a four character tag like ss01", setting)
+		}
+
+		features = append(features, shaping.FontFeature{Tag: ot.MustNewTag(tag), Value: value})
+	}
+
+	return features, nil
+}
+
+// featureFace is a font face that renders the glyphs selected by the
+// configured OpenType features instead of the default glyph of each rune
+//
+// Like all font faces, it is not safe to use concurrently.
+type featureFace struct {
+	imgfont.Face
+
+	font     *sfnt.Font
+	scale    fixed.Int26_6
+	hinting  imgfont.Hinting
+	shaper   shaping.HarfbuzzShaper
+	shaped   *gotext.Face
+	features []shaping.FontFeature
+	glyphs   map[rune]sfnt.GlyphIndex
+
+	buf  sfnt.Buffer
+	rast vector.Rasterizer
+	mask image.Alpha
+}
+
+// newFeatureLoader parses the font data once and creates a loader for font
+// faces with the given features enabled
+func newFeatureLoader(data []byte, features []shaping.FontFeature) (faceLoader, error) {
+	parsed, err := opentype.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	shaped, err := gotext.ParseTTF(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	return func(size float64, hinting imgfont.Hinting) (imgfont.Face, error) {
+		face, err := opentype.NewFace(parsed, &opentype.FaceOptions{Size: size, DPI: defaultFontDPI, Hinting: hinting})
+		if err != nil {
+			return nil, err
+		}
+
+		return &featureFace{
+			Face:     face,
+			font:     parsed,
+			scale:    fixed.Int26_6(0.5 + (size * defaultFontDPI * 64 / 72)),
+			hinting:  hinting,
+			shaped:   shaped,
+			features: features,
+			glyphs:   map[rune]sfnt.GlyphIndex{},
+		}, nil
+	}, nil
+}
+
+// glyphIndex returns the glyph of the rune after applying the features
+func (f *featureFace) glyphIndex(r rune) sfnt.GlyphIndex {
+	if isLigatureRune(r) {
+		return sfnt.GlyphIndex(r - ligatureBase)
+	}
+
+	if glyph, ok := f.glyphs[r]; ok {
+		return glyph
+	}
+
+	glyph, _ := f.font.GlyphIndex(&f.buf, r)
+
+	output := f.shaper.Shape(shaping.Input{
+		Text:         []rune{r},
+		RunEnd:       1,
+		Direction:    di.DirectionLTR,
+		Face:         f.shaped,
+		FontFeatures: f.features,
+		Size:         f.scale,
+		Script:       language.LookupScript(r),
+		Language:     language.DefaultLanguage(),
+	})
+
+	// Only single glyph substitutions are supported, since all content
+	// is rendered character by character
+	if len(output.Glyphs) == 1 && output.Glyphs[0].GlyphID != 0 {
+		glyph = sfnt.GlyphIndex(output.Glyphs[0].GlyphID)
+	}
+
+	f.glyphs[r] = glyph
+	return glyph
+}
+
+func (f *featureFace) GlyphBounds(r rune) (bounds fixed.Rectangle26_6, advance fixed.Int26_6, ok bool) {
+	glyph := f.glyphIndex(r)
+	bounds, advance, err := f.font.GlyphBounds(&f.buf, glyph, f.scale, f.hinting)
+	return bounds, advance, err == nil && glyph != 0
+}
+
+func (f *featureFace) GlyphAdvance(r rune) (advance fixed.Int26_6, ok bool) {
+	glyph := f.glyphIndex(r)
+	advance, err := f.font.GlyphAdvance(&f.buf, glyph, f.scale, f.hinting)
+	return advance, err == nil && glyph != 0
+}
+
+func (f *featureFace) Glyph(dot fixed.Point26_6, r rune) (dr image.Rectangle, mask image.Image, maskp image.Point, advance fixed.Int26_6, ok bool) {
+	glyph := f.glyphIndex(r)
+
+	advance, err := f.font.GlyphAdvance(&f.buf, glyph, f.scale, f.hinting)
+	if err != nil {
+		return image.Rectangle{}, nil, image.Point{}, 0, false
+	}
+
+	segments, err := f.font.LoadGlyph(&f.buf, glyph, f.scale, nil)
+	if err != nil {
+		return image.Rectangle{}, nil, image.Point{}, 0, false
+	}
+
+	// Rasterize the glyph outline the same way the opentype package does,
+	// with the glyph origin placed at the sub-pixel position of the dot
+	bounds := segments.Bounds().Add(dot)
+	dr = image.Rect(bounds.Min.X.Floor(), bounds.Min.Y.Floor(), bounds.Max.X.Ceil(), bounds.Max.Y.Ceil())
+	width, height := dr.Dx(), dr.Dy()
+	if width < 0 || height < 0 {
+		return image.Rectangle{}, nil, image.Point{}, 0, false
+	}
+
+	biasX := dot.X - fixed.Int26_6(dr.Min.X<<6)
+	biasY := dot.Y - fixed.Int26_6(dr.Min.Y<<6)
+	point := func(p fixed.Point26_6) (float32, float32) {
+		return float32(p.X+biasX) / 64, float32(p.Y+biasY) / 64
+	}
+
+	if n := width * height; cap(f.mask.Pix) < n {
+		f.mask.Pix = make([]uint8, 2*n)
+	}
+
+	f.mask.Pix = f.mask.Pix[:width*height]
+	f.mask.Stride = width
+	f.mask.Rect = image.Rect(0, 0, width, height)
+
+	f.rast.Reset(width, height)
+	f.rast.DrawOp = draw.Src
+	for _, segment := range segments {
+		switch segment.Op {
+		case sfnt.SegmentOpMoveTo:
+			f.rast.MoveTo(point(segment.Args[0]))
+
+		case sfnt.SegmentOpLineTo:
+			f.rast.LineTo(point(segment.Args[0]))
+
+		case sfnt.SegmentOpQuadTo:
+			x1, y1 := point(segment.Args[0])
+			x2, y2 := point(segment.Args[1])
+			f.rast.QuadTo(x1, y1, x2, y2)
+
+		case sfnt.SegmentOpCubeTo:
+			x1, y1 := point(segment.Args[0])
+			x2, y2 := point(segment.Args[1])
+			x3, y3 := point(segment.Args[2])
+			f.rast.CubeTo(x1, y1, x2, y2, x3, y3)
+		}
+	}
+
+	f.rast.Draw(&f.mask, f.mask.Bounds(), image.Opaque, image.Point{})
+
+	return dr, &f.mask, f.mask.Rect.Min, advance, glyph != 0
+}
@@ -0,0 +1,60 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package img
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+)
+
+// Filmstrip composes the images into a single image, where the images are
+// placed in a grid with the given number of columns from left to right and
+// top to bottom, one column results in a vertical strip. Each cell of the
+// grid has the size of the largest image, smaller images are placed at the
+// top left of their cell, and the remaining area is transparent.
+func Filmstrip(images []image.Image, columns int) (image.Image, error) {
+	if len(images) == 0 {
+		return nil, fmt.Errorf("failed to create filmstrip: no images")
+	}
+
+	if columns <= 0 {
+		return nil, fmt.Errorf("invalid number of columns %d, expected a positive number", columns)
+	}
+
+	var cell image.Point
+	for _, img := range images {
+		cell.X = max(cell.X, img.Bounds().Dx())
+		cell.Y = max(cell.Y, img.Bounds().Dy())
+	}
+
+	columns = min(columns, len(images))
+	rows := (len(images) + columns - 1) / columns
+
+	strip := image.NewRGBA(image.Rect(0, 0, columns*cell.X, rows*cell.Y))
+	for i, img := range images {
+		offset := image.Pt(i%columns*cell.X, i/columns*cell.Y)
+		bounds := img.Bounds()
+		draw.Draw(strip, bounds.Sub(bounds.Min).Add(offset), img, bounds.Min, draw.Src)
+	}
+
+	return strip, nil
+}
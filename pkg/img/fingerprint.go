@@ -0,0 +1,110 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package img
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"sort"
+)
+
+// ErrNoFingerprint is returned for scaffolds with custom decorations, which
+// can draw anything, so that their rendering cannot be described
+var ErrNoFingerprint = errors.New("custom decorations cannot be described by a fingerprint")
+
+// Fingerprint describes the content and all settings of the scaffold, so that
+// scaffolds with the same fingerprint render the same image. Fonts and
+// backgrounds are code, which cannot be described, they have to be part of
+// the description of the caller, e.g. using the flags they were created from.
+func (s *Scaffold) Fingerprint() ([]byte, error) {
+	if len(s.decorations) > 0 {
+		return nil, ErrNoFingerprint
+	}
+
+	var buf bytes.Buffer
+	for _, cr := range s.content {
+		underline, _ := s.table.UnderlineColor(cr.Settings)
+		fmt.Fprintf(&buf, "%q %x %x %d\n", s.table.Grapheme(cr.Symbol), cr.Settings, underline, s.table.Underline(cr.Settings))
+	}
+
+	var indicator string
+	if s.indicator != nil {
+		indicator = *s.indicator
+	}
+
+	var redactions []string
+	for _, r := range s.redactions {
+		redactions = append(redactions, fmt.Sprintf("%s:%v", r.pattern, r.style))
+	}
+
+	var paletteName string
+	if s.palette != nil {
+		paletteName = s.palette.name
+	}
+
+	metadata := make([]string, 0, len(s.metadata))
+	for key, value := range s.metadata {
+		metadata = append(metadata, key+"="+value)
+	}
+
+	sort.Strings(metadata)
+
+	for _, value := range []any{
+		s.factor, s.columns, s.rows,
+		s.defaultForegroundColor, s.defaultBackgroundColor, s.customColors,
+		s.quantization, s.sourcePalette, paletteName, s.dimOpacity, s.windowOpacity,
+		s.clipCanvas, s.trimWhitespace, s.trimBlankLines,
+		s.firstLine, s.lastLine, s.deterministic,
+		s.blinkStyle, s.blinkHidden, s.wrap,
+		s.maxLines, s.keepFirstLines, s.truncationMarker,
+		s.cursorStyle, s.cursorLine, s.cursorColumn,
+		s.drawDecorations, s.drawShadow,
+		indicator, s.indicatorStyle, s.promptTemplate,
+		s.stderrStyle, s.stderrMarker, s.stderrLines,
+		s.title, s.timestamp, s.highlights, redactions, metadata, s.dpi,
+		s.minWidth, s.minHeight, s.aspectRatio, s.alignHorizontal, s.alignVertical,
+		s.cardWidth, s.cardHeight,
+		s.shadowBaseColor, s.shadowRadius, s.shadowOffsetX, s.shadowOffsetY,
+		s.paddingTop, s.paddingRight, s.paddingBottom, s.paddingLeft,
+		s.drawBorder, s.marginTop, s.marginRight, s.marginBottom, s.marginLeft, s.cornerRadius,
+		s.borderColor, s.borderInnerColor, s.borderWidth,
+		s.fontFamily, s.fontSize, s.hinting, s.antialias, s.ligatures,
+		s.lineSpacing, s.tabWidth, s.lineNumbers, s.screenRows, s.scrollback,
+	} {
+		fmt.Fprintf(&buf, "%v\n", value)
+	}
+
+	if wm := s.watermark; wm != nil {
+		fmt.Fprintf(&buf, "%q %v %v\n", wm.Text, wm.Position, wm.Opacity)
+		if wm.Image != nil {
+			bounds := wm.Image.Bounds()
+			pixels := image.NewNRGBA(bounds.Sub(bounds.Min))
+			draw.Draw(pixels, pixels.Bounds(), wm.Image, bounds.Min, draw.Src)
+			fmt.Fprintf(&buf, "%v\n", pixels.Bounds())
+			buf.Write(pixels.Pix)
+		}
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,250 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package img
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/go-text/typesetting/shaping"
+	"github.com/golang/freetype/truetype"
+	"github.com/gonvenience/font"
+	imgfont "golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// faceLoader creates a font face for the given size in points and hinting
+type faceLoader func(size float64, hinting imgfont.Hinting) (imgfont.Face, error)
+
+// hackFontLoaders are the loaders for the default Hack font variants
+var hackFontLoaders = []faceLoader{
+	truetypeLoader(font.Hack.Regular),
+	truetypeLoader(font.Hack.Bold),
+	truetypeLoader(font.Hack.Italic),
+	truetypeLoader(font.Hack.BoldItalic),
+}
+
+func truetypeLoader(face func(*truetype.Options) imgfont.Face) faceLoader {
+	return func(size float64, hinting imgfont.Hinting) (imgfont.Face, error) {
+		return face(&truetype.Options{Size: size, DPI: defaultFontDPI, Hinting: hinting}), nil
+	}
+}
+
+// LoadCustomFonts loads custom fonts from file paths, applying them in order,
+// optionally enabling OpenType features of the fonts like "ss01" or "zero"
+func (s *Scaffold) LoadCustomFonts(fontPaths []string, features ...string) error {
+	fontFeatures, err := parseFontFeatures(features)
+	if err != nil {
+		return err
+	}
+
+	var family string
+	loaders := make([]faceLoader, 0, len(fontPaths))
+	for i, fontPath := range fontPaths {
+		fontBytes, err := os.ReadFile(fontPath)
+		if err != nil {
+			return fmt.Errorf("failed to read font file %s: %w", fontPath, err)
+		}
+
+		// The family name of the regular font is used for vector output
+		if i == 0 {
+			if parsed, err := sfnt.Parse(fontBytes); err == nil {
+				family, _ = parsed.Name(nil, sfnt.NameIDFamily)
+			}
+		}
+
+		loader, err := fileLoader(fontPath, fontBytes, fontFeatures, s.ligatures)
+		if err != nil {
+			return err
+		}
+
+		loaders = append(loaders, loader)
+	}
+
+	s.fontLoaders = loaders
+	s.fontFamily = family
+	return s.loadFaces()
+}
+
+// fileLoader creates the loader for the font file, using a shaper in case
+// OpenType features are configured or ligatures are enabled
+func fileLoader(fontPath string, fontBytes []byte, features []shaping.FontFeature, ligatures bool) (faceLoader, error) {
+	switch {
+	case len(features) > 0 || ligatures:
+		loader, err := newFeatureLoader(fontBytes, features)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse font %s: %w", fontPath, err)
+		}
+
+		return loader, nil
+
+	case strings.HasSuffix(strings.ToLower(fontPath), ".ttf"):
+		ttfFont, err := truetype.Parse(fontBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse TTF font %s: %w", fontPath, err)
+		}
+
+		return truetypeLoader(func(opts *truetype.Options) imgfont.Face {
+			return truetype.NewFace(ttfFont, opts)
+		}), nil
+
+	default:
+		otfFont, err := opentype.Parse(fontBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse font %s: %w", fontPath, err)
+		}
+
+		return func(size float64, hinting imgfont.Hinting) (imgfont.Face, error) {
+			face, err := opentype.NewFace(otfFont, &opentype.FaceOptions{Size: size, DPI: defaultFontDPI, Hinting: hinting})
+			if err != nil {
+				return nil, fmt.Errorf("failed to create font face for %s: %w", fontPath, err)
+			}
+
+			return face, nil
+		}, nil
+	}
+}
+
+// SetHinting configures the hinting of the glyph outlines, which re-creates
+// the font faces of the default or custom fonts
+func (s *Scaffold) SetHinting(hinting imgfont.Hinting) error {
+	s.hinting = hinting
+	return s.loadFaces()
+}
+
+// SetFontSize sets the size of the font in points, which re-creates the font
+// faces of the default or custom fonts, including fonts loaded afterwards
+func (s *Scaffold) SetFontSize(size float64) error {
+	if size <= 0 || math.IsInf(size, 0) || math.IsNaN(size) {
+		return fmt.Errorf("invalid font size %v, expected a positive number", size)
+	}
+
+	s.fontSize = size
+	return s.loadFaces()
+}
+
+// SetAntialiasing configures whether glyphs are rendered with antialiasing,
+// without it every pixel of a glyph is either fully drawn or not at all
+func (s *Scaffold) SetAntialiasing(value bool) { s.antialias = value }
+
+func (s *Scaffold) loadFaces() error {
+	for i, loader := range s.fontLoaders {
+		loaded, err := loader(s.factor*s.fontSize, s.hinting)
+		if err != nil {
+			return err
+		}
+
+		face := newCachedFace(loaded)
+
+		// Apply fonts in order: regular, bold, italic, boldItalic
+		// If only one font is provided, use it for all variants
+		switch i % 4 {
+		case 0:
+			s.regular = face
+			// If only one font provided, use it for all variants
+			if len(s.fontLoaders) == 1 {
+				s.bold = face
+				s.italic = face
+				s.boldItalic = face
+			}
+		case 1:
+			s.bold = face
+		case 2:
+			s.italic = face
+		case 3:
+			s.boldItalic = face
+		}
+	}
+
+	s.fallbacks = make([]imgfont.Face, len(s.fallbackLoaders))
+	for i, loader := range s.fallbackLoaders {
+		face, err := loader(s.factor*s.fontSize, s.hinting)
+		if err != nil {
+			return err
+		}
+
+		s.fallbacks[i] = newCachedFace(face)
+	}
+
+	return nil
+}
+
+// faces returns the font faces to render with, based on the antialiasing
+// setting and fallback fonts
+func (s *Scaffold) faces() (regular, bold, italic, boldItalic imgfont.Face) {
+	regular, bold, italic, boldItalic = s.regular, s.bold, s.italic, s.boldItalic
+	if len(s.fallbacks) > 0 {
+		regular = newFallbackFace(regular, s.fallbacks)
+		bold = newFallbackFace(bold, s.fallbacks)
+		italic = newFallbackFace(italic, s.fallbacks)
+		boldItalic = newFallbackFace(boldItalic, s.fallbacks)
+	}
+
+	if s.antialias {
+		return regular, bold, italic, boldItalic
+	}
+
+	return &aliasedFace{Face: regular},
+		&aliasedFace{Face: bold},
+		&aliasedFace{Face: italic},
+		&aliasedFace{Face: boldItalic}
+}
+
+// aliasedFace renders glyphs without antialiasing, by turning every pixel of
+// the glyph mask either fully on or off
+type aliasedFace struct {
+	imgfont.Face
+	mask image.Alpha
+}
+
+func (f *aliasedFace) Glyph(dot fixed.Point26_6, r rune) (dr image.Rectangle, mask image.Image, maskp image.Point, advance fixed.Int26_6, ok bool) {
+	dr, mask, maskp, advance, ok = f.Face.Glyph(dot, r)
+	if mask == nil {
+		return dr, mask, maskp, advance, ok
+	}
+
+	width, height := dr.Dx(), dr.Dy()
+	if n := width * height; cap(f.mask.Pix) < n {
+		f.mask.Pix = make([]uint8, n)
+	}
+
+	f.mask.Pix = f.mask.Pix[:width*height]
+	f.mask.Stride = width
+	f.mask.Rect = image.Rect(0, 0, width, height)
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var value uint8
+			if _, _, _, a := mask.At(maskp.X+x, maskp.Y+y).RGBA(); a >= 0x8000 {
+				value = 0xFF
+			}
+
+			f.mask.Pix[y*width+x] = value
+		}
+	}
+
+	return dr, &f.mask, image.Point{}, advance, ok
+}
@@ -0,0 +1,438 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package img
+
+import (
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gonvenience/bunt"
+	imgfont "golang.org/x/image/font"
+)
+
+// frame is the geometry of all elements of the screenshot, which is shared
+// by all output formats, all values are in pixels
+type frame struct {
+	width, height float64
+
+	window  Area
+	shadow  Area
+	content Area
+
+	corner   float64
+	radius   float64
+	distance float64
+
+	// buttonX and buttonY are the center of the first window button, which
+	// is also the vertical center of the title bar
+	buttonX, buttonY float64
+
+	text bunt.String
+
+	// gutter is the width reserved for line numbers left of the content
+	gutter float64
+
+	regular, bold, italic, boldItalic imgfont.Face
+
+	// faded is set if the content fades out towards lines that are left out
+	faded bool
+}
+
+// glyph is one character of the content placed in the window
+type glyph struct {
+	cr bunt.ColoredRune
+
+	// text is the string to draw, which is empty for line feeds
+	text string
+
+	// x and y are the position of the baseline where the glyph starts
+	x, y float64
+
+	width, height float64
+
+	face imgfont.Face
+
+	// ligature is drawn in raster images instead of the text if the glyph is
+	// shaped, it is empty for characters covered by a previous ligature
+	shaped   bool
+	ligature string
+}
+
+// rasterText returns the string to draw in raster images
+func (g glyph) rasterText() string {
+	switch {
+	case g.shaped:
+		return g.ligature
+
+	case utf8.RuneCountInString(g.text) > 1:
+		return rasterCluster(g.text)
+	}
+
+	return g.text
+}
+
+// rasterCluster returns the runes of the grapheme cluster that are drawn one
+// after another, which leaves out the invisible selectors, and the parts of
+// emoji sequences after the first joiner, since without a font that shapes
+// the sequence into one glyph, the parts would not fit into the cells
+func rasterCluster(text string) string {
+	if i := strings.IndexRune(text, '\u200D'); i > 0 {
+		text = text[:i]
+	}
+
+	return strings.Map(func(r rune) rune {
+		if r >= 0xFE00 && r <= 0xFE0F || r >= 0xE0020 && r <= 0xE007F {
+			return -1
+		}
+
+		return r
+	}, text)
+}
+
+func (s *Scaffold) frame() frame {
+	f := func(value float64) float64 { return s.factor * value }
+
+	fr := frame{
+		radius:   f(9),
+		distance: f(25),
+		text:     visualOrder(s.visibleContent(), s.table),
+	}
+
+	fr.regular, fr.bold, fr.italic, fr.boldItalic = s.faces()
+	fr.faded = s.truncationMarker == TruncationFade && s.truncated()
+	fr.gutter = s.gutterWidth(len(splitLines(fr.text)))
+	contentWidth, contentHeight := s.measureContent(fr.text)
+	contentWidth = math.Max(contentWidth, fr.gutter+s.styledWidth(fr, false))
+
+	// The size the content actually takes, in case it is aligned within a
+	// larger content area
+	textWidth, textHeight := contentWidth, contentHeight
+	if s.alignHorizontal != AlignStart {
+		textWidth = math.Max(s.textWidth(fr.text, fr.gutter), fr.gutter+s.styledWidth(fr, true))
+	}
+
+	// Make sure the output window is big enough in case no content or very few
+	// content will be rendered, and for the timestamp next to the decorations
+	contentWidth = math.Max(contentWidth, 3*fr.distance+3*fr.radius)
+	if s.timestamp != "" {
+		contentWidth = math.Max(contentWidth, 3*fr.distance+f(16)+s.timestampWidth(fr))
+	}
+
+	xOffset := s.marginLeft
+	yOffset := s.marginTop
+
+	var titleOffset float64
+	if s.titleBar() {
+		titleOffset = f(40)
+	}
+
+	innerWidth := contentWidth + s.paddingLeft + s.paddingRight
+	innerHeight := contentHeight + s.paddingTop + s.paddingBottom + titleOffset
+
+	// The content area takes the space the window is grown by, so that the
+	// content stays in the top left corner like in a terminal
+	if width, height := s.constrainSize(innerWidth, innerHeight); width != innerWidth || height != innerHeight {
+		contentWidth += width - innerWidth
+		contentHeight += height - innerHeight
+		innerWidth, innerHeight = width, height
+	}
+
+	fr.width = innerWidth + s.marginLeft + s.marginRight
+	fr.height = innerHeight + s.marginTop + s.marginBottom
+
+	// The shadow is offset to the bottom right, therefore the window is
+	// moved to the top left to keep the composition centered
+	if s.drawShadow {
+		xOffset -= s.shadowOffsetX / 2
+		yOffset -= s.shadowOffsetY / 2
+
+		fr.shadow = Area{X: xOffset + s.shadowOffsetX, Y: yOffset + s.shadowOffsetY, Width: innerWidth, Height: innerHeight}
+	}
+
+	fr.window = Area{X: xOffset, Y: yOffset, Width: innerWidth, Height: innerHeight}
+	fr.corner = math.Min(s.cornerRadius, math.Min(innerWidth, innerHeight)/2)
+	fr.content = s.alignedContent(Area{X: xOffset + s.paddingLeft, Y: yOffset + s.paddingTop + titleOffset, Width: contentWidth, Height: contentHeight}, textWidth, textHeight)
+	fr.buttonX, fr.buttonY = xOffset+s.paddingLeft+f(4), yOffset+s.paddingTop+f(4)
+
+	return fr
+}
+
+// styledWidth returns the width of the longest line as the glyphs are placed
+// with the faces of their text style, which is wider than measuring the line
+// with the regular face in case the bold or italic faces of the font are
+// wider, trailing whitespace is left out if trimmed is set
+func (s *Scaffold) styledWidth(fr frame, trimmed bool) float64 {
+	var styled bool
+	for _, cr := range fr.text {
+		if cr.Settings&0x0C != 0 {
+			styled = true
+			break
+		}
+	}
+
+	// The regular face is already considered by measuring the content
+	if !styled {
+		return 0
+	}
+
+	fr.content, fr.gutter = Area{}, 0
+
+	var width float64
+	layout := s.glyphLayout(fr)
+	for g, ok := layout.next(); ok; g, ok = layout.next() {
+		if g.cr.Symbol == '\n' || trimmed && g.cr.Symbol == ' ' {
+			continue
+		}
+
+		width = math.Max(width, g.x+g.width)
+	}
+
+	return width
+}
+
+// visibleArea returns the area of the canvas that contains the window and
+// its shadow including the blur, which is what remains of the canvas if it
+// is clipped
+func (s *Scaffold) visibleArea(fr frame) Area {
+	minX, minY := fr.window.X, fr.window.Y
+	maxX, maxY := fr.window.X+fr.window.Width, fr.window.Y+fr.window.Height
+
+	// The border is stroked along the edge of the window, i.e. half of it
+	// is outside of the window
+	if s.drawBorder {
+		minX, minY = minX-s.borderWidth/2, minY-s.borderWidth/2
+		maxX, maxY = maxX+s.borderWidth/2, maxY+s.borderWidth/2
+	}
+
+	if s.drawShadow {
+		blur := float64(s.shadowRadius)
+		minX = math.Min(minX, fr.shadow.X-blur)
+		minY = math.Min(minY, fr.shadow.Y-blur)
+		maxX = math.Max(maxX, fr.shadow.X+fr.shadow.Width+blur)
+		maxY = math.Max(maxY, fr.shadow.Y+fr.shadow.Height+blur)
+	}
+
+	minX, minY = math.Max(minX, 0), math.Max(minY, 0)
+	maxX, maxY = math.Min(maxX, fr.width), math.Min(maxY, fr.height)
+
+	return Area{X: minX, Y: minY, Width: maxX - minX, Height: maxY - minY}
+}
+
+// titleBar returns whether the window has a title bar, which is needed for
+// the decorations, the title, or the timestamp
+func (s *Scaffold) titleBar() bool {
+	return s.drawDecorations || s.title != "" || s.timestamp != ""
+}
+
+// timestampWidth returns the width of the timestamp in the title bar
+func (s *Scaffold) timestampWidth(fr frame) float64 {
+	if s.timestamp == "" {
+		return 0
+	}
+
+	return float64(imgfont.MeasureString(fr.regular, s.timestamp) >> 6)
+}
+
+// titlePlacement returns the title to draw, shortened if needed, and the
+// position and horizontal anchor where to draw it
+func (s *Scaffold) titlePlacement(fr frame) (title string, x float64, anchor float64) {
+	var reserved float64
+	if s.drawDecorations {
+		reserved = 3*fr.distance + s.factor*4
+	}
+
+	// The timestamp is on the right side of the title
+	var reservedRight float64
+	if s.timestamp != "" {
+		reservedRight = s.timestampWidth(fr) + s.factor*16
+	}
+
+	measure := func(runes []rune) float64 {
+		return float64(imgfont.MeasureString(fr.regular, string(runes)) >> 6)
+	}
+
+	runes := []rune(s.title)
+	maxWidth := fr.window.Width - s.paddingLeft - s.paddingRight - reserved - reservedRight
+	for len(runes) > 1 {
+		if measure(runes) <= maxWidth {
+			break
+		}
+
+		runes = append(runes[:len(runes)-2], '…')
+	}
+
+	x, anchor = fr.window.X+fr.window.Width/2, 0.5
+	if measure(runes) > fr.window.Width-2*(s.paddingLeft+math.Max(reserved, reservedRight)) {
+		x, anchor = fr.window.X+s.paddingLeft+reserved, 0.0
+	}
+
+	return string(runes), x, anchor
+}
+
+// highlightArea returns the area of a highlighted line, which spans the full
+// width of the window
+func (s *Scaffold) highlightArea(fr frame, line int) Area {
+	metrics := s.regular.Metrics()
+	lineHeight := float64(metrics.Height) / 64 * s.lineSpacing
+	ascent := float64(metrics.Ascent) / 64
+	descent := float64(metrics.Descent) / 64
+
+	baseline := fr.content.Y + s.fontHeight() + float64(line)*lineHeight
+	return Area{
+		X:      fr.window.X,
+		Y:      baseline - ascent - (lineHeight-ascent-descent)/2,
+		Width:  fr.window.Width,
+		Height: lineHeight,
+	}
+}
+
+// cell returns the area of the character cell of the glyph, which spans the
+// full line height with the glyph vertically centered like in a highlighted
+// line, the edges are on whole pixels, so that the cells of adjacent
+// characters and lines touch without any gap or overlap
+func (s *Scaffold) cell(g glyph) Area {
+	metrics := g.face.Metrics()
+	ascent := float64(metrics.Ascent) / 64
+	descent := float64(metrics.Descent) / 64
+	lineHeight := g.height * s.lineSpacing
+
+	top := g.y - ascent - (lineHeight-ascent-descent)/2
+	x0, y0 := math.Round(g.x), math.Round(top)
+	return Area{X: x0, Y: y0, Width: math.Round(g.x+g.width) - x0, Height: math.Round(top+lineHeight) - y0}
+}
+
+// glyphs places all characters of the content in the window
+func (s *Scaffold) glyphs(fr frame) []glyph {
+	glyphs := make([]glyph, 0, len(fr.text))
+
+	layout := s.glyphLayout(fr)
+	for g, ok := layout.next(); ok; g, ok = layout.next() {
+		glyphs = append(glyphs, g)
+	}
+
+	return glyphs
+}
+
+// glyphLayout places the characters of the content one after another, so
+// that only the glyphs currently needed have to be kept in memory
+type glyphLayout struct {
+	s    *Scaffold
+	fr   frame
+	x, y float64
+	pos  int
+
+	// shaped are the runes to draw for the characters of the current line
+	// starting at lineStart, in case ligatures are enabled
+	shaped    []rune
+	lineStart int
+}
+
+func (s *Scaffold) glyphLayout(fr frame) *glyphLayout {
+	return &glyphLayout{s: s, fr: fr, x: fr.content.X + fr.gutter, y: fr.content.Y + s.fontHeight()}
+}
+
+// next returns the next glyph, or false if all characters are placed
+func (l *glyphLayout) next() (glyph, bool) {
+	if l.pos >= len(l.fr.text) {
+		return glyph{}, false
+	}
+
+	s, fr := l.s, l.fr
+	if s.ligatures && (l.pos == 0 || fr.text[l.pos-1].Symbol == '\n') {
+		l.shapeLine()
+	}
+
+	cr := fr.text[l.pos]
+	cr.Settings = s.blinkSettings(cr.Settings)
+	shaped := l.shapedRune(l.pos)
+	l.pos++
+
+	face := fr.face(cr.Settings)
+
+	str := s.table.Grapheme(cr.Symbol)
+	if cr.Symbol == blurredRune {
+		str = "█"
+	}
+
+	w := float64(imgfont.MeasureString(face, str) >> 6)
+	h := float64(face.Metrics().Height) / 64
+
+	// Grapheme clusters and wide characters take one or two cells like in the
+	// terminal, and symbols of Powerline and Nerd Fonts exactly one cell,
+	// regardless of the advance of their glyphs
+	cell := float64(imgfont.MeasureString(face, " ") >> 6)
+	switch runes := []rune(str); {
+	case len(runes) > 1 || s.table.Width(cr.Symbol) == 2:
+		w = float64(s.table.Width(cr.Symbol)) * cell
+
+	case isIconRune(runes[0]):
+		w = cell
+	}
+
+	g := glyph{cr: cr, x: l.x, y: l.y, width: w, height: h, face: face}
+
+	switch str {
+	case "\n":
+		l.x = fr.content.X + fr.gutter
+		l.y += h * s.lineSpacing
+
+	case "█":
+		if cr.Symbol != blurredRune {
+			g.text = str
+		}
+
+		l.x += w
+
+	case "✗", "ˣ": // mitigate issue #1 by replacing it with a similar character
+		g.text = "×"
+		l.x += w
+
+	default:
+		g.text = str
+		l.x += w
+
+		if shaped != cr.Symbol {
+			g.shaped = true
+			if shaped != 0 {
+				g.ligature = string(shaped)
+			}
+		}
+	}
+
+	return g, true
+}
+
+// face returns the font face for the text style of the settings
+func (fr frame) face(settings uint64) imgfont.Face {
+	switch settings & 0x0C {
+	case 4:
+		return fr.bold
+
+	case 8:
+		return fr.italic
+
+	case 12:
+		return fr.boldItalic
+	}
+
+	return fr.regular
+}
@@ -0,0 +1,166 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package img
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+
+	"github.com/fogleman/gg"
+
+	imgfont "golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// cachedFace keeps the advance and the rasterized mask of each glyph, so that
+// repeated characters are only measured and rasterized once per face
+type cachedFace struct {
+	imgfont.Face
+
+	advances map[rune]cachedAdvance
+	glyphs   map[glyphKey]cachedGlyph
+}
+
+type cachedAdvance struct {
+	advance fixed.Int26_6
+	ok      bool
+}
+
+// glyphKey identifies a rasterized glyph, which depends on the sub-pixel
+// position of the dot, but not on its integer position
+type glyphKey struct {
+	r      rune
+	fx, fy fixed.Int26_6
+}
+
+type cachedGlyph struct {
+	dr      image.Rectangle
+	mask    *image.Alpha
+	advance fixed.Int26_6
+	ok      bool
+}
+
+func newCachedFace(face imgfont.Face) *cachedFace {
+	return &cachedFace{
+		Face:     face,
+		advances: map[rune]cachedAdvance{},
+		glyphs:   map[glyphKey]cachedGlyph{},
+	}
+}
+
+func (f *cachedFace) GlyphAdvance(r rune) (advance fixed.Int26_6, ok bool) {
+	entry, found := f.advances[r]
+	if !found {
+		entry.advance, entry.ok = f.Face.GlyphAdvance(r)
+		f.advances[r] = entry
+	}
+
+	return entry.advance, entry.ok
+}
+
+func (f *cachedFace) Glyph(dot fixed.Point26_6, r rune) (dr image.Rectangle, mask image.Image, maskp image.Point, advance fixed.Int26_6, ok bool) {
+	offset := image.Pt(dot.X.Floor(), dot.Y.Floor())
+	key := glyphKey{r: r, fx: dot.X & 0x3F, fy: dot.Y & 0x3F}
+
+	entry, found := f.glyphs[key]
+	if !found {
+		entry = f.rasterize(key)
+		f.glyphs[key] = entry
+	}
+
+	if entry.mask == nil {
+		return entry.dr.Add(offset), nil, image.Point{}, entry.advance, entry.ok
+	}
+
+	return entry.dr.Add(offset), entry.mask, image.Point{}, entry.advance, entry.ok
+}
+
+// rasterize renders the glyph at the sub-pixel position of the key, the mask
+// is copied, since faces reuse their buffers for the next glyph
+func (f *cachedFace) rasterize(key glyphKey) cachedGlyph {
+	dr, mask, maskp, advance, ok := f.Face.Glyph(fixed.Point26_6{X: key.fx, Y: key.fy}, key.r)
+
+	entry := cachedGlyph{dr: dr, advance: advance, ok: ok}
+	if mask != nil {
+		entry.mask = image.NewAlpha(image.Rect(0, 0, dr.Dx(), dr.Dy()))
+		draw.Draw(entry.mask, entry.mask.Bounds(), mask, maskp, draw.Src)
+	}
+
+	return entry
+}
+
+// drawGlyph draws the text of the glyph by compositing the glyph masks
+// directly onto the image, which is considerably faster than drawing the
+// string using the drawing context, which transforms every mask; since the
+// masks are only translated, the result is the same
+func drawGlyph(dc *gg.Context, g glyph, c color.Color) {
+	dst, ok := dc.Image().(*image.RGBA)
+	if !ok {
+		dc.SetFontFace(g.face)
+		dc.SetColor(c)
+		dc.DrawString(g.rasterText(), g.x, g.y)
+		return
+	}
+
+	sr, sg, sb, sa := c.RGBA()
+
+	// The drawing context is at most translated by whole pixels, which is
+	// applied after the conversion to keep the sub-pixel position the same
+	tx, ty := dc.TransformPoint(0, 0)
+	dot := fixed.Point26_6{
+		X: fixed.Int26_6(g.x*64) + fixed.I(int(tx)),
+		Y: fixed.Int26_6(g.y*64) + fixed.I(int(ty)),
+	}
+	prev := rune(-1)
+	for _, r := range g.rasterText() {
+		if prev >= 0 {
+			dot.X += g.face.Kern(prev, r)
+		}
+
+		dr, mask, maskp, advance, ok := g.face.Glyph(dot, r)
+		if !ok {
+			continue
+		}
+
+		area := dr.Intersect(dst.Bounds())
+		for y := area.Min.Y; y < area.Max.Y; y++ {
+			for x := area.Min.X; x < area.Max.X; x++ {
+				_, _, _, ma := mask.At(maskp.X+x-dr.Min.X, maskp.Y+y-dr.Min.Y).RGBA()
+				if ma == 0 {
+					continue
+				}
+
+				// Same arithmetic as the "over" operator of the drawing context
+				pa1 := 0xFFFF - sa*ma/0xFFFF
+				i := dst.PixOffset(x, y)
+				pix := dst.Pix[i : i+4 : i+4]
+				pix[0] = uint8((uint32(pix[0])*0x101*pa1/0xFFFF + sr*ma/0xFFFF) >> 8)
+				pix[1] = uint8((uint32(pix[1])*0x101*pa1/0xFFFF + sg*ma/0xFFFF) >> 8)
+				pix[2] = uint8((uint32(pix[2])*0x101*pa1/0xFFFF + sb*ma/0xFFFF) >> 8)
+				pix[3] = uint8((uint32(pix[3])*0x101*pa1/0xFFFF + sa*ma/0xFFFF) >> 8)
+			}
+		}
+
+		dot.X += advance
+		prev = r
+	}
+}
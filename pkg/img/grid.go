@@ -0,0 +1,78 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package img
+
+import (
+	"image"
+	"image/draw"
+)
+
+// Grid composes the provided images into one image by placing them in rows
+// with the given number of columns. All images in the same column share the
+// width of the widest image in that column, all images in the same row share
+// the height of the highest image in that row.
+func Grid(columns int, images ...image.Image) image.Image {
+	if columns < 1 {
+		columns = 1
+	}
+
+	if columns > len(images) {
+		columns = len(images)
+	}
+
+	if columns == 0 {
+		return image.NewRGBA(image.Rect(0, 0, 0, 0))
+	}
+
+	rows := (len(images) + columns - 1) / columns
+	widths, heights := make([]int, columns), make([]int, rows)
+	for i, img := range images {
+		col, row := i%columns, i/columns
+		widths[col] = max(widths[col], img.Bounds().Dx())
+		heights[row] = max(heights[row], img.Bounds().Dy())
+	}
+
+	var totalWidth, totalHeight int
+	for _, width := range widths {
+		totalWidth += width
+	}
+
+	for _, height := range heights {
+		totalHeight += height
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, totalWidth, totalHeight))
+
+	var y int
+	for row := 0; row < rows; row++ {
+		var x int
+		for col := 0; col < columns && row*columns+col < len(images); col++ {
+			img := images[row*columns+col]
+			bounds := img.Bounds()
+			draw.Draw(dst, image.Rect(x, y, x+bounds.Dx(), y+bounds.Dy()), img, bounds.Min, draw.Over)
+			x += widths[col]
+		}
+
+		y += heights[row]
+	}
+
+	return dst
+}
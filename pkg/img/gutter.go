@@ -0,0 +1,75 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package img
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gonvenience/bunt"
+	imgfont "golang.org/x/image/font"
+)
+
+// ShowLineNumbers configures whether a gutter with dimmed line numbers is
+// shown left of the content
+func (s *Scaffold) ShowLineNumbers(value bool) { s.lineNumbers = value }
+
+// lineNumberColor is the color of the line numbers in the gutter
+var lineNumberColor = bunt.DimGray
+
+// gutterWidth returns the width of the gutter for the given number of lines,
+// which is the width of the largest line number and one space
+func (s *Scaffold) gutterWidth(lines int) float64 {
+	if !s.lineNumbers {
+		return 0
+	}
+
+	digits := len(strconv.Itoa(max(lines, 1)))
+	return float64(imgfont.MeasureString(s.regular, strings.Repeat("0", digits+1)) >> 6)
+}
+
+// lineNumberGlyphs places the line numbers right-aligned in the gutter, one for
+// each line of the content
+func (s *Scaffold) lineNumberGlyphs(fr frame) []glyph {
+	if !s.lineNumbers {
+		return nil
+	}
+
+	lines := len(splitLines(fr.text))
+	space := float64(imgfont.MeasureString(fr.regular, " ") >> 6)
+	height := float64(fr.regular.Metrics().Height) / 64
+
+	glyphs := make([]glyph, lines)
+	for i := range glyphs {
+		text := strconv.Itoa(i + 1)
+		width := float64(imgfont.MeasureString(fr.regular, text) >> 6)
+		glyphs[i] = glyph{
+			text:   text,
+			x:      fr.content.X + fr.gutter - space - width,
+			y:      fr.content.Y + s.fontHeight() + float64(i)*height*s.lineSpacing,
+			width:  width,
+			height: height,
+			face:   fr.regular,
+		}
+	}
+
+	return glyphs
+}
@@ -0,0 +1,287 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package img
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"image/color"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/gonvenience/bunt"
+
+	"github.com/homeport/termshot/internal/vt"
+)
+
+// WriteHTML writes the scaffold content as a standalone HTML document into the
+// provided writer, with the content as styled text and the window drawn using
+// CSS, custom decorations, the background, and the watermark are not included
+func (s *Scaffold) WriteHTML(w io.Writer) error {
+	// All sizes are in CSS pixels, which correspond to the unscaled pixels
+	f := func(value float64) string { return num(value/s.factor) + "px" }
+
+	out := bufio.NewWriter(w)
+	p := func(format string, a ...interface{}) { _, _ = fmt.Fprintf(out, format, a...) }
+
+	fontFamily := "monospace"
+	if family := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`'"&<>;{}`, r) {
+			return -1
+		}
+		return r
+	}, s.fontFamily); family != "" {
+		fontFamily = fmt.Sprintf("'%s', monospace", family)
+	}
+
+	title := s.title
+	if title == "" {
+		title = "termshot"
+	}
+
+	margin := fmt.Sprintf("%s %s %s %s", f(s.marginTop), f(s.marginRight), f(s.marginBottom), f(s.marginLeft))
+	if s.clipCanvas {
+		margin = "0"
+	}
+
+	var windowStyle string
+	var shadows []string
+	if s.drawBorder {
+		windowStyle += fmt.Sprintf(" border: %s solid %s;", f(s.borderWidth), cssColor(s.borderColor))
+		if s.borderInnerColor != nil {
+			shadows = append(shadows, fmt.Sprintf("inset 0 0 0 %s %s", f(s.borderWidth), cssColor(s.borderInnerColor)))
+		}
+	}
+
+	// The window is grown like in images in case of size constraints
+	if s.minWidth > 0 || s.minHeight > 0 || s.aspectRatio > 0 {
+		fr := s.frame()
+		windowStyle += fmt.Sprintf(" box-sizing: border-box; min-width: %s; min-height: %s;", f(fr.window.Width), f(fr.window.Height))
+	}
+
+	if s.drawShadow {
+		shadows = append(shadows, fmt.Sprintf("%s %s %s %s", f(s.shadowOffsetX), f(s.shadowOffsetY), f(float64(s.shadowRadius)), cssColor(parseShadowColor(s.shadowBaseColor))))
+	}
+
+	if len(shadows) > 0 {
+		windowStyle += fmt.Sprintf(" box-shadow: %s;", strings.Join(shadows, ", "))
+	}
+
+	p("<!DOCTYPE html>\n")
+	p("<html>\n<head>\n")
+	p("<meta charset=\"utf-8\">\n")
+	p("<title>%s</title>\n", html.EscapeString(title))
+	p("<style>\n")
+	p(".termshot { display: inline-block; margin: %s; }\n", margin)
+	p(".termshot .window { position: relative; padding: %s %s %s %s; border-radius: %s; background: %s; color: %s; font-family: %s; font-size: %s;%s }\n",
+		f(s.paddingTop), f(s.paddingRight), f(s.paddingBottom), f(s.paddingLeft),
+		f(s.cornerRadius), cssColor(s.windowColor()), cssColor(s.defaultForegroundColor),
+		fontFamily, f(s.factor*s.fontSize*defaultFontDPI/72), windowStyle)
+	p(".termshot .titlebar { position: relative; height: %s; }\n", f(s.factor*40))
+	p(".termshot .button { position: absolute; top: %s; width: %s; height: %s; border-radius: 50%%; }\n", f(s.factor*-5), f(s.factor*18), f(s.factor*18))
+	p(".termshot .title { position: absolute; top: %s; left: 0; right: 0; padding: 0 %s; transform: translateY(-50%%); text-align: center; white-space: pre; overflow: hidden; text-overflow: ellipsis; }\n", f(s.factor*4), f(s.factor*79))
+	p(".termshot .timestamp { position: absolute; top: %s; right: 0; transform: translateY(-50%%); white-space: pre; color: %s; }\n", f(s.factor*4), cssColor(lineNumberColor))
+	p(".termshot .content { white-space: pre; line-height: %s; }\n", num(s.lineSpacing))
+	if s.alignHorizontal == AlignCenter {
+		p(".termshot .content { width: fit-content; margin-left: auto; margin-right: auto; }\n")
+	}
+
+	if s.alignVertical == AlignCenter {
+		p(".termshot .window { display: flex; flex-direction: column; }\n")
+		p(".termshot .content { margin-top: auto; margin-bottom: auto; }\n")
+	}
+
+	p(".termshot .line { min-height: %sem; margin: 0 -%s 0 -%s; padding: 0 %s 0 %s; }\n", num(s.lineSpacing), f(s.paddingRight), f(s.paddingLeft), f(s.paddingRight), f(s.paddingLeft))
+	p("@keyframes termshot-blink { 50%% { color: transparent; } }\n")
+	p("</style>\n")
+	p("</head>\n<body>\n")
+	p("<div class=\"termshot\"><div class=\"window\">\n")
+
+	// Optional: Title bar with window decorations (i.e. three buttons) and title
+	//
+	if s.titleBar() {
+		p("<div class=\"titlebar\">")
+		if s.drawDecorations {
+			for i, color := range []string{red, yellow, green} {
+				p("<span class=\"button\" style=\"left: %s; background: %s;\"></span>", f(s.factor*(float64(i)*25-5)), color)
+			}
+		}
+
+		if s.title != "" {
+			p("<div class=\"title\">%s</div>", html.EscapeString(s.title))
+		}
+
+		if s.timestamp != "" {
+			p("<div class=\"timestamp\">%s</div>", html.EscapeString(s.timestamp))
+		}
+
+		p("</div>\n")
+	}
+
+	// Optional: Fade out the content towards the lines that are left out
+	//
+	var contentStyle string
+	if s.truncationMarker == TruncationFade && s.truncated() {
+		direction := "bottom"
+		if s.keepFirstLines {
+			direction = "top"
+		}
+
+		contentStyle = fmt.Sprintf(" style=\"mask-image: linear-gradient(to %s, transparent, black %sem);\"", direction, num(fadeLines*s.lineSpacing))
+	}
+
+	p("<div class=\"content\"%s>", contentStyle)
+	lines := splitLines(s.visibleContent())
+	digits := len(strconv.Itoa(len(lines)))
+	for i, line := range lines {
+		p("<div class=\"line\"")
+		if color, ok := s.highlights[i]; ok {
+			p(" style=\"background: %s;\"", cssColor(color))
+		}
+
+		p(">")
+
+		if s.lineNumbers {
+			p("<span style=\"color: %s\">%*d </span>", cssColor(lineNumberColor), digits, i+1)
+		}
+
+		// Consecutive characters with the same style are combined into one span
+		for j := 0; j < len(line); j++ {
+			start := line[j]
+
+			blurred := start.Symbol == blurredRune
+
+			var text strings.Builder
+			for ; j < len(line) && line[j].Settings == start.Settings && (line[j].Symbol == blurredRune) == blurred; j++ {
+				switch line[j].Symbol {
+				case blurredRune:
+					text.WriteRune('█')
+
+				default:
+					text.WriteString(s.table.Grapheme(line[j].Symbol))
+				}
+			}
+
+			j--
+
+			style := s.cssStyle(start)
+			if blurred {
+				style = strings.TrimPrefix(style+"; filter: blur("+f(s.factor*3)+")", "; ")
+			}
+
+			if style != "" {
+				p("<span style=\"%s\">%s</span>", style, html.EscapeString(text.String()))
+			} else {
+				p("%s", html.EscapeString(text.String()))
+			}
+		}
+
+		p("</div>")
+	}
+
+	p("</div>\n")
+	p("</div></div>\n")
+	p("</body>\n</html>\n")
+
+	return out.Flush()
+}
+
+// cssStyle returns the inline CSS style of the character
+func (s *Scaffold) cssStyle(cr bunt.ColoredRune) string {
+	var styles []string
+	if cr.Settings&0xA1 != 0 {
+		styles = append(styles, "color: "+cssColor(s.foregroundColor(cr)))
+	}
+
+	if bg, ok := s.backgroundColor(cr); ok {
+		styles = append(styles, "background: "+cssColor(bg))
+	}
+
+	if cr.Settings&0x04 != 0 {
+		styles = append(styles, "font-weight: bold")
+	}
+
+	if cr.Settings&0x08 != 0 {
+		styles = append(styles, "font-style: italic")
+	}
+
+	switch cr.Settings & 0x50 {
+	case 0x10:
+		styles = append(styles, "text-decoration: underline")
+
+	case 0x40:
+		styles = append(styles, "text-decoration: line-through")
+
+	case 0x50:
+		styles = append(styles, "text-decoration: underline line-through")
+	}
+
+	if name, ok := underlineNames[s.table.Underline(cr.Settings)]; ok {
+		styles = append(styles, "text-decoration-style: "+name)
+	}
+
+	if c, ok := s.underlineColor(cr); ok && cr.Settings&0x10 != 0 {
+		styles = append(styles, "text-decoration-color: "+cssColor(c))
+	}
+
+	if vt.Blink(cr.Settings) {
+		styles = append(styles, fmt.Sprintf("animation: termshot-blink %s step-end infinite", num((2*blinkInterval).Seconds())+"s"))
+	}
+
+	return strings.Join(styles, "; ")
+}
+
+// splitLines splits the content into lines without the line feeds
+func splitLines(content bunt.String) []bunt.String {
+	if len(content) == 0 {
+		return nil
+	}
+
+	var lines []bunt.String
+	var line bunt.String
+	for _, cr := range content {
+		if cr.Symbol == '\n' {
+			lines = append(lines, line)
+			line = nil
+			continue
+		}
+
+		line = append(line, cr)
+	}
+
+	if len(line) > 0 {
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+// cssColor returns the CSS notation of the color
+func cssColor(c color.Color) string {
+	nrgba := color.NRGBAModel.Convert(c).(color.NRGBA)
+	if nrgba.A == 0xFF {
+		return HexColor(c)
+	}
+
+	return fmt.Sprintf("rgba(%d, %d, %d, %s)", nrgba.R, nrgba.G, nrgba.B, num(float64(nrgba.A)/255))
+}
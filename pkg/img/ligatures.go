@@ -0,0 +1,154 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package img
+
+import (
+	"github.com/go-text/typesetting/di"
+	"github.com/go-text/typesetting/language"
+	"github.com/go-text/typesetting/shaping"
+	imgfont "golang.org/x/image/font"
+	"golang.org/x/image/font/sfnt"
+)
+
+// ligatureBase is the start of the private use runes that refer to a glyph
+// of the font directly, which are used for the shaped ligatures, since the
+// glyphs of the font faces are looked up by rune
+const ligatureBase = 0x100000
+
+// isLigatureRune returns whether the rune refers to a glyph of the font, the
+// last rune of the plane is excluded, since it is the rune of the missing
+// character glyph
+func isLigatureRune(r rune) bool {
+	return r >= ligatureBase && r < missingRune
+}
+
+// SetLigatures configures whether ligatures of the font like "=>" or "!=" are
+// drawn in raster images, which requires custom fonts with ligatures that are
+// loaded afterwards, see [Scaffold.LoadCustomFonts]
+func (s *Scaffold) SetLigatures(value bool) { s.ligatures = value }
+
+// ligatureFace is a font face that can shape text
+type ligatureFace interface {
+	// ligatures returns the rune to draw for each rune of the text, which is
+	// the rune itself, a ligature rune, or zero if the rune is covered by the
+	// ligature of a previous one, or nil if nothing changes
+	ligatures(text []rune) []rune
+}
+
+// faceLigatures returns the ligatures of the text, if the face can shape it
+func faceLigatures(face imgfont.Face, text []rune) []rune {
+	if face, ok := face.(ligatureFace); ok {
+		return face.ligatures(text)
+	}
+
+	return nil
+}
+
+func (f *cachedFace) ligatures(text []rune) []rune   { return faceLigatures(f.Face, text) }
+func (f *fallbackFace) ligatures(text []rune) []rune { return faceLigatures(f.Face, text) }
+func (f *aliasedFace) ligatures(text []rune) []rune  { return faceLigatures(f.Face, text) }
+
+func (f *featureFace) ligatures(text []rune) []rune {
+	// Programming ligatures are defined for the default and the Latin script,
+	// which is used for all text, since it is rendered left to right anyway
+	output := f.shaper.Shape(shaping.Input{
+		Text:         text,
+		RunEnd:       len(text),
+		Direction:    di.DirectionLTR,
+		Face:         f.shaped,
+		FontFeatures: f.features,
+		Size:         f.scale,
+		Script:       language.Latin,
+		Language:     language.DefaultLanguage(),
+	})
+
+	var result []rune
+	for _, g := range output.Glyphs {
+		// Only clusters shaped into one glyph can be drawn in the cell of
+		// their first character, which is the case for ligatures as well as
+		// the contextual alternates most coding fonts use for them
+		if g.GlyphCount != 1 || g.GlyphID == 0 || g.ClusterIndex+g.RuneCount > len(text) {
+			continue
+		}
+
+		if g.RuneCount == 1 && sfnt.GlyphIndex(g.GlyphID) == f.glyphIndex(text[g.ClusterIndex]) {
+			continue
+		}
+
+		if result == nil {
+			result = append([]rune(nil), text...)
+		}
+
+		result[g.ClusterIndex] = ligatureBase + rune(g.GlyphID)
+		for i := 1; i < g.RuneCount; i++ {
+			result[g.ClusterIndex+i] = 0
+		}
+	}
+
+	return result
+}
+
+// shapeLine shapes the line starting at the current position in runs of
+// characters with the same font face
+func (l *glyphLayout) shapeLine() {
+	text := l.fr.text
+	end := l.pos
+	for end < len(text) && text[end].Symbol != '\n' {
+		end++
+	}
+
+	l.lineStart, l.shaped = l.pos, nil
+	for start := l.pos; start < end; {
+		face := l.fr.face(l.s.blinkSettings(text[start].Settings))
+
+		stop := start + 1
+		for stop < end && l.fr.face(l.s.blinkSettings(text[stop].Settings)) == face {
+			stop++
+		}
+
+		runes := make([]rune, 0, stop-start)
+		for _, cr := range text[start:stop] {
+			runes = append(runes, cr.Symbol)
+		}
+
+		if shaped := faceLigatures(face, runes); shaped != nil {
+			if l.shaped == nil {
+				l.shaped = make([]rune, end-l.pos)
+				for i, cr := range text[l.pos:end] {
+					l.shaped[i] = cr.Symbol
+				}
+			}
+
+			copy(l.shaped[start-l.lineStart:], shaped)
+		}
+
+		start = stop
+	}
+}
+
+// shapedRune returns the rune to draw for the character at the position
+func (l *glyphLayout) shapedRune(pos int) rune {
+	if i := pos - l.lineStart; l.shaped != nil && i < len(l.shaped) {
+		return l.shaped[i]
+	}
+
+	return l.fr.text[pos].Symbol
+}
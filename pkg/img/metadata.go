@@ -0,0 +1,176 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package img
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"sort"
+)
+
+// pngSignature is the fixed header of every PNG file
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// SetMetadata sets a text entry that is embedded into PNG images as
+// compressed text chunk, an empty value removes the entry again
+func (s *Scaffold) SetMetadata(key, value string) {
+	if s.metadata == nil {
+		s.metadata = map[string]string{}
+	}
+
+	if value == "" {
+		delete(s.metadata, key)
+		return
+	}
+
+	s.metadata[key] = value
+}
+
+// ReadMetadata reads all text entries of a PNG image, which includes the
+// entries set using [Scaffold.SetMetadata]
+func ReadMetadata(r io.Reader) (map[string]string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+
+	if !bytes.HasPrefix(data, pngSignature) {
+		return nil, fmt.Errorf("not a PNG image")
+	}
+
+	metadata := map[string]string{}
+	for offset := len(pngSignature); offset+12 <= len(data); {
+		length := int(binary.BigEndian.Uint32(data[offset:]))
+		if offset+12+length > len(data) {
+			return nil, fmt.Errorf("truncated PNG image")
+		}
+
+		kind := string(data[offset+4 : offset+8])
+		chunk := data[offset+8 : offset+8+length]
+		offset += 12 + length
+
+		switch kind {
+		case "tEXt":
+			if key, value, found := bytes.Cut(chunk, []byte{0}); found {
+				metadata[string(key)] = string(value)
+			}
+
+		case "zTXt":
+			key, compressed, found := bytes.Cut(chunk, []byte{0})
+			if !found || len(compressed) == 0 {
+				continue
+			}
+
+			zr, err := zlib.NewReader(bytes.NewReader(compressed[1:]))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read text chunk %q: %w", key, err)
+			}
+
+			value, err := io.ReadAll(zr)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read text chunk %q: %w", key, err)
+			}
+
+			metadata[string(key)] = string(value)
+
+		case "IEND":
+			return metadata, nil
+		}
+	}
+
+	return metadata, nil
+}
+
+// pngChunk is an ancillary chunk that is added to the encoded PNG image
+type pngChunk struct {
+	kind string
+	data []byte
+}
+
+// metadataChunks creates the compressed text chunks of the metadata, sorted
+// by key so that the same metadata always results in the same image
+func metadataChunks(metadata map[string]string) ([]pngChunk, error) {
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	chunks := make([]pngChunk, 0, len(keys))
+	for _, key := range keys {
+		if len(key) == 0 || len(key) > 79 || bytes.IndexByte([]byte(key), 0) >= 0 {
+			return nil, fmt.Errorf("invalid metadata key %q, expected 1 to 79 characters", key)
+		}
+
+		var chunk bytes.Buffer
+		chunk.WriteString(key)
+		chunk.Write([]byte{0, 0}) // separator and compression method
+
+		zw := zlib.NewWriter(&chunk)
+		if _, err := zw.Write([]byte(metadata[key])); err != nil {
+			return nil, err
+		}
+
+		if err := zw.Close(); err != nil {
+			return nil, err
+		}
+
+		chunks = append(chunks, pngChunk{kind: "zTXt", data: chunk.Bytes()})
+	}
+
+	return chunks, nil
+}
+
+// insertChunks adds the chunks directly after the header chunk of the encoded
+// PNG image
+func insertChunks(data []byte, chunks []pngChunk) ([]byte, error) {
+	// signature and header chunk with its 13 bytes of data
+	headerEnd := len(pngSignature) + 12 + 13
+	if len(data) < headerEnd || !bytes.HasPrefix(data, pngSignature) {
+		return nil, fmt.Errorf("not a PNG image")
+	}
+
+	var buf bytes.Buffer
+	buf.Write(data[:headerEnd])
+	for _, chunk := range chunks {
+		writeChunk(&buf, chunk.kind, chunk.data)
+	}
+
+	buf.Write(data[headerEnd:])
+	return buf.Bytes(), nil
+}
+
+func writeChunk(buf *bytes.Buffer, kind string, data []byte) {
+	_ = binary.Write(buf, binary.BigEndian, uint32(len(data))) // #nosec G115
+
+	crc := crc32.NewIEEE()
+	_, _ = crc.Write([]byte(kind))
+	_, _ = crc.Write(data)
+
+	buf.WriteString(kind)
+	buf.Write(data)
+	_ = binary.Write(buf, binary.BigEndian, crc.Sum32())
+}
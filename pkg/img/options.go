@@ -0,0 +1,323 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package img
+
+import (
+	"image/color"
+	"regexp"
+
+	imgfont "golang.org/x/image/font"
+)
+
+// Option configures the look of a scaffold created using [New]
+type Option func(*Scaffold) error
+
+// New creates a scaffold with the default look (see [NewImageCreator]) and
+// applies the options in the provided order, it fails if any option fails
+func New(opts ...Option) (Scaffold, error) {
+	s := NewImageCreator()
+	for _, opt := range opts {
+		if err := opt(&s); err != nil {
+			return Scaffold{}, err
+		}
+	}
+
+	return s, nil
+}
+
+// WithColumns sets the number of columns after which the content is wrapped
+func WithColumns(columns int) Option {
+	return func(s *Scaffold) error { s.SetColumns(columns); return nil }
+}
+
+// WithWrap sets how lines longer than the number of columns are wrapped
+func WithWrap(mode WrapMode) Option {
+	return func(s *Scaffold) error { s.SetWrap(mode); return nil }
+}
+
+// WithRows fixes the number of lines of the window
+func WithRows(rows int) Option {
+	return func(s *Scaffold) error { s.SetRows(rows); return nil }
+}
+
+// WithScrollback processes the content on a screen with the provided number
+// of rows and keeps the provided number of scrolled out lines
+func WithScrollback(rows, scrollback int) Option {
+	return func(s *Scaffold) error { return s.SetScrollback(rows, scrollback) }
+}
+
+// WithTabWidth sets the number of columns between tab stops
+func WithTabWidth(width int) Option {
+	return func(s *Scaffold) error { s.SetTabWidth(width); return nil }
+}
+
+// WithLineRange limits the content to the lines from first to last, counting
+// from one, where zero leaves the end of the range open
+func WithLineRange(first, last int) Option {
+	return func(s *Scaffold) error { s.SetLineRange(first, last); return nil }
+}
+
+// WithMaxLines limits the content to the provided number of lines
+func WithMaxLines(lines int) Option {
+	return func(s *Scaffold) error { s.SetMaxLines(lines); return nil }
+}
+
+// WithKeepFirstLines keeps the first instead of the last lines in case the
+// content has more lines than the maximum
+func WithKeepFirstLines(value bool) Option {
+	return func(s *Scaffold) error { s.KeepFirstLines(value); return nil }
+}
+
+// WithTruncationMarker sets how lines left out due to the maximum number of
+// lines are marked
+func WithTruncationMarker(marker TruncationMarker) Option {
+	return func(s *Scaffold) error { s.SetTruncationMarker(marker); return nil }
+}
+
+// WithTitle sets a title to be shown in the title bar of the window
+func WithTitle(title string) Option {
+	return func(s *Scaffold) error { s.SetTitle(title); return nil }
+}
+
+// WithCommandIndicator sets the string shown in front of the command
+func WithCommandIndicator(indicator string) Option {
+	return func(s *Scaffold) error { s.SetCommandIndicator(indicator); return nil }
+}
+
+// WithCommandIndicatorStyle sets the style of the command indicator, see
+// [Scaffold.SetCommandIndicatorStyle] for the format
+func WithCommandIndicatorStyle(style string) Option {
+	return func(s *Scaffold) error { return s.SetCommandIndicatorStyle(style) }
+}
+
+// WithPromptTemplate sets the template of the command line, see
+// [Scaffold.SetPromptTemplate] for the placeholders
+func WithPromptTemplate(template string) Option {
+	return func(s *Scaffold) error { return s.SetPromptTemplate(template) }
+}
+
+// WithStderrStyle sets the style of the output of the standard error, see
+// [Scaffold.SetStderrStyle] for the format
+func WithStderrStyle(style string) Option {
+	return func(s *Scaffold) error { return s.SetStderrStyle(style) }
+}
+
+// WithTimestamp sets the time of the capture shown in the title bar
+func WithTimestamp(timestamp string) Option {
+	return func(s *Scaffold) error { s.SetTimestamp(timestamp); return nil }
+}
+
+// WithDecorations configures whether the window buttons are drawn
+func WithDecorations(value bool) Option {
+	return func(s *Scaffold) error { s.DrawDecorations(value); return nil }
+}
+
+// WithShadow configures whether the window casts a shadow
+func WithShadow(value bool) Option {
+	return func(s *Scaffold) error { s.DrawShadow(value); return nil }
+}
+
+// WithBorder configures whether the window has an outer border
+func WithBorder(value bool) Option {
+	return func(s *Scaffold) error { s.DrawBorder(value); return nil }
+}
+
+// WithClipCanvas configures whether the image is clipped to the window
+func WithClipCanvas(value bool) Option {
+	return func(s *Scaffold) error { s.ClipCanvas(value); return nil }
+}
+
+// WithPadding sets the space between the window border and the content in
+// pixels (before scaling)
+func WithPadding(top, right, bottom, left float64) Option {
+	return func(s *Scaffold) error { s.SetPadding(top, right, bottom, left); return nil }
+}
+
+// WithMargin sets the space around the window in pixels (before scaling)
+func WithMargin(top, right, bottom, left float64) Option {
+	return func(s *Scaffold) error { s.SetMargin(top, right, bottom, left); return nil }
+}
+
+// WithScale sets the factor all sizes are scaled with
+func WithScale(factor float64) Option {
+	return func(s *Scaffold) error { return s.SetScale(factor) }
+}
+
+// WithDPI sets the scale based on the resolution in dots per inch
+func WithDPI(dpi float64) Option {
+	return func(s *Scaffold) error { return s.SetDPI(dpi) }
+}
+
+// WithFont loads custom font files, optionally enabling OpenType features
+func WithFont(fontPaths []string, features ...string) Option {
+	return func(s *Scaffold) error { return s.LoadCustomFonts(fontPaths, features...) }
+}
+
+// WithLigatures configures whether ligatures of the custom fonts are drawn,
+// it has to be applied before [WithFont]
+func WithLigatures(value bool) Option {
+	return func(s *Scaffold) error { s.SetLigatures(value); return nil }
+}
+
+// WithFallbackFonts loads font files for characters the font has no glyph for
+func WithFallbackFonts(fontPaths ...string) Option {
+	return func(s *Scaffold) error { return s.LoadFallbackFonts(fontPaths...) }
+}
+
+// WithFontSize sets the size of the font in points
+func WithFontSize(size float64) Option {
+	return func(s *Scaffold) error { return s.SetFontSize(size) }
+}
+
+// WithHinting configures the hinting of the glyph outlines
+func WithHinting(hinting imgfont.Hinting) Option {
+	return func(s *Scaffold) error { return s.SetHinting(hinting) }
+}
+
+// WithAntialiasing configures whether glyphs are rendered with antialiasing
+func WithAntialiasing(value bool) Option {
+	return func(s *Scaffold) error { s.SetAntialiasing(value); return nil }
+}
+
+// WithTheme applies the built-in color scheme with the given name
+func WithTheme(name string) Option {
+	return func(s *Scaffold) error { return s.LoadTheme(name) }
+}
+
+// WithColorscheme applies the color scheme of the JSON file
+func WithColorscheme(colorschemeFile string) Option {
+	return func(s *Scaffold) error { return s.LoadColorscheme(colorschemeFile) }
+}
+
+// WithColorschemeFormat applies the color scheme of the file in the given
+// format, see [ColorschemeFormats] for the supported formats
+func WithColorschemeFormat(colorschemeFile string, format ColorschemeFormat) Option {
+	return func(s *Scaffold) error { return s.LoadColorschemeFormat(colorschemeFile, format) }
+}
+
+// WithPalette sets the 16 standard colors of the palette
+func WithPalette(palette [16]color.Color) Option {
+	return func(s *Scaffold) error { s.SetPalette(palette); return nil }
+}
+
+// WithForegroundColor sets the default color of text without a color
+func WithForegroundColor(c color.Color) Option {
+	return func(s *Scaffold) error { s.SetForegroundColor(c); return nil }
+}
+
+// WithBackgroundColor sets the background color of the window
+func WithBackgroundColor(c color.Color) Option {
+	return func(s *Scaffold) error { s.SetBackgroundColor(c); return nil }
+}
+
+// WithDimOpacity sets the opacity with which dim text is drawn on top of
+// its background color
+func WithDimOpacity(opacity float64) Option {
+	return func(s *Scaffold) error { return s.SetDimOpacity(opacity) }
+}
+
+// WithBlinkStyle sets how blinking text is shown in static images
+func WithBlinkStyle(style BlinkStyle) Option {
+	return func(s *Scaffold) error { s.SetBlinkStyle(style); return nil }
+}
+
+// WithCursor sets the style of the cursor drawn after the content
+func WithCursor(style CursorStyle) Option {
+	return func(s *Scaffold) error { s.SetCursor(style); return nil }
+}
+
+// WithBackground sets what is drawn behind the window
+func WithBackground(background Background) Option {
+	return func(s *Scaffold) error { s.SetBackground(background); return nil }
+}
+
+// WithWindowOpacity sets the opacity of the background of the window
+func WithWindowOpacity(opacity float64) Option {
+	return func(s *Scaffold) error { return s.SetWindowOpacity(opacity) }
+}
+
+// WithBorderStyle sets the color and width of the window outline
+func WithBorderStyle(style BorderStyle) Option {
+	return func(s *Scaffold) error { return s.SetBorderStyle(style) }
+}
+
+// WithCornerRadius sets the radius of the rounded window corners in pixels
+func WithCornerRadius(radius float64) Option {
+	return func(s *Scaffold) error { s.SetCornerRadius(radius); return nil }
+}
+
+// WithMinSize sets the minimum width and height of the window in pixels
+func WithMinSize(width, height float64) Option {
+	return func(s *Scaffold) error { return s.SetMinSize(width, height) }
+}
+
+// WithAspectRatio sets the aspect ratio the window is grown to
+func WithAspectRatio(ratio float64) Option {
+	return func(s *Scaffold) error { return s.SetAspectRatio(ratio) }
+}
+
+// WithAlignment sets the alignment of the content within a larger window
+func WithAlignment(horizontal, vertical Alignment) Option {
+	return func(s *Scaffold) error { s.SetAlignment(horizontal, vertical); return nil }
+}
+
+// WithCardSize places the window on a card of the given size in pixels
+func WithCardSize(width, height int) Option {
+	return func(s *Scaffold) error { return s.SetCardSize(width, height) }
+}
+
+// WithLineNumbers configures whether line numbers are shown in a gutter
+func WithLineNumbers(value bool) Option {
+	return func(s *Scaffold) error { s.ShowLineNumbers(value); return nil }
+}
+
+// WithTrimWhitespace configures whether trailing whitespace and common
+// indentation are ignored for the window size
+func WithTrimWhitespace(value bool) Option {
+	return func(s *Scaffold) error { s.TrimWhitespace(value); return nil }
+}
+
+// WithTrimBlankLines configures whether empty lines at the start and the end
+// of the content are left out
+func WithTrimBlankLines(value bool) Option {
+	return func(s *Scaffold) error { s.TrimBlankLines(value); return nil }
+}
+
+// WithDeterministicOutput makes the output only depend on the content and
+// the settings, see [Scaffold.SetDeterministic]
+func WithDeterministicOutput() Option {
+	return func(s *Scaffold) error { s.SetDeterministic(true); return nil }
+}
+
+// WithRedaction masks all text matching the pattern
+func WithRedaction(pattern *regexp.Regexp, style RedactStyle) Option {
+	return func(s *Scaffold) error { s.Redact(pattern, style); return nil }
+}
+
+// WithWatermark stamps the watermark on top of the window
+func WithWatermark(watermark *Watermark) Option {
+	return func(s *Scaffold) error { s.SetWatermark(watermark); return nil }
+}
+
+// WithDecoration draws the custom decoration on top of the window
+func WithDecoration(decoration Decoration) Option {
+	return func(s *Scaffold) error { s.AddDecoration(decoration); return nil }
+}
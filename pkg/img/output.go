@@ -0,0 +1,1514 @@
+// Copyright © 2020 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package img
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/fogleman/gg"
+	"github.com/gonvenience/bunt"
+	"github.com/gonvenience/term"
+	imgfont "golang.org/x/image/font"
+
+	"github.com/homeport/termshot/internal/vt"
+)
+
+const (
+	red    = "#ED655A"
+	yellow = "#E1C04C"
+	green  = "#71BD47"
+)
+
+const (
+	defaultFontSize = 12
+	defaultFontDPI  = 144
+)
+
+const (
+	defaultCommandIndicator = "➜"
+
+	// deterministicColumns is the number of columns used instead of the
+	// width of the current terminal in deterministic mode
+	deterministicColumns = 80
+
+	// defaultDimOpacity is the opacity of dim text on top of its background
+	defaultDimOpacity = 0.5
+)
+
+// commandIndicator is the string to be used to indicate the command in the screenshot
+var commandIndicator = func() string {
+	if val, ok := os.LookupEnv("TS_COMMAND_INDICATOR"); ok {
+		return val
+	}
+
+	return defaultCommandIndicator
+}()
+
+// Scaffold collects the content and the look settings of a screenshot, use
+// [NewImageCreator] to create one with the default settings
+type Scaffold struct {
+	content bunt.String
+	table   *vt.Table
+	input   []byte
+
+	factor float64
+
+	columns int
+	rows    int
+
+	defaultForegroundColor color.Color
+	defaultBackgroundColor color.Color
+	customColors           map[int]color.Color
+	quantization           Quantization
+	sourcePalette          string
+	palette                *paletteMatcher
+	dimOpacity             float64
+	windowOpacity          float64
+
+	clipCanvas     bool
+	trimWhitespace bool
+	trimBlankLines bool
+
+	firstLine, lastLine int
+	deterministic       bool
+
+	blinkStyle  BlinkStyle
+	blinkHidden bool
+
+	wrap WrapMode
+
+	maxLines         int
+	keepFirstLines   bool
+	truncationMarker TruncationMarker
+
+	cursorStyle  CursorStyle
+	cursorLine   int
+	cursorColumn int
+
+	drawDecorations bool
+	drawShadow      bool
+
+	indicator      *string
+	indicatorStyle uint64
+	promptTemplate string
+
+	stderrStyle  uint64
+	stderrMarker bool
+	stderrLines  []int
+
+	title       string
+	timestamp   string
+	highlights  map[int]color.Color
+	decorations []Decoration
+	background  Background
+	redactions  []redaction
+	watermark   *Watermark
+	metadata    map[string]string
+	dpi         float64
+
+	// minWidth and minHeight are the minimum size of the window, which is
+	// grown to the aspect ratio (width divided by height) if it is set
+	minWidth, minHeight float64
+	aspectRatio         float64
+
+	alignHorizontal, alignVertical Alignment
+
+	// cardWidth and cardHeight are the size of the card in pixels, on which
+	// the window is placed, or zero if there is no card
+	cardWidth, cardHeight int
+
+	shadowBaseColor string
+	shadowRadius    uint8
+	shadowOffsetX   float64
+	shadowOffsetY   float64
+
+	paddingTop    float64
+	paddingRight  float64
+	paddingBottom float64
+	paddingLeft   float64
+	drawBorder    bool
+	marginTop     float64
+	marginRight   float64
+	marginBottom  float64
+	marginLeft    float64
+	cornerRadius  float64
+
+	borderColor, borderInnerColor color.Color
+	borderWidth                   float64
+
+	fontLoaders     []faceLoader
+	fallbackLoaders []faceLoader
+	fontFamily      string
+	fontSize        float64
+	hinting         imgfont.Hinting
+	antialias       bool
+	ligatures       bool
+
+	regular     imgfont.Face
+	bold        imgfont.Face
+	italic      imgfont.Face
+	boldItalic  imgfont.Face
+	fallbacks   []imgfont.Face
+	lineSpacing float64
+	tabWidth    int
+	lineNumbers bool
+
+	screenRows int
+	scrollback int
+}
+
+// NewImageCreator creates a scaffold with the default look, i.e. a window
+// with decorations, border, and shadow, using the Hack font
+func NewImageCreator() Scaffold {
+	f := 2.0
+
+	s := Scaffold{
+		defaultForegroundColor: bunt.LightGray,
+		defaultBackgroundColor: color.RGBA{R: 0x15, G: 0x15, B: 0x15, A: 255}, // #151515
+		dimOpacity:             defaultDimOpacity,
+		windowOpacity:          1,
+
+		factor: f,
+
+		marginTop:    f * 48,
+		marginRight:  f * 48,
+		marginBottom: f * 48,
+		marginLeft:   f * 48,
+
+		paddingTop:    f * 24,
+		paddingRight:  f * 24,
+		paddingBottom: f * 24,
+		paddingLeft:   f * 24,
+
+		cornerRadius: f * 6,
+		drawBorder:   true,
+		borderColor:  defaultBorderColor,
+		borderWidth:  f * 1,
+
+		drawDecorations: true,
+		drawShadow:      true,
+
+		shadowBaseColor: "#10101066",
+		shadowRadius:    uint8(math.Min(f*16, 255)),
+		shadowOffsetX:   f * 16,
+		shadowOffsetY:   f * 16,
+
+		fontLoaders: hackFontLoaders,
+		fontFamily:  "Hack",
+		fontSize:    defaultFontSize,
+		antialias:   true,
+
+		lineSpacing: 1.2,
+		tabWidth:    8,
+	}
+
+	_ = s.loadFaces()
+
+	return s
+}
+
+// SetFontFaceRegular sets the font face used for regular text
+func (s *Scaffold) SetFontFaceRegular(face imgfont.Face) { s.regular = face }
+
+// SetFontFaceBold sets the font face used for bold text
+func (s *Scaffold) SetFontFaceBold(face imgfont.Face) { s.bold = face }
+
+// SetFontFaceItalic sets the font face used for italic text
+func (s *Scaffold) SetFontFaceItalic(face imgfont.Face) { s.italic = face }
+
+// SetFontFaceBoldItalic sets the font face used for bold italic text
+func (s *Scaffold) SetFontFaceBoldItalic(face imgfont.Face) { s.boldItalic = face }
+
+// SetColumns sets the number of columns after which the content is wrapped,
+// by default the width of the current terminal is used
+func (s *Scaffold) SetColumns(columns int) { s.columns = columns }
+
+// SetTabWidth sets the number of columns between the tab stops of the content
+func (s *Scaffold) SetTabWidth(width int) { s.tabWidth = width }
+
+// SetScrollback processes the content on a screen with the provided number
+// of rows, like the terminal the command ran in, and keeps the provided
+// number of lines scrolled out of the screen, where a negative number keeps
+// all of them, and zero only the screen
+func (s *Scaffold) SetScrollback(rows, scrollback int) error {
+	if rows <= 0 {
+		return fmt.Errorf("invalid number of screen rows %d, expected a positive number", rows)
+	}
+
+	s.screenRows = rows
+	s.scrollback = scrollback
+	return nil
+}
+
+// SetRows fixes the number of lines of the window to the provided number,
+// where only the last lines of the content are shown, and missing lines are
+// left empty, like on the screen of a terminal
+func (s *Scaffold) SetRows(rows int) { s.rows = rows }
+
+// DrawDecorations configures whether the window buttons are drawn
+func (s *Scaffold) DrawDecorations(value bool) { s.drawDecorations = value }
+
+// DrawShadow configures whether the window casts a shadow
+func (s *Scaffold) DrawShadow(value bool) { s.drawShadow = value }
+
+// ClipCanvas configures whether the image is clipped to the visible area,
+// i.e. without the transparent margin around the window
+func (s *Scaffold) ClipCanvas(value bool) { s.clipCanvas = value }
+
+// DrawBorder configures whether the window has an outer border
+func (s *Scaffold) DrawBorder(value bool) { s.drawBorder = value }
+
+// SetDeterministic configures whether the output only depends on the content
+// and the settings, so that the same input always creates a byte-identical
+// image, e.g. for snapshot tests; the width of the current terminal and the
+// environment variable TS_COMMAND_INDICATOR are then ignored, and content is
+// wrapped after 80 columns unless the columns are set
+func (s *Scaffold) SetDeterministic(value bool) { s.deterministic = value }
+
+// SetTitle sets a title to be shown in the title bar of the window
+func (s *Scaffold) SetTitle(title string) { s.title = title }
+
+// SetTimestamp sets the time of the capture to be shown in the right corner
+// of the title bar, formatted as text
+func (s *Scaffold) SetTimestamp(timestamp string) { s.timestamp = timestamp }
+
+// SetForegroundColor sets the default color of text without a color
+func (s *Scaffold) SetForegroundColor(c color.Color) { s.defaultForegroundColor = c }
+
+// SetBackgroundColor sets the background color of the window
+func (s *Scaffold) SetBackgroundColor(c color.Color) { s.defaultBackgroundColor = c }
+
+// SetPalette sets the 16 standard colors of the palette, like a color scheme
+// with the colors color0 to color15 does, colors that are nil keep their
+// current value, see [Scaffold.SetForegroundColor] and
+// [Scaffold.SetBackgroundColor] for the default colors
+func (s *Scaffold) SetPalette(palette [16]color.Color) {
+	if s.customColors == nil {
+		s.customColors = make(map[int]color.Color)
+	}
+
+	for i, c := range palette {
+		if c != nil {
+			s.customColors[i] = c
+		}
+	}
+}
+
+// SetDimOpacity sets the opacity between 0 and 1 with which dim text is
+// drawn on top of its background color
+func (s *Scaffold) SetDimOpacity(opacity float64) error {
+	if opacity < 0 || opacity > 1 || math.IsNaN(opacity) {
+		return fmt.Errorf("invalid dim opacity %v, expected a number between 0 and 1", opacity)
+	}
+
+	s.dimOpacity = opacity
+	return nil
+}
+
+// SetWindowOpacity sets the opacity between 0 and 1 of the background of
+// the window, so that the screenshot can be placed on top of other content,
+// while the text stays opaque
+func (s *Scaffold) SetWindowOpacity(opacity float64) error {
+	if opacity < 0 || opacity > 1 || math.IsNaN(opacity) {
+		return fmt.Errorf("invalid window opacity %v, expected a number between 0 and 1", opacity)
+	}
+
+	s.windowOpacity = opacity
+	return nil
+}
+
+// windowColor returns the background color of the window with its opacity
+func (s *Scaffold) windowColor() color.Color {
+	if s.windowOpacity == 1 {
+		return s.defaultBackgroundColor
+	}
+
+	nrgba := color.NRGBAModel.Convert(s.defaultBackgroundColor).(color.NRGBA)
+	nrgba.A = uint8(math.Round(float64(nrgba.A) * s.windowOpacity))
+	return nrgba
+}
+
+// HighlightLine highlights the line with the given index (starting with zero)
+// by painting the full width of the line in the provided color
+func (s *Scaffold) HighlightLine(line int, c color.Color) {
+	if s.highlights == nil {
+		s.highlights = map[int]color.Color{}
+	}
+
+	s.highlights[line] = c
+}
+
+// highlightedLines returns the highlighted lines in order, so that adjacent
+// highlights always overlap the same way
+func (s *Scaffold) highlightedLines() []int {
+	lines := make([]int, 0, len(s.highlights))
+	for line := range s.highlights {
+		lines = append(lines, line)
+	}
+
+	sort.Ints(lines)
+	return lines
+}
+
+// SetPadding sets the space between the window border and the content in
+// pixels (before scaling)
+func (s *Scaffold) SetPadding(top, right, bottom, left float64) {
+	s.paddingTop = s.factor * top
+	s.paddingRight = s.factor * right
+	s.paddingBottom = s.factor * bottom
+	s.paddingLeft = s.factor * left
+}
+
+// SetCornerRadius sets the radius of the rounded window corners in pixels
+// (before scaling), use zero for a window with square corners
+func (s *Scaffold) SetCornerRadius(radius float64) {
+	s.cornerRadius = s.factor * math.Max(radius, 0)
+}
+
+// SetMargin sets the space around the window in pixels (before scaling)
+func (s *Scaffold) SetMargin(top, right, bottom, left float64) {
+	s.marginTop = s.factor * top
+	s.marginRight = s.factor * right
+	s.marginBottom = s.factor * bottom
+	s.marginLeft = s.factor * left
+}
+
+// SetHorizontalPadding sets the left and right padding in pixels
+func (s *Scaffold) SetHorizontalPadding(value float64) {
+	s.paddingLeft = s.factor * value
+	s.paddingRight = s.factor * value
+}
+
+// SetVerticalPadding sets the top and bottom padding in pixels
+func (s *Scaffold) SetVerticalPadding(value float64) {
+	s.paddingTop = s.factor * value
+	s.paddingBottom = s.factor * value
+}
+
+// SetHorizontalMargin sets the left and right margin in pixels
+func (s *Scaffold) SetHorizontalMargin(value float64) {
+	s.marginLeft = s.factor * value
+	s.marginRight = s.factor * value
+}
+
+// SetVerticalMargin sets the top and bottom margin in pixels
+func (s *Scaffold) SetVerticalMargin(value float64) {
+	s.marginTop = s.factor * value
+	s.marginBottom = s.factor * value
+}
+
+// colorscheme is the JSON format of a color scheme
+type colorscheme struct {
+	// Colors contains the palette colors color0 to color255, and the
+	// default foreground and background color
+	Colors map[string]string `json:"colors"`
+
+	// Truecolor defines how colors that are not palette colors are mapped
+	// to the color scheme, see [Quantization]
+	Truecolor Quantization `json:"truecolor"`
+}
+
+// Quantization defines how colors of the content, that are not exactly a
+// palette color, are mapped to the colors of a color scheme
+type Quantization string
+
+const (
+	// QuantizeAuto maps colors that are close to one of the 16 standard
+	// colors to the respective color scheme color
+	QuantizeAuto Quantization = "auto"
+
+	// QuantizeExact only maps colors that are exactly a palette color, all
+	// other colors are rendered as-is
+	QuantizeExact Quantization = "exact"
+
+	// QuantizeNearest maps all colors to the closest color of the color
+	// scheme, so that only color scheme colors are used
+	QuantizeNearest Quantization = "nearest"
+)
+
+// LoadColorscheme loads a custom colorscheme from a JSON file, either with a
+// single color scheme object, or an array of which the first one is used, or
+// from the color scheme file of a terminal, whose format is detected based on
+// the file extension and content, see [Scaffold.LoadColorschemeFormat]
+func (s *Scaffold) LoadColorscheme(colorschemeFile string) error {
+	return s.LoadColorschemeFormat(colorschemeFile, "")
+}
+
+// LoadColorschemeFormat loads a custom colorscheme from a file in the given
+// format, where an empty format is detected based on the file extension and
+// content, see [ColorschemeFormats] for the supported formats
+func (s *Scaffold) LoadColorschemeFormat(colorschemeFile string, format ColorschemeFormat) error {
+	data, err := os.ReadFile(colorschemeFile)
+	if err != nil {
+		return fmt.Errorf("failed to read colorscheme file: %w", err)
+	}
+
+	if format == "" {
+		format = detectColorschemeFormat(colorschemeFile, data)
+	}
+
+	parse, ok := colorschemeParsers[format]
+	if !ok {
+		return fmt.Errorf("unsupported colorscheme format %q, supported are: %s", format, strings.Join(ColorschemeFormats(), ", "))
+	}
+
+	scheme, err := parse(data)
+	if err != nil {
+		return err
+	}
+
+	return s.setColorscheme(scheme)
+}
+
+// applyColorscheme configures the colors based on the JSON color scheme data
+func (s *Scaffold) applyColorscheme(data []byte) error {
+	scheme, err := parseColorscheme(data)
+	if err != nil {
+		return err
+	}
+
+	return s.setColorscheme(scheme)
+}
+
+// parseColorscheme parses the JSON color scheme data
+func parseColorscheme(data []byte) (colorscheme, error) {
+	var scheme colorscheme
+	var schemeArray []colorscheme
+	if err := json.Unmarshal(data, &schemeArray); err == nil && len(schemeArray) > 0 {
+		scheme = schemeArray[0]
+
+	} else if err := json.Unmarshal(data, &scheme); err != nil {
+		return colorscheme{}, fmt.Errorf("failed to parse colorscheme JSON: %w", err)
+	}
+
+	return scheme, nil
+}
+
+// setColorscheme configures the colors based on the color scheme
+func (s *Scaffold) setColorscheme(scheme colorscheme) error {
+	s.customColors = make(map[int]color.Color)
+	for i := 0; i < 256; i++ {
+		colorKey := fmt.Sprintf("color%d", i)
+		if hexColor, exists := scheme.Colors[colorKey]; exists {
+			c, err := ParseHexColor(hexColor)
+			if err != nil {
+				return fmt.Errorf("invalid color %s for %s: %w", hexColor, colorKey, err)
+			}
+			s.customColors[i] = c
+		}
+	}
+
+	// Apply custom foreground color if specified
+	if foregroundHex, exists := scheme.Colors["foreground"]; exists {
+		c, err := ParseHexColor(foregroundHex)
+		if err != nil {
+			return fmt.Errorf("invalid foreground color %s: %w", foregroundHex, err)
+		}
+		s.defaultForegroundColor = c
+	}
+
+	// Apply custom background color if specified
+	if backgroundHex, exists := scheme.Colors["background"]; exists {
+		c, err := ParseHexColor(backgroundHex)
+		if err != nil {
+			return fmt.Errorf("invalid background color %s: %w", backgroundHex, err)
+		}
+		s.defaultBackgroundColor = c
+	}
+
+	switch scheme.Truecolor {
+	case "", QuantizeAuto, QuantizeExact, QuantizeNearest:
+		s.quantization = scheme.Truecolor
+
+	default:
+		return fmt.Errorf("invalid truecolor setting %q, supported are: auto, exact, nearest", scheme.Truecolor)
+	}
+
+	return nil
+}
+
+// ParseHexColor converts a color in hex notation (#rrggbb) to a color
+func ParseHexColor(hexStr string) (color.Color, error) {
+	hexStr = strings.TrimPrefix(hexStr, "#")
+	if len(hexStr) != 6 {
+		return nil, fmt.Errorf("hex color must be 6 characters long")
+	}
+
+	rgb, err := hex.DecodeString(hexStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex color: %w", err)
+	}
+
+	return color.RGBA{R: rgb[0], G: rgb[1], B: rgb[2], A: 255}, nil
+}
+
+// HexColor converts a color to hex notation (#RRGGBB), see [ParseHexColor]
+func HexColor(c color.Color) string {
+	r, g, b, _ := c.RGBA()
+	return fmt.Sprintf("#%02X%02X%02X", r>>8, g>>8, b>>8)
+}
+
+// getColor returns the appropriate color based on ANSI color index and custom colorscheme
+func (s *Scaffold) getColor(ansiColorIndex int, fallbackColor color.Color) color.Color {
+	if s.customColors != nil {
+		if customColor, exists := s.customColors[ansiColorIndex]; exists {
+			return customColor
+		}
+	}
+	return fallbackColor
+}
+
+// extendedColors contains the RGB values of the 8-bit palette colors 16 to
+// 255, both the common XTerm values and the ones bunt uses
+var extendedColors = func() map[[3]int]int {
+	colors := map[[3]int]int{}
+	add := func(rgb [3]int, index int) {
+		if _, exists := colors[rgb]; !exists {
+			colors[rgb] = index
+		}
+	}
+
+	levels := []int{0, 95, 135, 175, 215, 255}
+	for i := 0; i < 216; i++ {
+		r, g, b := i/36, i/6%6, i%6
+		add([3]int{levels[r], levels[g], levels[b]}, 16+i)
+		add([3]int{r * 51, g * 51, b * 51}, 16+i)
+	}
+
+	for i := 0; i < 24; i++ {
+		add([3]int{8 + 10*i, 8 + 10*i, 8 + 10*i}, 232+i)
+
+		value := int(uint8(float32(i) * (255.0 / 23.0)))
+		add([3]int{value, value, value}, 232+i)
+	}
+
+	return colors
+}()
+
+// mapStandardColor attempts to map standard ANSI RGB values to custom colors
+func (s *Scaffold) mapStandardColor(r, g, b int) (color.Color, bool) {
+	if index, found := s.paletteIndex(r, g, b); found {
+		return s.customColors[index], true
+	}
+
+	return nil, false
+}
+
+// paletteIndex returns the index of the custom color that is used for the
+// provided RGB values, if there is any
+func (s *Scaffold) paletteIndex(r, g, b int) (int, bool) {
+	if s.customColors == nil {
+		return -1, false
+	}
+
+	// Try exact match first
+	if colorIndex, found := s.paletteMatcher().standard[[3]int{r, g, b}]; found {
+		if _, exists := s.customColors[colorIndex]; exists {
+			return colorIndex, true
+		}
+	}
+
+	if colorIndex, found := extendedColors[[3]int{r, g, b}]; found {
+		if _, exists := s.customColors[colorIndex]; exists {
+			return colorIndex, true
+		}
+	}
+
+	switch s.quantization {
+	case QuantizeExact:
+		return -1, false
+
+	case QuantizeNearest:
+		return s.nearestCustomColor(r, g, b)
+	}
+
+	// Fallback: Find closest color by similarity
+	return s.findClosestColor(r, g, b)
+}
+
+// nearestCustomColor returns the index of the custom color that is the most
+// similar to the provided RGB values
+func (s *Scaffold) nearestCustomColor(r, g, b int) (int, bool) {
+	minDistance := int(^uint(0) >> 1) // max int
+	closestIndex := -1
+
+	for index, customColor := range s.customColors {
+		cr, cg, cb, _ := customColor.RGBA()
+		dr := r - int(cr>>8)
+		dg := g - int(cg>>8)
+		db := b - int(cb>>8)
+		distance := dr*dr + dg*dg + db*db
+
+		if distance < minDistance || (distance == minDistance && index < closestIndex) {
+			minDistance = distance
+			closestIndex = index
+		}
+	}
+
+	return closestIndex, closestIndex >= 0
+}
+
+// findClosestColor finds the closest ANSI color index using color distance
+func (s *Scaffold) findClosestColor(r, g, b int) (int, bool) {
+	if s.customColors == nil {
+		return -1, false
+	}
+
+	minDistance := int(^uint(0) >> 1) // max int
+	closestIndex := -1
+
+	for index, rgb := range s.paletteMatcher().closest {
+		// Calculate Euclidean distance in RGB space
+		dr := r - rgb[0]
+		dg := g - rgb[1]
+		db := b - rgb[2]
+		distance := dr*dr + dg*dg + db*db
+
+		if distance < minDistance {
+			minDistance = distance
+			closestIndex = index
+		}
+	}
+
+	// Only use the closest color if it's reasonably close (distance < 10000)
+	// This prevents completely wrong color matches
+	if closestIndex >= 0 && minDistance < 10000 {
+		if _, exists := s.customColors[closestIndex]; exists {
+			return closestIndex, true
+		}
+	}
+
+	return -1, false
+}
+
+// foregroundColor returns the color that is used to render the character,
+// which is its background color in case it uses reverse video, and which is
+// blended into the background color in case it is dim
+func (s *Scaffold) foregroundColor(cr bunt.ColoredRune) color.Color {
+	var fg color.Color = s.defaultForegroundColor
+	if cr.Settings&0x20 != 0 {
+		fg = s.defaultBackgroundColor
+		if bg, ok := s.settingsColor(cr.Settings, true); ok {
+			fg = bg
+		}
+
+	} else if c, ok := s.settingsColor(cr.Settings, false); ok {
+		fg = c
+	}
+
+	if cr.Settings&0x80 != 0 {
+		bg, ok := s.backgroundColor(cr)
+		if !ok {
+			bg = s.defaultBackgroundColor
+		}
+
+		fg = blendColors(fg, bg, s.dimOpacity)
+	}
+
+	return fg
+}
+
+// backgroundColor returns the background color of the character, if it has
+// one, which is always the case for characters using reverse video
+func (s *Scaffold) backgroundColor(cr bunt.ColoredRune) (color.Color, bool) {
+	if cr.Settings&0x20 != 0 {
+		if fg, ok := s.settingsColor(cr.Settings, false); ok {
+			return fg, true
+		}
+
+		return s.defaultForegroundColor, true
+	}
+
+	return s.settingsColor(cr.Settings, true)
+}
+
+// settingsColor returns the foreground or background color of the settings,
+// if it has one
+func (s *Scaffold) settingsColor(settings uint64, background bool) (color.Color, bool) {
+	r, g, b, ok := vt.Color(settings, background)
+	if !ok {
+		return nil, false
+	}
+
+	if index, found := s.settingsIndex(settings, background); found {
+		return s.customColors[index], true
+	}
+
+	return color.NRGBA{R: r, G: g, B: b, A: 255}, true
+}
+
+// settingsIndex returns the index of the custom color that is used for the
+// foreground or background color of the settings, where colors of the content
+// keep the palette index they were set with
+func (s *Scaffold) settingsIndex(settings uint64, background bool) (int, bool) {
+	r, g, b, _ := vt.Color(settings, background)
+	index, _ := vt.PaletteIndex(settings, background)
+	return s.colorIndex([3]uint8{r, g, b}, index)
+}
+
+// colorIndex returns the index of the custom color that is used for the color
+// with the palette index it was set with, or -1 if it was not set by index
+func (s *Scaffold) colorIndex(rgb [3]uint8, index int) (int, bool) {
+	if _, exists := s.customColors[index]; exists && index >= 0 {
+		return index, true
+	}
+
+	return s.paletteIndex(int(rgb[0]), int(rgb[1]), int(rgb[2]))
+}
+
+// blendColors returns the color that results from drawing the foreground
+// color with the given opacity on top of the background color
+func blendColors(fg, bg color.Color, opacity float64) color.Color {
+	r1, g1, b1, _ := fg.RGBA()
+	r2, g2, b2, _ := bg.RGBA()
+
+	mix := func(f, b uint32) uint8 {
+		return uint8(math.Round((opacity*float64(f) + (1-opacity)*float64(b)) / 0x101)) // #nosec G115 -- at most 255
+	}
+
+	return color.NRGBA{R: mix(r1, r2), G: mix(g1, g2), B: mix(b1, b2), A: 255}
+}
+
+// GetFixedColumns returns the number of columns after which the content is
+// wrapped
+func (s *Scaffold) GetFixedColumns() int {
+	if s.columns != 0 {
+		return s.columns
+	}
+
+	if s.deterministic {
+		return deterministicColumns
+	}
+
+	columns, _ := term.GetTerminalSize()
+	return columns
+}
+
+// AddCommand adds a line with the command indicator and the command, or the
+// prompt template with the command if one is set
+func (s *Scaffold) AddCommand(args ...string) error {
+	return s.AddContent(strings.NewReader(s.CommandLine(args...) + "\n"))
+}
+
+// sprintf formats the text including its color annotations as terminal
+// output, where the colors are rendered depending on the current terminal
+// by default, but always as true colors in deterministic mode
+func (s *Scaffold) sprintf(format string, a ...any) string {
+	if !s.deterministic {
+		return bunt.Sprintf(format, a...)
+	}
+
+	text, err := bunt.ParseString(fmt.Sprintf(format, a...), bunt.ProcessTextAnnotations())
+	if err != nil {
+		return bunt.Sprintf(format, a...)
+	}
+
+	var buf strings.Builder
+	var current uint64
+	for _, cr := range *text {
+		if cr.Settings != current {
+			buf.WriteString(renderSettings(cr.Settings))
+			current = cr.Settings
+		}
+
+		buf.WriteRune(cr.Symbol)
+	}
+
+	if current != 0 {
+		buf.WriteString("\x1b[0m")
+	}
+
+	return buf.String()
+}
+
+// renderSettings returns the escape sequence that resets all attributes
+// and applies the attributes and true colors of the settings
+func renderSettings(settings uint64) string {
+	parameters := []string{"0"}
+	for i, mask := range []uint64{0x04, 0x80, 0x08, 0x10, 0x20, 0x40} {
+		if settings&mask != 0 {
+			parameters = append(parameters, []string{"1", "2", "3", "4", "7", "9"}[i])
+		}
+	}
+
+	if settings&0x01 != 0 {
+		parameters = append(parameters, fmt.Sprintf("38;2;%d;%d;%d", (settings>>8)&0xFF, (settings>>16)&0xFF, (settings>>24)&0xFF))
+	}
+
+	if settings&0x02 != 0 {
+		parameters = append(parameters, fmt.Sprintf("48;2;%d;%d;%d", (settings>>32)&0xFF, (settings>>40)&0xFF, (settings>>48)&0xFF))
+	}
+
+	return "\x1b[" + strings.Join(parameters, ";") + "m"
+}
+
+func (s *Scaffold) commandIndicator() string {
+	switch {
+	case s.indicator != nil:
+		return *s.indicator
+
+	case s.deterministic:
+		return defaultCommandIndicator
+	}
+
+	return commandIndicator
+}
+
+// AddFooter adds a dimmed annotation line below the current content
+func (s *Scaffold) AddFooter(text string) error {
+	var prefix string
+	if len(s.content) > 0 && s.content[len(s.content)-1].Symbol != '\n' {
+		prefix = "\n"
+	}
+
+	return s.AddContent(strings.NewReader(
+		prefix + s.sprintf("DimGray{%s}\n", text),
+	))
+}
+
+// AddExitCode adds a status line with the exit code of the command below the
+// current content, which starts with a green check mark for a successful
+// command and a red cross otherwise (using characters that the default font
+// has glyphs for)
+func (s *Scaffold) AddExitCode(code int) error {
+	var prefix string
+	if len(s.content) > 0 && s.content[len(s.content)-1].Symbol != '\n' {
+		prefix = "\n"
+	}
+
+	badge := "LimeGreen{*√*}"
+	if code != 0 {
+		badge = "Red{*×*}"
+	}
+
+	return s.AddContent(strings.NewReader(
+		prefix + s.sprintf(badge+" DimGray{exit code %d}\n", code),
+	))
+}
+
+// Input returns the terminal output of all added content as it was read,
+// except for redacted text, so that the content can be processed again
+func (s *Scaffold) Input() []byte {
+	return s.input
+}
+
+// AddContent adds the terminal output to the content, where the output is
+// processed like a terminal would do, so that only the text visible on the
+// screen remains, for example of progress bars that update the same line, and
+// lines that are longer than the number of columns are wrapped
+func (s *Scaffold) AddContent(in io.Reader) error {
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return fmt.Errorf("failed to read input stream: %w", err)
+	}
+
+	data = s.styleStderr(data)
+
+	// Redact the content on the unwrapped lines, so that matches cannot
+	// escape the redaction by being wrapped into the next line
+	if len(s.redactions) > 0 {
+		unwrapped := vt.New(0, s.screenRows)
+		unwrapped.SetTabWidth(s.tabWidth)
+		unwrapped.SetScrollback(s.scrollback)
+		if _, err := unwrapped.Write(data); err != nil {
+			return fmt.Errorf("failed to process input stream: %w", err)
+		}
+
+		data = []byte(vt.Render(s.redact(unwrapped.Content()), unwrapped.Table()))
+	}
+
+	// Copies of the scaffold keep their own input
+	s.input = append(s.input[:len(s.input):len(s.input)], data...)
+
+	// Lines are wrapped by the terminal, unless they are wrapped at
+	// whitespace afterwards, or not at all
+	columns := s.GetFixedColumns()
+	if s.wrap != WrapCharacter {
+		columns = 0
+	}
+
+	// The settings of all content refer to the same table
+	if s.table == nil {
+		s.table = &vt.Table{}
+	}
+
+	// The command may assume another width than the columns, therefore lines
+	// that are redrawn after a carriage return are redrawn from their start
+	screen := vt.New(columns, s.screenRows)
+	screen.SetTable(s.table)
+	screen.SetTabWidth(s.tabWidth)
+	screen.SetScrollback(s.scrollback)
+	screen.SetLogicalReturn(true)
+	stderrLines, err := writeContent(screen, data)
+	if err != nil {
+		return fmt.Errorf("failed to process input stream: %w", err)
+	}
+
+	content := screen.Content()
+	x, y := screen.Cursor()
+	if s.wrap == WrapWord {
+		content, x, y = wrapWords(content, s.table, s.GetFixedColumns(), x, y)
+		stderrLines = wrappedLines(content, stderrLines)
+	}
+
+	// The cursor position is relative to the content added before
+	var lines, start int
+	for i, cr := range s.content {
+		if cr.Symbol == '\n' {
+			lines, start = lines+1, i+1
+		}
+	}
+
+	s.cursorLine, s.cursorColumn = lines+y, x
+	if y == 0 {
+		s.cursorColumn += len(s.content) - start
+	}
+
+	for _, line := range stderrLines {
+		if n := len(s.stderrLines); n == 0 || s.stderrLines[n-1] != lines+line {
+			s.stderrLines = append(s.stderrLines, lines+line)
+		}
+	}
+
+	s.content = append(s.content, content...)
+
+	// The palette is detected again to consider the colors of the new content
+	if s.sourcePalette == "" {
+		s.palette = detectPalette(s.content)
+	}
+
+	return nil
+}
+
+// Lines returns the content as plain text lines, the same way they are
+// rendered in the screenshot, i.e. including line wrapping
+func (s *Scaffold) Lines() []string {
+	if len(s.content) == 0 {
+		return nil
+	}
+
+	var tmp strings.Builder
+	for _, cr := range s.content {
+		switch cr.Symbol {
+		case blurredRune:
+			tmp.WriteRune('█')
+
+		default:
+			tmp.WriteString(s.table.Grapheme(cr.Symbol))
+		}
+	}
+
+	return strings.Split(strings.TrimSuffix(tmp.String(), "\n"), "\n")
+}
+
+func (s *Scaffold) fontHeight() float64 {
+	return float64(s.regular.Metrics().Height >> 6)
+}
+
+func (s *Scaffold) measureContent(content bunt.String) (width float64, height float64) {
+	lines := measuredLines(content, s.table)
+
+	// temporary drawer for reference calucation
+	tmpDrawer := &imgfont.Drawer{Face: s.regular}
+
+	// width, either by using longest line, or by fixed column value
+	switch {
+	case s.columns == 0 || s.trimWhitespace || s.wrap == WrapNone: // unlimited or trimmed: max width of all lines
+		for _, line := range lines {
+			advance := tmpDrawer.MeasureString(line)
+			if lineWidth := float64(advance >> 6); lineWidth > width {
+				width = lineWidth
+			}
+		}
+
+	default: // fixed: max width based on column count
+		width = float64(tmpDrawer.MeasureString(strings.Repeat("a", s.GetFixedColumns())) >> 6)
+	}
+
+	// gutter, space for the line numbers left of the content
+	width += s.gutterWidth(len(lines))
+
+	// height, lines times font height and line spacing
+	height = float64(len(lines)) * s.fontHeight() * s.lineSpacing
+
+	return width, height
+}
+
+// measuredLines returns the lines of the content as they are measured, where
+// grapheme clusters and wide characters are replaced with spaces for the
+// number of cells they take, like in the layout of the glyphs
+func measuredLines(content bunt.String, table *vt.Table) []string {
+	tmp := make([]rune, 0, len(content))
+	for _, cr := range content {
+		if cells := table.Width(cr.Symbol); cells == 2 || utf8.RuneCountInString(table.Grapheme(cr.Symbol)) > 1 {
+			tmp = append(tmp, []rune(strings.Repeat(" ", cells))...)
+			continue
+		}
+
+		tmp = append(tmp, cr.Symbol)
+	}
+
+	return strings.Split(
+		strings.TrimSuffix(
+			string(tmp),
+			"\n",
+		),
+		"\n",
+	)
+}
+
+func (s *Scaffold) image(ctx context.Context) (image.Image, error) {
+	fr := s.frame()
+	dc := gg.NewContext(int(fr.width), int(fr.height))
+
+	if err := s.draw(ctx, dc, fr, image.Rect(0, 0, dc.Width(), dc.Height()), s.glyphs(fr), s.lineNumberGlyphs(fr)); err != nil {
+		return nil, err
+	}
+
+	return dc.Image(), nil
+}
+
+// draw draws the area of the canvas onto the drawing context, where the
+// glyphs and line numbers need to contain at least all that touch the area
+func (s *Scaffold) draw(ctx context.Context, dc *gg.Context, fr frame, area image.Rectangle, glyphs, numbers []glyph) error {
+	f := func(value float64) float64 { return s.factor * value }
+
+	canvas := image.Rect(0, 0, int(fr.width), int(fr.height))
+	if area.Min != (image.Point{}) {
+		dc.Translate(-float64(area.Min.X), -float64(area.Min.Y))
+	}
+
+	// Optional: Draw background behind the window
+	//
+	if s.background != nil {
+		if err := s.background(dc, float64(canvas.Dx()), float64(canvas.Dy())); err != nil {
+			return err
+		}
+	}
+
+	// Optional: Apply blurred rounded rectangle to mimic the window shadow
+	//
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if s.drawShadow {
+		// The shadow below an opaque window does not need to be blurred
+		var covered image.Rectangle
+		if _, _, _, a := s.windowColor().RGBA(); a == 0xFFFF {
+			covered = fr.window.bounds().Inset(int(math.Ceil(fr.corner)) + 1)
+		}
+
+		if err := drawBlurred(dc, canvas, area, fr.shadow.bounds(), covered, uint32(s.shadowRadius), func(bc *gg.Context, visible image.Rectangle) {
+			drawRoundedRectangle(bc, fr.shadow, fr.corner, visible)
+			bc.SetHexColor(s.shadowBaseColor)
+			bc.Fill()
+		}); err != nil {
+			return err
+		}
+	}
+
+	// Draw rounded rectangle with outline to produce impression of a window
+	//
+	drawRoundedRectangle(dc, fr.window, fr.corner, area)
+	dc.SetColor(s.windowColor())
+	dc.Fill()
+
+	if s.drawBorder {
+		for _, line := range s.borderLines(fr) {
+			drawRoundedRectangle(dc, line.area, line.radius, area)
+			dc.SetColor(line.color)
+			dc.SetLineWidth(s.borderWidth)
+			dc.Stroke()
+		}
+	}
+
+	// Optional: Draw window decorations (i.e. three buttons) to produce the
+	// impression of an actional window
+	//
+	if s.drawDecorations {
+		for i, color := range []string{red, yellow, green} {
+			dc.DrawCircle(fr.buttonX+float64(i)*fr.distance, fr.buttonY, fr.radius)
+			dc.SetHexColor(color)
+			dc.Fill()
+		}
+	}
+
+	// Optional: Draw window title centered in the title bar, or next to the
+	// window decorations in case it is too long, shortened if needed
+	//
+	if s.title != "" {
+		dc.SetFontFace(fr.regular)
+		dc.SetColor(s.defaultForegroundColor)
+
+		title, x, anchor := s.titlePlacement(fr)
+		dc.DrawStringAnchored(title, x, fr.buttonY, anchor, 0.35)
+	}
+
+	// Optional: Draw the timestamp in the right corner of the title bar
+	//
+	if s.timestamp != "" {
+		dc.SetFontFace(fr.regular)
+		dc.SetColor(lineNumberColor)
+		dc.DrawStringAnchored(s.timestamp, fr.window.X+fr.window.Width-s.paddingRight, fr.buttonY, 1, 0.35)
+	}
+
+	// Optional: Highlight selected lines using the full width of the window
+	//
+	for _, line := range s.highlightedLines() {
+		area := s.highlightArea(fr, line)
+		dc.DrawRectangle(area.X, area.Y, area.Width, area.Height)
+		dc.SetColor(s.highlights[line])
+		dc.Fill()
+	}
+
+	// Optional: Mark the lines with output of the standard error
+	//
+	for _, line := range s.markedLines() {
+		area := s.markerArea(fr, line)
+		dc.DrawRectangle(area.X, area.Y, area.Width, area.Height)
+		dc.SetColor(stderrMarkerColor)
+		dc.Fill()
+	}
+
+	// Optional: Draw line numbers into the gutter
+	//
+	for _, g := range numbers {
+		dc.SetFontFace(g.face)
+		dc.SetColor(lineNumberColor)
+		dc.DrawString(g.text, g.x, g.y)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// Optional: Draw blurred bars for redacted characters
+	//
+	radius := uint32(f(3))
+	for _, areas := range groupBlurredAreas(s.blurredAreas(glyphs), int(radius)+1) {
+		var bounds image.Rectangle
+		for _, area := range areas {
+			bounds = bounds.Union(area.bounds())
+		}
+
+		if err := drawBlurred(dc, canvas, area, bounds, image.Rectangle{}, radius, func(bc *gg.Context, _ image.Rectangle) {
+			for _, area := range areas {
+				bc.DrawRoundedRectangle(area.X, area.Y, area.Width, area.Height, f(2))
+				bc.SetColor(area.color)
+				bc.Fill()
+			}
+		}); err != nil {
+			return err
+		}
+	}
+
+	// Apply the actual text into the prepared content area of the window
+	//
+	for i, g := range glyphs {
+		// Check for cancellation regularly, without slowing down small renders
+		if i%1024 == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+
+		// background color, which fills the whole cell, so that there are no
+		// gaps between the backgrounds of adjacent characters and lines
+		if bg, ok := s.backgroundColor(g.cr); ok {
+			cell := s.cell(g)
+			dc.SetColor(bg)
+			dc.DrawRectangle(cell.X, cell.Y, cell.Width, cell.Height)
+			dc.Fill()
+		}
+
+		// Blinking text is not drawn in the frames where it is hidden
+		if g.text == "" || s.blinkHidden && vt.Blink(g.cr.Settings) {
+			continue
+		}
+
+		// foreground color
+		fg := s.foregroundColor(g.cr)
+		s.drawText(dc, g, fg)
+
+		// There seems to be no font face based way to do an underlined
+		// string, therefore manually draw a line under each character
+		if g.cr.Settings&0x10 != 0 {
+			s.drawUnderline(dc, g, fg)
+		}
+
+		// Same for struck-through text, where the line goes through the
+		// middle of the lowercase letters
+		if g.cr.Settings&0x40 != 0 {
+			y := g.y - strikethroughOffset(g.face)
+			dc.SetColor(fg)
+			dc.DrawLine(g.x, y, g.x+g.width, y)
+			dc.SetLineWidth(f(1))
+			dc.Stroke()
+		}
+	}
+
+	// Optional: Draw the cursor on top of the content
+	//
+	if s.cursorStyle != CursorNone {
+		s.drawCursor(dc, fr, glyphs)
+	}
+
+	// Optional: Fade out the content towards the lines that are left out
+	//
+	if fr.faded {
+		s.drawFade(dc, fr)
+	}
+
+	// Optional: Draw custom decorations on top of the window
+	//
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if len(s.decorations) > 0 {
+		if err := s.drawDecorationsOn(dc, fr); err != nil {
+			return err
+		}
+	}
+
+	// Optional: Stamp the watermark on top of everything
+	//
+	if s.watermark != nil {
+		layer, err := s.watermarkLayer(fr)
+		if err != nil {
+			return err
+		}
+
+		dc.DrawImage(layer, 0, 0)
+	}
+
+	return nil
+}
+
+// strikethroughOffset returns the distance of the strikethrough line above
+// the baseline, which is half the x-height of the font face
+func strikethroughOffset(face imgfont.Face) float64 {
+	metrics := face.Metrics()
+	if metrics.XHeight > 0 {
+		return float64(metrics.XHeight) / 64 / 2
+	}
+
+	return float64(metrics.Ascent) / 64 / 3
+}
+
+// Write writes the scaffold content as PNG into the provided writer
+//
+// Deprecated: Use [Scaffold.WritePNG] instead.
+func (s *Scaffold) Write(w io.Writer) error {
+	return s.WritePNG(w)
+}
+
+// Image renders the scaffold content into an image
+func (s *Scaffold) Image() (image.Image, error) {
+	return s.ImageContext(context.Background())
+}
+
+// ImageContext renders the scaffold content into an image like [Scaffold.Image],
+// but stops with the error of the context once the context is done, which is
+// checked between the expensive steps of the rendering
+func (s *Scaffold) ImageContext(ctx context.Context) (image.Image, error) {
+	if s.cardWidth > 0 {
+		return s.cardImage(ctx)
+	}
+
+	img, err := s.image(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Optional: Clip image to the window and its shadow, the area is taken
+	// from the layout, so that the blurred edge of the shadow is not cut off
+	//
+	if s.clipCanvas && s.background == nil {
+		if imgRGBA, ok := img.(*image.RGBA); ok {
+			bounds := s.visibleArea(s.frame()).bounds()
+
+			// Custom decorations can draw anywhere on the canvas, therefore
+			// the pixels are checked to not cut them off
+			if len(s.decorations) > 0 {
+				bounds = bounds.Union(opaqueBounds(imgRGBA))
+			}
+
+			img = imgRGBA.SubImage(bounds)
+		}
+	}
+
+	return img, nil
+}
+
+// opaqueBounds returns the area of the image without the surrounding
+// transparent pixels, the rows are checked from the top and the bottom and
+// the columns from the left and the right until the first visible pixel,
+// which only needs the alpha values, since the pixels are premultiplied
+func opaqueBounds(img *image.RGBA) image.Rectangle {
+	bounds := img.Bounds()
+	visible := func(x, y int) bool { return img.Pix[img.PixOffset(x, y)+3] != 0 }
+
+	row := func(y int) bool {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if visible(x, y) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	minY, maxY := bounds.Min.Y, bounds.Max.Y
+	for minY < maxY && !row(minY) {
+		minY++
+	}
+
+	for maxY > minY && !row(maxY-1) {
+		maxY--
+	}
+
+	column := func(x int) bool {
+		for y := minY; y < maxY; y++ {
+			if visible(x, y) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	minX, maxX := bounds.Min.X, bounds.Max.X
+	for minX < maxX && !column(minX) {
+		minX++
+	}
+
+	for maxX > minX && !column(maxX-1) {
+		maxX--
+	}
+
+	return image.Rect(minX, minY, maxX, maxY)
+}
+
+// WritePNG writes the scaffold content as PNG into the provided writer, with
+// the metadata entries embedded as compressed text chunks and the resolution
+// in case it was set using [Scaffold.SetDPI]
+//
+// Very large images are rendered and encoded in bands like using
+// [Scaffold.WritePNGBanded], so that they do not have to be kept in memory
+func (s *Scaffold) WritePNG(w io.Writer) error {
+	return s.WritePNGContext(context.Background(), w)
+}
+
+// WritePNGContext writes the scaffold content as PNG like [Scaffold.WritePNG],
+// but stops with the error of the context once the context is done
+func (s *Scaffold) WritePNGContext(ctx context.Context, w io.Writer) error {
+	chunks, err := s.pngChunks()
+	if err != nil {
+		return err
+	}
+
+	// Very large images are rendered and encoded in bands to bound the memory
+	if fr := s.frame(); s.bandable() && fr.width*fr.height > bandedPixels {
+		return s.writePNGBanded(ctx, w, fr, defaultBandHeight, chunks)
+	}
+
+	return s.writePNG(ctx, w, chunks)
+}
+
+// pngChunks returns the additional chunks to embed into PNG images
+func (s *Scaffold) pngChunks() ([]pngChunk, error) {
+	chunks, err := metadataChunks(s.metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.dpi > 0 {
+		chunks = append([]pngChunk{resolutionChunk(s.dpi)}, chunks...)
+	}
+
+	return chunks, nil
+}
+
+func (s *Scaffold) writePNG(ctx context.Context, w io.Writer, chunks []pngChunk) error {
+	img, err := s.ImageContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if len(chunks) == 0 {
+		return png.Encode(w, img)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return err
+	}
+
+	data, err := insertChunks(buf.Bytes(), chunks)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(data)
+	return err
+}
+
+// WriteText writes the scaffold content as plain text without any escape
+// sequences or trailing whitespace into the provided writer
+func (s *Scaffold) WriteText(w io.Writer) error {
+	for _, line := range s.Lines() {
+		if _, err := fmt.Fprintln(w, strings.TrimRightFunc(line, unicode.IsSpace)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteRaw writes the scaffold content as-is into the provided writer
+func (s *Scaffold) WriteRaw(w io.Writer) error {
+	_, err := w.Write([]byte(vt.Render(s.content, s.table)))
+	return err
+}
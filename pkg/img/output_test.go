@@ -0,0 +1,1841 @@
+// Copyright © 2020 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package img_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/xml"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/fogleman/gg"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/webp"
+
+	. "github.com/gonvenience/bunt"
+	. "github.com/homeport/termshot/pkg/img"
+)
+
+var _ = Describe("Creating images", func() {
+	Context("Use scaffold to create PNG file", func() {
+		BeforeEach(func() {
+			SetColorSettings(ON, ON)
+		})
+
+		It("should write a PNG stream based on provided input", func() {
+			scaffold := NewImageCreator()
+			Expect(scaffold.AddContent(strings.NewReader("foobar"))).ToNot(HaveOccurred())
+			Expect(scaffold).To(LookLike(testdata("expected-foobar.png")))
+		})
+
+		It("should omit the window decorations when configured", func() {
+			scaffold := NewImageCreator()
+			scaffold.DrawDecorations(false)
+
+			Expect(scaffold.AddContent(strings.NewReader("foobar"))).ToNot(HaveOccurred())
+			Expect(scaffold).To(LookLike(testdata("expected-no-decoration.png")))
+		})
+
+		It("should omit the window shadow when configured", func() {
+			scaffold := NewImageCreator()
+			scaffold.DrawShadow(false)
+
+			Expect(scaffold.AddContent(strings.NewReader("foobar"))).ToNot(HaveOccurred())
+			Expect(scaffold).To(LookLike(testdata("expected-no-shadow.png")))
+		})
+
+		It("should clip the canvas when configured", func() {
+			scaffold := NewImageCreator()
+			scaffold.ClipCanvas(true)
+
+			Expect(scaffold.AddContent(strings.NewReader("foobar"))).ToNot(HaveOccurred())
+			Expect(scaffold).To(LookLike(testdata("expected-clip-canvas.png")))
+		})
+
+		It("should wrap the content when configured", func() {
+			scaffold := NewImageCreator()
+			scaffold.SetColumns(4)
+
+			Expect(scaffold.AddContent(strings.NewReader("foobar"))).ToNot(HaveOccurred())
+			Expect(scaffold).To(LookLike(testdata("expected-wrapping.png")))
+		})
+
+		It("should ignore trailing whitespace and common indentation when configured", func() {
+			trimmed := NewImageCreator()
+			trimmed.SetColumns(80)
+			trimmed.TrimWhitespace(true)
+			Expect(trimmed.AddContent(strings.NewReader("    foo    \n      bar\n\n"))).To(Succeed())
+
+			expected := NewImageCreator()
+			Expect(expected.AddContent(strings.NewReader("foo\n  bar\n\n"))).To(Succeed())
+
+			actual, err := trimmed.Image()
+			Expect(err).ToNot(HaveOccurred())
+
+			reference, err := expected.Image()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(actual).To(Equal(reference))
+		})
+
+		It("should leave out blank lines at the start and end when configured", func() {
+			trimmed := NewImageCreator()
+			trimmed.TrimBlankLines(true)
+			Expect(trimmed.AddContent(strings.NewReader("\n   \nfoo\n\nbar\n\n\n"))).To(Succeed())
+
+			expected := NewImageCreator()
+			Expect(expected.AddContent(strings.NewReader("foo\n\nbar"))).To(Succeed())
+
+			actual, err := trimmed.Image()
+			Expect(err).ToNot(HaveOccurred())
+
+			reference, err := expected.Image()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(actual).To(Equal(reference))
+		})
+
+		It("should only show the lines in the line range when configured", func() {
+			ranged := NewImageCreator()
+			ranged.SetLineRange(2, 3)
+			Expect(ranged.AddContent(strings.NewReader("foo\nbar\nbaz\nqux\n"))).To(Succeed())
+
+			expected := NewImageCreator()
+			Expect(expected.AddContent(strings.NewReader("bar\nbaz"))).To(Succeed())
+
+			actual, err := ranged.Image()
+			Expect(err).ToNot(HaveOccurred())
+
+			reference, err := expected.Image()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(actual).To(Equal(reference))
+		})
+
+		It("should add a gutter with line numbers to the width when configured", func() {
+			width := func(lineNumbers bool, lines int) int {
+				scaffold := NewImageCreator()
+				scaffold.ShowLineNumbers(lineNumbers)
+				Expect(scaffold.AddContent(strings.NewReader(strings.Repeat("the quick brown fox jumps\n", lines)))).To(Succeed())
+
+				image, err := scaffold.Image()
+				Expect(err).ToNot(HaveOccurred())
+				return image.Bounds().Dx()
+			}
+
+			Expect(width(true, 9)).To(BeNumerically(">", width(false, 9)))
+			Expect(width(true, 10)).To(BeNumerically(">", width(true, 9)))
+			Expect(width(false, 10)).To(Equal(width(false, 9)))
+		})
+
+		It("should scale all sizes when configured", func() {
+			size := func(scale float64) image.Point {
+				scaffold := NewImageCreator()
+				Expect(scaffold.SetScale(scale)).To(Succeed())
+				scaffold.SetPadding(10, 10, 10, 10)
+				Expect(scaffold.AddContent(strings.NewReader("the quick brown fox jumps\n"))).To(Succeed())
+
+				image, err := scaffold.Image()
+				Expect(err).ToNot(HaveOccurred())
+				return image.Bounds().Size()
+			}
+
+			Expect(size(4).X).To(BeNumerically("~", 4*size(1).X, 8))
+			Expect(size(4).Y).To(BeNumerically("~", 4*size(1).Y, 8))
+
+			scaffold := NewImageCreator()
+			Expect(scaffold.SetScale(0)).ToNot(Succeed())
+		})
+
+		It("should use the configured font size for custom fonts loaded afterwards", func() {
+			width := func(size float64) int {
+				scaffold := NewImageCreator()
+				if size > 0 {
+					Expect(scaffold.SetFontSize(size)).To(Succeed())
+				}
+
+				Expect(scaffold.LoadCustomFonts([]string{testdata("Hack-Regular.ttf")})).To(Succeed())
+				Expect(scaffold.AddContent(strings.NewReader("the quick brown fox jumps"))).To(Succeed())
+
+				image, err := scaffold.Image()
+				Expect(err).ToNot(HaveOccurred())
+				return image.Bounds().Dx()
+			}
+
+			Expect(width(24)).To(BeNumerically(">", width(0)))
+			Expect(width(12)).To(Equal(width(0)))
+		})
+
+		It("should store the resolution in PNG images when configured", func() {
+			var buf bytes.Buffer
+			scaffold := NewImageCreator()
+			Expect(scaffold.SetDPI(96)).To(Succeed())
+			Expect(scaffold.AddContent(strings.NewReader("foobar"))).To(Succeed())
+			Expect(scaffold.WritePNG(&buf)).To(Succeed())
+			Expect(buf.Bytes()).To(ContainSubstring("pHYs"))
+
+			img, err := png.Decode(&buf)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(img.Bounds().Dx()).To(BeNumerically("<", 300))
+		})
+
+		It("should create a scaffold with the provided options", func() {
+			scaffold, err := New(WithColumns(8), WithShadow(false), WithPadding(0, 0, 0, 0), WithMargin(0, 0, 0, 0))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(scaffold.GetFixedColumns()).To(Equal(8))
+
+			reference := NewImageCreator()
+			reference.SetColumns(8)
+			reference.DrawShadow(false)
+			reference.SetPadding(0, 0, 0, 0)
+			reference.SetMargin(0, 0, 0, 0)
+
+			for _, s := range []*Scaffold{&scaffold, &reference} {
+				Expect(s.AddContent(strings.NewReader("foobar baz qux"))).To(Succeed())
+			}
+
+			actual, err := scaffold.Image()
+			Expect(err).ToNot(HaveOccurred())
+			expected, err := reference.Image()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(actual).To(Equal(expected))
+
+			_, err = New(WithTheme("does-not-exist"))
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should render the same image when rendering again with cached glyphs", func() {
+			scaffold := NewImageCreator()
+			Expect(scaffold.AddContent(strings.NewReader(Sprintf("*foo* _bar_ ~baz~ Red{qux}\n")))).To(Succeed())
+
+			first, err := scaffold.Image()
+			Expect(err).ToNot(HaveOccurred())
+
+			second, err := scaffold.Image()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(second).To(Equal(first))
+		})
+
+		It("should render the same PNG image in bands", func() {
+			var content strings.Builder
+			for i := range 20 {
+				content.WriteString(Sprintf("%02d *foo* _bar_ Red{secret} Blue{baz}\n", i))
+			}
+
+			render := func(bandHeight int) image.Image {
+				scaffold := NewImageCreator()
+				Expect(scaffold.AddContent(strings.NewReader(content.String()))).To(Succeed())
+				scaffold.SetTitle("foobar")
+				scaffold.ShowLineNumbers(true)
+				scaffold.HighlightLine(3, color.RGBA{R: 64, A: 128})
+				scaffold.Redact(regexp.MustCompile(`secret`), RedactBlur)
+				scaffold.ClipCanvas(true)
+
+				var buf bytes.Buffer
+				if bandHeight == 0 {
+					Expect(scaffold.WritePNG(&buf)).To(Succeed())
+				} else {
+					Expect(scaffold.WritePNGBanded(&buf, bandHeight)).To(Succeed())
+				}
+
+				img, err := png.Decode(&buf)
+				Expect(err).ToNot(HaveOccurred())
+				return img
+			}
+
+			expected := render(0)
+			for _, bandHeight := range []int{16, 100} {
+				actual := render(bandHeight)
+				Expect(actual.Bounds()).To(Equal(expected.Bounds()))
+
+				var different int
+				bounds := expected.Bounds()
+				for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+					for x := bounds.Min.X; x < bounds.Max.X; x++ {
+						if color.NRGBAModel.Convert(actual.At(x, y)) != color.NRGBAModel.Convert(expected.At(x, y)) {
+							different++
+						}
+					}
+				}
+
+				Expect(different).To(BeZero())
+			}
+
+			scaffold := NewImageCreator()
+			Expect(scaffold.WritePNGBanded(io.Discard, 0)).To(MatchError("band height must be positive, got 0"))
+		})
+
+		It("should create the same image in deterministic mode regardless of the terminal", func() {
+			render := func() []byte {
+				scaffold, err := New(WithDeterministicOutput())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(scaffold.GetFixedColumns()).To(Equal(80))
+
+				Expect(scaffold.AddCommand("echo", "foobar")).To(Succeed())
+				Expect(scaffold.AddContent(strings.NewReader("foobar\n"))).To(Succeed())
+				Expect(scaffold.AddExitCode(0)).To(Succeed())
+				scaffold.HighlightLine(0, color.RGBA{R: 64, A: 128})
+				scaffold.HighlightLine(1, color.RGBA{B: 64, A: 128})
+
+				var buf bytes.Buffer
+				Expect(scaffold.WritePNG(&buf)).To(Succeed())
+				return buf.Bytes()
+			}
+
+			expected := render()
+
+			SetColorSettings(ON, OFF)
+			Expect(render()).To(Equal(expected))
+
+			SetColorSettings(OFF, OFF)
+			Expect(render()).To(Equal(expected))
+		})
+
+		It("should map colors back through the detected or configured source palette", func() {
+			paletteIndex := func(palette string) int {
+				scaffold := NewImageCreator()
+				Expect(scaffold.LoadTheme("nord")).To(Succeed())
+				Expect(scaffold.SetSourcePalette(palette)).To(Succeed())
+				Expect(scaffold.AddContent(strings.NewReader("\x1b[38;2;249;241;165mfoo\x1b[38;2;197;15;31mbar\x1b[0m"))).To(Succeed())
+
+				if palette == PaletteAuto {
+					Expect(scaffold.SourcePalette()).To(Equal("windows"))
+				}
+
+				return scaffold.ColorUsage()[0].PaletteIndex
+			}
+
+			Expect(paletteIndex(PaletteAuto)).To(Equal(11))
+			Expect(paletteIndex("windows")).To(Equal(11))
+			Expect(paletteIndex("xterm")).ToNot(Equal(11))
+
+			scaffold := NewImageCreator()
+			Expect(scaffold.SetSourcePalette("amiga")).To(MatchError(ContainSubstring(`unknown source palette "amiga"`)))
+		})
+
+		It("should stop rendering once the context is cancelled", func() {
+			scaffold := NewImageCreator()
+			Expect(scaffold.AddContent(strings.NewReader(strings.Repeat("the quick brown fox jumps\n", 100)))).To(Succeed())
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			_, err := scaffold.ImageContext(ctx)
+			Expect(err).To(MatchError(context.Canceled))
+
+			var buf bytes.Buffer
+			Expect(scaffold.WritePNGContext(ctx, &buf)).To(MatchError(context.Canceled))
+			Expect(buf.Len()).To(BeZero())
+
+			Expect(scaffold.WritePNGContext(context.Background(), &buf)).To(Succeed())
+		})
+
+		It("should apply OpenType features of custom fonts", func() {
+			render := func(features ...string) image.Image {
+				scaffold := NewImageCreator()
+				Expect(scaffold.LoadCustomFonts([]string{testdata("Hack-Regular.ttf")}, features...)).To(Succeed())
+				Expect(scaffold.AddContent(strings.NewReader("x2"))).To(Succeed())
+
+				img, err := scaffold.Image()
+				Expect(err).ToNot(HaveOccurred())
+				return img
+			}
+
+			Expect(render("sups")).ToNot(Equal(render("-sups")))
+			Expect(render("sups=0")).To(Equal(render("-sups")))
+		})
+
+		It("should keep the glyphs of fonts without ligatures when ligatures are enabled", func() {
+			render := func(ligatures bool, features ...string) image.Image {
+				scaffold := NewImageCreator()
+				scaffold.SetLigatures(ligatures)
+				Expect(scaffold.LoadCustomFonts([]string{testdata("Hack-Regular.ttf")}, features...)).To(Succeed())
+				Expect(scaffold.AddContent(strings.NewReader("a => b != c\n\x1b[1mfi <-- fl\x1b[0m"))).To(Succeed())
+
+				img, err := scaffold.Image()
+				Expect(err).ToNot(HaveOccurred())
+				return img
+			}
+
+			Expect(render(true)).To(Equal(render(false, "liga")))
+		})
+
+		It("should render glyphs without antialiasing when configured", func() {
+			scaffold := NewImageCreator()
+			scaffold.SetAntialiasing(false)
+			scaffold.DrawShadow(false)
+			scaffold.DrawDecorations(false)
+			scaffold.DrawBorder(false)
+			scaffold.SetMargin(0, 0, 0, 0)
+			scaffold.SetPadding(24, 24, 24, 24)
+			Expect(scaffold.AddContent(strings.NewReader("foobar"))).To(Succeed())
+
+			img, err := scaffold.Image()
+			Expect(err).ToNot(HaveOccurred())
+
+			background := color.RGBA{R: 0x15, G: 0x15, B: 0x15, A: 0xFF}
+			foreground := color.RGBAModel.Convert(LightGray)
+
+			bounds := img.Bounds()
+			for y := bounds.Min.Y + 48; y < bounds.Max.Y-48; y++ {
+				for x := bounds.Min.X + 48; x < bounds.Max.X-48; x++ {
+					Expect(color.RGBAModel.Convert(img.At(x, y))).To(BeElementOf(background, foreground))
+				}
+			}
+		})
+
+		It("should apply hinting when configured", func() {
+			render := func(hinting font.Hinting) image.Image {
+				scaffold := NewImageCreator()
+				Expect(scaffold.SetHinting(hinting)).To(Succeed())
+				Expect(scaffold.AddContent(strings.NewReader("foobar"))).To(Succeed())
+
+				img, err := scaffold.Image()
+				Expect(err).ToNot(HaveOccurred())
+				return img
+			}
+
+			Expect(render(font.HintingFull)).ToNot(Equal(render(font.HintingNone)))
+		})
+
+		It("should render characters the font has no glyph for using fallback fonts", func() {
+			fallback := filepath.Join(GinkgoT().TempDir(), "Go-Regular.ttf")
+			Expect(os.WriteFile(fallback, goregular.TTF, 0600)).To(Succeed())
+
+			render := func(content string, fallbacks ...string) image.Image {
+				scaffold := NewImageCreator()
+				Expect(scaffold.LoadFallbackFonts(fallbacks...)).To(Succeed())
+				Expect(scaffold.AddContent(strings.NewReader(content))).To(Succeed())
+
+				img, err := scaffold.Image()
+				Expect(err).ToNot(HaveOccurred())
+				return img
+			}
+
+			Expect(render("Ǎ", fallback)).ToNot(Equal(render("Ǎ")))
+			Expect(render("Äa", fallback)).To(Equal(render("Äa")))
+		})
+
+		It("should fail for invalid OpenType features", func() {
+			scaffold := NewImageCreator()
+			Expect(scaffold.LoadCustomFonts([]string{testdata("Hack-Regular.ttf")}, "stylistic")).To(MatchError(ContainSubstring("invalid font feature")))
+		})
+
+		It("should show only the last lines when the number of rows is fixed", func() {
+			render := func(rows int, content string) image.Image {
+				scaffold := NewImageCreator()
+				scaffold.SetRows(rows)
+				Expect(scaffold.AddContent(strings.NewReader(content))).To(Succeed())
+
+				img, err := scaffold.Image()
+				Expect(err).ToNot(HaveOccurred())
+				return img
+			}
+
+			Expect(render(2, "foo\nbar\nbaz\n")).To(Equal(render(0, "bar\nbaz\n")))
+			Expect(render(3, "foo")).To(Equal(render(0, "foo\n\n\n")))
+		})
+
+		It("should write frames as animated GIF", func() {
+			var frames []Frame
+			for _, content := range []string{"foo", "foo\nbar"} {
+				scaffold := NewImageCreator()
+				scaffold.SetRows(2)
+				Expect(scaffold.AddContent(strings.NewReader(content))).To(Succeed())
+
+				img, err := scaffold.Image()
+				Expect(err).ToNot(HaveOccurred())
+				frames = append(frames, Frame{Image: img, Delay: 250 * time.Millisecond})
+			}
+
+			var buf bytes.Buffer
+			Expect(WriteGIF(&buf, frames)).To(Succeed())
+
+			animation, err := gif.DecodeAll(&buf)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(animation.Image).To(HaveLen(2))
+			Expect(animation.Delay).To(Equal([]int{25, 25}))
+			Expect(animation.Image[0].Bounds()).To(Equal(frames[0].Image.Bounds()))
+		})
+
+		It("should write frames as animated WebP with only the changed area of each frame", func() {
+			var frames []Frame
+			for _, content := range []string{"foo", "foo\nbar"} {
+				scaffold := NewImageCreator()
+				scaffold.SetRows(2)
+				Expect(scaffold.AddContent(strings.NewReader(content))).To(Succeed())
+
+				img, err := scaffold.Image()
+				Expect(err).ToNot(HaveOccurred())
+				frames = append(frames, Frame{Image: img, Delay: 250 * time.Millisecond})
+			}
+
+			var buf bytes.Buffer
+			Expect(WriteAnimatedWebP(&buf, frames, WebPOptions{})).To(Succeed())
+
+			data := buf.Bytes()
+			Expect(string(data[0:4])).To(Equal("RIFF"))
+			Expect(int(binary.LittleEndian.Uint32(data[4:8]))).To(Equal(len(data) - 8))
+			Expect(string(data[8:16])).To(Equal("WEBPVP8X"))
+
+			var images []image.Image
+			var areas []image.Rectangle
+			for offset := 12; offset < len(data); {
+				id, size := string(data[offset:offset+4]), int(binary.LittleEndian.Uint32(data[offset+4:offset+8]))
+				chunk := data[offset+8 : offset+8+size]
+				offset += 8 + size + size%2
+
+				if id != "ANMF" {
+					continue
+				}
+
+				uint24 := func(b []byte) int { return int(b[0]) | int(b[1])<<8 | int(b[2])<<16 }
+				Expect(uint24(chunk[12:15])).To(Equal(250))
+
+				// Wrap the image data of the frame into a file of its own
+				var still bytes.Buffer
+				still.WriteString("RIFF")
+				Expect(binary.Write(&still, binary.LittleEndian, uint32(len(chunk)-16+4))).To(Succeed())
+				still.WriteString("WEBP")
+				still.Write(chunk[16:])
+
+				img, err := webp.Decode(&still)
+				Expect(err).ToNot(HaveOccurred())
+
+				x, y := 2*uint24(chunk[0:3]), 2*uint24(chunk[3:6])
+				images = append(images, img)
+				areas = append(areas, image.Rect(x, y, x+uint24(chunk[6:9])+1, y+uint24(chunk[9:12])+1))
+			}
+
+			Expect(images).To(HaveLen(2))
+			Expect(areas[0]).To(Equal(frames[0].Image.Bounds()))
+			Expect(areas[1].Dx() * areas[1].Dy()).To(BeNumerically("<", areas[0].Dx()*areas[0].Dy()/4))
+
+			for _, p := range []image.Point{{0, 0}, {areas[1].Dx() / 2, areas[1].Dy() / 2}} {
+				Expect(color.NRGBAModel.Convert(images[1].At(p.X, p.Y))).To(Equal(color.NRGBAModel.Convert(frames[1].Image.At(areas[1].Min.X+p.X, areas[1].Min.Y+p.Y))))
+			}
+		})
+
+		It("should write frames as animated PNG with only the changed area of each frame", func() {
+			var frames []Frame
+			for _, content := range []string{"foo", "foo\nbar"} {
+				scaffold := NewImageCreator()
+				scaffold.SetRows(2)
+				Expect(scaffold.AddContent(strings.NewReader(content))).To(Succeed())
+
+				img, err := scaffold.Image()
+				Expect(err).ToNot(HaveOccurred())
+				frames = append(frames, Frame{Image: img, Delay: 250 * time.Millisecond})
+			}
+
+			var buf bytes.Buffer
+			Expect(WriteAPNG(&buf, frames)).To(Succeed())
+
+			// Viewers without animation support show the first frame
+			first, err := png.Decode(bytes.NewReader(buf.Bytes()))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(first.Bounds()).To(Equal(frames[0].Image.Bounds()))
+			Expect(color.NRGBAModel.Convert(first.At(100, 100))).To(Equal(color.NRGBAModel.Convert(frames[0].Image.At(100, 100))))
+
+			var kinds []string
+			var control, data []byte
+			for encoded, offset := buf.Bytes(), 8; offset < len(encoded); {
+				length := int(binary.BigEndian.Uint32(encoded[offset:]))
+				kinds = append(kinds, string(encoded[offset+4:offset+8]))
+				chunk := encoded[offset+8 : offset+8+length]
+				offset += 12 + length
+
+				switch kinds[len(kinds)-1] {
+				case "fcTL":
+					control = chunk
+
+				case "fdAT":
+					Expect(binary.BigEndian.Uint32(chunk)).To(Equal(uint32(2)))
+					data = chunk[4:]
+				}
+			}
+
+			Expect(kinds).To(Equal([]string{"IHDR", "acTL", "fcTL", "IDAT", "fcTL", "fdAT", "IEND"}))
+			Expect(binary.BigEndian.Uint16(control[20:])).To(Equal(uint16(250)))
+			Expect(binary.BigEndian.Uint16(control[22:])).To(Equal(uint16(1000)))
+
+			// Wrap the image data of the second frame into an image of its own
+			area := image.Rect(0, 0, int(binary.BigEndian.Uint32(control[4:])), int(binary.BigEndian.Uint32(control[8:]))).
+				Add(image.Pt(int(binary.BigEndian.Uint32(control[12:])), int(binary.BigEndian.Uint32(control[16:]))))
+			Expect(area.Dx() * area.Dy()).To(BeNumerically("<", first.Bounds().Dx()*first.Bounds().Dy()/4))
+
+			var still bytes.Buffer
+			still.WriteString("\x89PNG\r\n\x1a\n")
+			for _, chunk := range []struct {
+				kind string
+				data []byte
+			}{{"IHDR", append(control[4:12:12], 8, 6, 0, 0, 0)}, {"IDAT", data}, {"IEND", nil}} {
+				Expect(binary.Write(&still, binary.BigEndian, uint32(len(chunk.data)))).To(Succeed())
+				still.WriteString(chunk.kind)
+				still.Write(chunk.data)
+				Expect(binary.Write(&still, binary.BigEndian, crc32.ChecksumIEEE(append([]byte(chunk.kind), chunk.data...)))).To(Succeed())
+			}
+
+			second, err := png.Decode(&still)
+			Expect(err).ToNot(HaveOccurred())
+			for _, p := range []image.Point{{0, 0}, {area.Dx() / 2, area.Dy() / 2}} {
+				Expect(color.NRGBAModel.Convert(second.At(p.X, p.Y))).To(Equal(color.NRGBAModel.Convert(frames[1].Image.At(area.Min.X+p.X, area.Min.Y+p.Y))))
+			}
+		})
+
+		It("should compose images into a grid with cells of the size of the largest image", func() {
+			small := image.NewRGBA(image.Rect(0, 0, 10, 5))
+			large := image.NewRGBA(image.Rect(5, 5, 25, 15))
+			draw.Draw(small, small.Bounds(), image.NewUniform(color.RGBA{R: 0xFF, A: 0xFF}), image.Point{}, draw.Src)
+			draw.Draw(large, large.Bounds(), image.NewUniform(color.RGBA{B: 0xFF, A: 0xFF}), image.Point{}, draw.Src)
+
+			strip, err := Filmstrip([]image.Image{small, large, small}, 2)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(strip.Bounds()).To(Equal(image.Rect(0, 0, 40, 20)))
+			Expect(strip.At(0, 0)).To(Equal(color.RGBA{R: 0xFF, A: 0xFF}))
+			Expect(strip.At(0, 9)).To(Equal(color.RGBA{}))
+			Expect(strip.At(20, 0)).To(Equal(color.RGBA{B: 0xFF, A: 0xFF}))
+			Expect(strip.At(39, 9)).To(Equal(color.RGBA{B: 0xFF, A: 0xFF}))
+			Expect(strip.At(9, 14)).To(Equal(color.RGBA{R: 0xFF, A: 0xFF}))
+			Expect(strip.At(20, 10)).To(Equal(color.RGBA{}))
+
+			_, err = Filmstrip([]image.Image{small}, 0)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should keep the blurred edge of the shadow when clipping the canvas", func() {
+			render := func(clip bool) image.Image {
+				scaffold := NewImageCreator()
+				scaffold.ClipCanvas(clip)
+				Expect(scaffold.AddContent(strings.NewReader("foobar"))).To(Succeed())
+
+				img, err := scaffold.Image()
+				Expect(err).ToNot(HaveOccurred())
+				return img
+			}
+
+			full, clipped := render(false), render(true)
+			Expect(clipped.Bounds().Dx()).To(BeNumerically("<", full.Bounds().Dx()))
+
+			bounds := full.Bounds()
+			for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+				for x := bounds.Min.X; x < bounds.Max.X; x++ {
+					if _, _, _, a := full.At(x, y).RGBA(); a > 0 {
+						Expect(image.Pt(x, y).In(clipped.Bounds())).To(BeTrue())
+					}
+				}
+			}
+		})
+
+		It("should keep custom decorations outside of the window when clipping the canvas", func() {
+			scaffold := NewImageCreator()
+			scaffold.ClipCanvas(true)
+			scaffold.AddDecoration(func(dc *gg.Context, _ Layout) error {
+				dc.DrawRectangle(0, 0, 4, 4)
+				dc.SetColor(color.White)
+				dc.Fill()
+				return nil
+			})
+
+			Expect(scaffold.AddContent(strings.NewReader("foobar"))).To(Succeed())
+
+			img, err := scaffold.Image()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(img.Bounds().Min).To(Equal(image.Pt(0, 0)))
+			Expect(img.At(0, 0)).To(Equal(color.RGBA{R: 255, G: 255, B: 255, A: 255}))
+		})
+
+		It("should measure styled text with the face of its style", func() {
+			render := func(content string) image.Rectangle {
+				parsed, err := opentype.Parse(goregular.TTF)
+				Expect(err).ToNot(HaveOccurred())
+
+				bold, err := opentype.NewFace(parsed, &opentype.FaceOptions{Size: 96, DPI: 72})
+				Expect(err).ToNot(HaveOccurred())
+
+				scaffold := NewImageCreator()
+				scaffold.SetFontFaceBold(bold)
+				Expect(scaffold.AddContent(strings.NewReader(content))).To(Succeed())
+
+				img, err := scaffold.Image()
+				Expect(err).ToNot(HaveOccurred())
+				return img.Bounds()
+			}
+
+			Expect(render("\x1b[1mfoobar foobar foobar\x1b[0m").Dx()).To(BeNumerically(">", render("foobar foobar foobar").Dx()))
+		})
+
+		It("should fill the background color of adjacent lines without gaps", func() {
+			scaffold := NewImageCreator()
+			scaffold.DrawShadow(false)
+			scaffold.DrawDecorations(false)
+			scaffold.SetMargin(0, 0, 0, 0)
+			Expect(scaffold.AddContent(strings.NewReader("\x1b[41m    \x1b[0m\n\x1b[41m    \x1b[0m\n\x1b[41m    \x1b[0m"))).To(Succeed())
+
+			img, err := scaffold.Image()
+			Expect(err).ToNot(HaveOccurred())
+
+			x := img.Bounds().Min.X + 50
+			var rows []int
+			for y := img.Bounds().Min.Y; y < img.Bounds().Max.Y; y++ {
+				if r, g, _, _ := img.At(x, y).RGBA(); r > 0x8000 && g < 0x8000 {
+					rows = append(rows, y)
+				}
+			}
+
+			Expect(rows).ToNot(BeEmpty())
+			Expect(rows[len(rows)-1] - rows[0] + 1).To(Equal(len(rows)))
+		})
+
+		It("should draw a background behind the window when configured", func() {
+			render := func(background Background) image.Image {
+				scaffold := NewImageCreator()
+				scaffold.ClipCanvas(true)
+				scaffold.SetBackground(background)
+				Expect(scaffold.AddContent(strings.NewReader("foobar"))).To(Succeed())
+
+				img, err := scaffold.Image()
+				Expect(err).ToNot(HaveOccurred())
+				return img
+			}
+
+			solid := render(SolidBackground(color.RGBA{R: 255, A: 255}))
+			Expect(solid.At(0, 0)).To(Equal(color.RGBA{R: 255, A: 255}))
+			Expect(solid.Bounds().Dx()).To(BeNumerically(">", render(nil).Bounds().Dx()))
+
+			gradient := render(LinearGradientBackground(90, color.Black, color.White))
+			bounds := gradient.Bounds()
+			left, _, _, _ := gradient.At(bounds.Min.X, bounds.Min.Y).RGBA()
+			right, _, _, _ := gradient.At(bounds.Max.X-1, bounds.Min.Y).RGBA()
+			Expect(left).To(BeNumerically("<", right))
+
+			backdrop := image.NewRGBA(image.Rect(0, 0, 4, 4))
+			backdrop.Set(0, 0, color.RGBA{B: 255, A: 255})
+			Expect(render(ImageBackground(backdrop)).At(0, 0)).ToNot(Equal(color.RGBA{}))
+		})
+
+		It("should draw the window background with the configured opacity", func() {
+			scaffold := NewImageCreator()
+			scaffold.DrawShadow(false)
+			scaffold.DrawDecorations(false)
+			scaffold.DrawBorder(false)
+			scaffold.SetMargin(0, 0, 0, 0)
+			scaffold.SetPadding(24, 24, 24, 24)
+			scaffold.SetForegroundColor(color.White)
+			Expect(scaffold.SetWindowOpacity(0)).To(Succeed())
+			Expect(scaffold.AddContent(strings.NewReader("█"))).To(Succeed())
+
+			img, err := scaffold.Image()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(img.At(4, 4)).To(Equal(color.RGBA{}))
+
+			var opaque bool
+			bounds := img.Bounds()
+			for y := bounds.Min.Y; y < bounds.Max.Y && !opaque; y++ {
+				for x := bounds.Min.X; x < bounds.Max.X && !opaque; x++ {
+					opaque = img.At(x, y) == color.RGBA{R: 255, G: 255, B: 255, A: 255}
+				}
+			}
+
+			Expect(opaque).To(BeTrue())
+
+			Expect(scaffold.SetWindowOpacity(1.5)).ToNot(Succeed())
+		})
+
+		It("should draw the border with the configured style", func() {
+			scaffold := NewImageCreator()
+			scaffold.DrawShadow(false)
+			scaffold.SetMargin(0, 0, 0, 0)
+			scaffold.SetCornerRadius(0)
+			Expect(scaffold.SetBorderStyle(BorderStyle{
+				Color:      color.RGBA{R: 255, A: 255},
+				InnerColor: color.RGBA{B: 255, A: 255},
+				Width:      2,
+			})).To(Succeed())
+			Expect(scaffold.AddContent(strings.NewReader("foobar"))).To(Succeed())
+
+			img, err := scaffold.Image()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(img.At(1, 50)).To(Equal(color.RGBA{R: 255, A: 255}))
+			Expect(img.At(4, 50)).To(Equal(color.RGBA{B: 255, A: 255}))
+
+			Expect(scaffold.SetBorderStyle(BorderStyle{Width: 0})).ToNot(Succeed())
+		})
+
+		It("should draw square window corners when configured", func() {
+			corner := func(radius float64) color.Color {
+				scaffold := NewImageCreator()
+				scaffold.DrawShadow(false)
+				scaffold.DrawBorder(false)
+				scaffold.SetMargin(0, 0, 0, 0)
+				scaffold.SetCornerRadius(radius)
+				Expect(scaffold.AddContent(strings.NewReader("foobar"))).To(Succeed())
+
+				img, err := scaffold.Image()
+				Expect(err).ToNot(HaveOccurred())
+				return img.At(0, 0)
+			}
+
+			Expect(corner(6)).To(Equal(color.RGBA{}))
+			Expect(corner(0)).To(Equal(color.RGBA{R: 0x15, G: 0x15, B: 0x15, A: 255}))
+		})
+
+		It("should grow the window to the minimum size and aspect ratio", func() {
+			render := func(configure func(*Scaffold)) image.Rectangle {
+				scaffold := NewImageCreator()
+				scaffold.ClipCanvas(true)
+				scaffold.DrawShadow(false)
+				scaffold.DrawBorder(false)
+				configure(&scaffold)
+				Expect(scaffold.AddContent(strings.NewReader("foobar"))).To(Succeed())
+
+				img, err := scaffold.Image()
+				Expect(err).ToNot(HaveOccurred())
+				return img.Bounds()
+			}
+
+			bounds := render(func(s *Scaffold) { Expect(s.SetMinSize(400, 300)).To(Succeed()) })
+			Expect(bounds.Dx()).To(BeNumerically("~", 800, 1))
+			Expect(bounds.Dy()).To(BeNumerically("~", 600, 1))
+
+			bounds = render(func(s *Scaffold) { Expect(s.SetAspectRatio(2)).To(Succeed()) })
+			Expect(float64(bounds.Dx()) / float64(bounds.Dy())).To(BeNumerically("~", 2, 0.01))
+
+			scaffold := NewImageCreator()
+			Expect(scaffold.SetMinSize(-1, 0)).ToNot(Succeed())
+			Expect(scaffold.SetAspectRatio(-1)).ToNot(Succeed())
+		})
+
+		It("should center the content within a larger window when configured", func() {
+			firstColumn := func(alignment Alignment) int {
+				scaffold := NewImageCreator()
+				scaffold.DrawShadow(false)
+				scaffold.DrawDecorations(false)
+				scaffold.SetMargin(0, 0, 0, 0)
+				scaffold.SetPadding(0, 0, 0, 0)
+				scaffold.SetForegroundColor(color.White)
+				scaffold.SetBackgroundColor(color.Black)
+				scaffold.SetAlignment(alignment, AlignStart)
+				Expect(scaffold.SetMinSize(400, 0)).To(Succeed())
+				Expect(scaffold.AddContent(strings.NewReader("█"))).To(Succeed())
+
+				img, err := scaffold.Image()
+				Expect(err).ToNot(HaveOccurred())
+
+				bounds := img.Bounds()
+				for x := bounds.Min.X + 4; x < bounds.Max.X; x++ {
+					if r, _, _, _ := img.At(x, bounds.Dy()/2).RGBA(); r > 0x8000 {
+						return x
+					}
+				}
+
+				return -1
+			}
+
+			Expect(firstColumn(AlignStart)).To(BeNumerically("<", 20))
+			Expect(firstColumn(AlignCenter)).To(BeNumerically("~", 400-8, 16))
+		})
+
+		It("should place the window in the center of a card of fixed size", func() {
+			render := func(content string) image.Image {
+				scaffold := NewImageCreator()
+				Expect(scaffold.SetCardSize(400, 200)).To(Succeed())
+				scaffold.SetBackground(SolidBackground(color.RGBA{R: 255, A: 255}))
+				Expect(scaffold.AddContent(strings.NewReader(content))).To(Succeed())
+
+				img, err := scaffold.Image()
+				Expect(err).ToNot(HaveOccurred())
+				return img
+			}
+
+			small := render("foobar")
+			Expect(small.Bounds()).To(Equal(image.Rect(0, 0, 400, 200)))
+			Expect(small.At(0, 0)).To(Equal(color.RGBA{R: 255, A: 255}))
+			Expect(small.At(200, 100)).ToNot(Equal(color.RGBA{R: 255, A: 255}))
+
+			large := render(strings.Repeat("a very long line of text\n", 40))
+			Expect(large.Bounds()).To(Equal(image.Rect(0, 0, 400, 200)))
+			Expect(large.At(200, 5)).To(Equal(color.RGBA{R: 255, A: 255}))
+
+			scaffold := NewImageCreator()
+			Expect(scaffold.SetCardSize(400, 0)).ToNot(Succeed())
+		})
+
+		It("should use the configured default foreground and background color", func() {
+			scaffold := NewImageCreator()
+			scaffold.DrawShadow(false)
+			scaffold.DrawDecorations(false)
+			scaffold.DrawBorder(false)
+			scaffold.SetMargin(0, 0, 0, 0)
+			scaffold.SetPadding(24, 24, 24, 24)
+			scaffold.SetForegroundColor(color.Black)
+			scaffold.SetBackgroundColor(color.White)
+			Expect(scaffold.AddContent(strings.NewReader("█"))).To(Succeed())
+
+			img, err := scaffold.Image()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(color.GrayModel.Convert(img.At(4, 4))).To(Equal(color.Gray{Y: 255}))
+
+			var dark bool
+			bounds := img.Bounds()
+			for y := bounds.Min.Y; y < bounds.Max.Y && !dark; y++ {
+				for x := bounds.Min.X; x < bounds.Max.X && !dark; x++ {
+					dark = color.GrayModel.Convert(img.At(x, y)) == color.Gray{Y: 0}
+				}
+			}
+
+			Expect(dark).To(BeTrue())
+		})
+
+		It("should draw box-drawing characters without gaps between them", func() {
+			render := func(content string) image.Image {
+				scaffold := NewImageCreator()
+				scaffold.DrawShadow(false)
+				scaffold.DrawDecorations(false)
+				scaffold.DrawBorder(false)
+				scaffold.SetMargin(0, 0, 0, 0)
+				scaffold.SetPadding(0, 0, 0, 0)
+				scaffold.SetForegroundColor(color.White)
+				Expect(scaffold.AddContent(strings.NewReader(content))).To(Succeed())
+
+				img, err := scaffold.Image()
+				Expect(err).ToNot(HaveOccurred())
+				return img
+			}
+
+			// lit returns whether the pixel is fully covered by the line,
+			// where the image is transposed for vertical lines
+			lit := func(img image.Image, transposed bool) func(a, b int) bool {
+				return func(a, b int) bool {
+					x, y := a, b
+					if transposed {
+						x, y = b, a
+					}
+
+					return color.GrayModel.Convert(img.At(x, y)).(color.Gray).Y == 0xFF
+				}
+			}
+
+			// Along the line, all pixels have to be lit without interruption
+			expectSeamless := func(length, across int, lit func(a, b int) bool) {
+				var first, last, count int
+				for b := 0; b < across; b++ {
+					var start, end, n int
+					for a := 0; a < length; a++ {
+						if lit(a, b) {
+							if n == 0 {
+								start = a
+							}
+
+							end = a
+							n++
+						}
+					}
+
+					if n > count {
+						first, last, count = start, end, n
+					}
+				}
+
+				Expect(count).To(BeNumerically(">", length/2))
+				Expect(last - first + 1).To(Equal(count))
+			}
+
+			horizontal := render("────")
+			expectSeamless(horizontal.Bounds().Dx(), horizontal.Bounds().Dy(), lit(horizontal, false))
+
+			vertical := render("│\n│\n│")
+			expectSeamless(vertical.Bounds().Dy(), vertical.Bounds().Dx(), lit(vertical, true))
+		})
+
+		It("should fit Powerline separators into the background of their cell", func() {
+			scaffold := NewImageCreator()
+			scaffold.DrawShadow(false)
+			scaffold.DrawDecorations(false)
+			Expect(scaffold.AddContent(strings.NewReader("\x1b[41m \x1b[31;49m\ue0b0\x1b[0m"))).To(Succeed())
+
+			img, err := scaffold.Image()
+			Expect(err).ToNot(HaveOccurred())
+
+			red := func(x, y int) bool {
+				r, g, _, _ := img.At(x, y).RGBA()
+				return r > 0x8000 && g < 0x4000
+			}
+
+			// extent returns the first and last row with red pixels in the
+			// columns
+			bounds := img.Bounds()
+			extent := func(x0, x1 int) (top, bottom int) {
+				top, bottom = bounds.Max.Y, bounds.Min.Y
+				for x := x0; x < x1; x++ {
+					for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+						if red(x, y) {
+							top, bottom = min(top, y), max(bottom, y)
+						}
+					}
+				}
+
+				return top, bottom
+			}
+
+			// The first red column is the background of the cell before the
+			// separator, which has to have the same height
+			first := bounds.Min.X
+			for first < bounds.Max.X {
+				if top, _ := extent(first, first+1); top < bounds.Max.Y {
+					break
+				}
+
+				first++
+			}
+
+			top, bottom := extent(first, first+1)
+			allTop, allBottom := extent(bounds.Min.X, bounds.Max.X)
+			Expect(top).To(BeNumerically("<", bottom))
+			Expect(allTop).To(Equal(top))
+			Expect(allBottom).To(Equal(bottom))
+		})
+
+		It("should lay out grapheme clusters and wide characters in cells", func() {
+			scaffold := NewImageCreator()
+			scaffold.SetColumns(4)
+			Expect(scaffold.AddContent(strings.NewReader("日本語\ne\u0301🇩🇪x"))).To(Succeed())
+			Expect(scaffold.Lines()).To(Equal([]string{"日本", "語", "e\u0301🇩🇪x"}))
+
+			width := func(content string) int {
+				scaffold := NewImageCreator()
+				scaffold.DrawShadow(false)
+				Expect(scaffold.AddContent(strings.NewReader(content))).To(Succeed())
+
+				img, err := scaffold.Image()
+				Expect(err).ToNot(HaveOccurred())
+				return img.Bounds().Dx()
+			}
+
+			Expect(width(strings.Repeat("日", 20))).To(Equal(width(strings.Repeat("a", 40))))
+			Expect(width(strings.Repeat("e\u0301", 40))).To(Equal(width(strings.Repeat("a", 40))))
+		})
+
+		It("should draw a line through struck-through text", func() {
+			render := func(input string) image.Image {
+				scaffold := NewImageCreator()
+				scaffold.DrawShadow(false)
+				scaffold.DrawDecorations(false)
+				scaffold.SetForegroundColor(color.White)
+				Expect(scaffold.AddContent(strings.NewReader(input))).To(Succeed())
+
+				img, err := scaffold.Image()
+				Expect(err).ToNot(HaveOccurred())
+				return img
+			}
+
+			lit := func(img image.Image) (count int) {
+				bounds := img.Bounds()
+				for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+					for x := bounds.Min.X; x < bounds.Max.X; x++ {
+						if color.GrayModel.Convert(img.At(x, y)).(color.Gray).Y > 200 {
+							count++
+						}
+					}
+				}
+
+				return count
+			}
+
+			plain := lit(render("oooo"))
+			Expect(lit(render("\x1b[9moooo\x1b[29m"))).To(BeNumerically(">", plain))
+			Expect(lit(render("\x1b[9m\x1b[29moooo"))).To(Equal(plain))
+		})
+
+		It("should show and hide blinking text in animation frames", func() {
+			scaffold := NewImageCreator()
+			Expect(scaffold.AddContent(strings.NewReader("foo"))).To(Succeed())
+			Expect(scaffold.HasBlinkingText()).To(BeFalse())
+
+			frames, err := scaffold.BlinkFrames()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(frames).To(HaveLen(1))
+
+			scaffold = NewImageCreator()
+			Expect(scaffold.AddContent(strings.NewReader("\x1b[5mfoo\x1b[25m"))).To(Succeed())
+			Expect(scaffold.HasBlinkingText()).To(BeTrue())
+
+			frames, err = scaffold.BlinkFrames()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(frames).To(HaveLen(2))
+			Expect(frames[0].Image).ToNot(Equal(frames[1].Image))
+
+			hidden := NewImageCreator()
+			Expect(hidden.AddContent(strings.NewReader(""))).To(Succeed())
+			Expect(hidden.Image()).To(Equal(frames[1].Image))
+
+			static, err := scaffold.Image()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(static).ToNot(Equal(frames[0].Image))
+
+			scaffold.SetBlinkStyle(BlinkNone)
+			Expect(scaffold.Image()).To(Equal(frames[0].Image))
+		})
+
+		It("should draw the cursor where it is after the content when configured", func() {
+			render := func(input string, style CursorStyle) image.Image {
+				scaffold := NewImageCreator()
+				scaffold.SetCursor(style)
+				Expect(scaffold.AddContent(strings.NewReader(input))).To(Succeed())
+
+				img, err := scaffold.Image()
+				Expect(err).ToNot(HaveOccurred())
+				return img
+			}
+
+			plain := render("foo bar", CursorNone)
+			Expect(render("foo bar", CursorBlock)).ToNot(Equal(plain))
+			Expect(render("foo bar", CursorBar)).ToNot(Equal(plain))
+
+			// The cursor on the empty line after the output is after the last character
+			Expect(render("foo bar\n", CursorBlock)).To(Equal(render("foo bar", CursorBlock)))
+			Expect(render("foo bar\x1b[3D", CursorBlock)).ToNot(Equal(render("foo bar", CursorBlock)))
+		})
+
+		It("should stamp a watermark on top of the window when configured", func() {
+			render := func(watermark *Watermark) image.Image {
+				scaffold := NewImageCreator()
+				scaffold.SetWatermark(watermark)
+				Expect(scaffold.AddContent(strings.NewReader("foobar"))).To(Succeed())
+
+				img, err := scaffold.Image()
+				Expect(err).ToNot(HaveOccurred())
+				return img
+			}
+
+			logo := image.NewRGBA(image.Rect(0, 0, 8, 8))
+			for x := 0; x < 8; x++ {
+				for y := 0; y < 8; y++ {
+					logo.Set(x, y, color.RGBA{R: 255, A: 255})
+				}
+			}
+
+			plain := render(nil)
+			opaque := render(&Watermark{Image: logo, Position: WatermarkTopLeft, Opacity: 1})
+			translucent := render(&Watermark{Image: logo, Position: WatermarkTopLeft, Opacity: 0.5})
+
+			Expect(opaque.Bounds()).To(Equal(plain.Bounds()))
+			Expect(opaque).ToNot(Equal(plain))
+			Expect(translucent).ToNot(Equal(opaque))
+			Expect(render(&Watermark{Text: "ACME", Opacity: 1})).ToNot(Equal(plain))
+
+			scaffold := NewImageCreator()
+			scaffold.SetWatermark(&Watermark{Text: "ACME", Position: "middle"})
+			_, err := scaffold.Image()
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should show the command when configured", func() {
+			scaffold := NewImageCreator()
+			Expect(scaffold.AddCommand("echo", "foobar")).To(Succeed())
+			Expect(scaffold.AddContent(strings.NewReader("foobar"))).To(Succeed())
+			Expect(scaffold).To(LookLike(testdata("expected-show-cmd.png")))
+		})
+
+		It("should write a PNG stream based on provided input with ANSI sequences", func() {
+			var buf bytes.Buffer
+			_, _ = Fprintf(&buf, "Text with emphasis, like *bold*, _italic_, _*bold/italic*_ or ~underline~.\n\n")
+			_, _ = Fprintf(&buf, "Colors:\n")
+			_, _ = Fprintf(&buf, "\tRed{Red}\n")
+			_, _ = Fprintf(&buf, "\tGreen{Green}\n")
+			_, _ = Fprintf(&buf, "\tBlue{Blue}\n")
+			_, _ = Fprintf(&buf, "\tMintCream{MintCream}\n")
+
+			scaffold := NewImageCreator()
+			Expect(scaffold.AddContent(&buf)).ToNot(HaveOccurred())
+			Expect(scaffold).To(LookLike(testdata("expected-ansi.png")))
+		})
+	})
+
+	Context("Use scaffold to inspect content", func() {
+		It("should return the content as plain text lines including line wrapping", func() {
+			scaffold := NewImageCreator()
+			scaffold.SetColumns(4)
+
+			Expect(scaffold.Lines()).To(BeEmpty())
+			Expect(scaffold.AddContent(strings.NewReader(Sprintf("MintCream{foobar}\nfoo\n")))).To(Succeed())
+			Expect(scaffold.Lines()).To(Equal([]string{"foob", "ar", "foo"}))
+		})
+
+		It("should only show the final state of lines redrawn after a carriage return", func() {
+			scaffold := NewImageCreator()
+			scaffold.SetColumns(10)
+
+			Expect(scaffold.AddContent(strings.NewReader("[====    ] 50%\r[========] 100%\ndone\n"))).To(Succeed())
+			Expect(scaffold.Lines()).To(Equal([]string{"[========]", " 100%", "done"}))
+		})
+
+		It("should only show the screen and the configured scrollback lines", func() {
+			scaffold := NewImageCreator()
+			Expect(scaffold.SetScrollback(3, 0)).To(Succeed())
+			Expect(scaffold.AddContent(strings.NewReader("1\n2\n3\n4\n5"))).To(Succeed())
+			Expect(scaffold.Lines()).To(Equal([]string{"3", "4", "5"}))
+
+			scaffold = NewImageCreator()
+			Expect(scaffold.SetScrollback(3, 1)).To(Succeed())
+			Expect(scaffold.AddContent(strings.NewReader("1\n2\n3\n4\n5"))).To(Succeed())
+			Expect(scaffold.Lines()).To(Equal([]string{"2", "3", "4", "5"}))
+
+			scaffold = NewImageCreator()
+			Expect(scaffold.SetScrollback(3, -1)).To(Succeed())
+			Expect(scaffold.AddContent(strings.NewReader("1\n2\n3\n4\n5"))).To(Succeed())
+			Expect(scaffold.Lines()).To(Equal([]string{"1", "2", "3", "4", "5"}))
+		})
+
+		It("should describe the content and all settings with the fingerprint", func() {
+			fingerprint := func(s Scaffold) string {
+				result, err := s.Fingerprint()
+				Expect(err).ToNot(HaveOccurred())
+				return string(result)
+			}
+
+			scaffold := NewImageCreator()
+			Expect(scaffold.AddContent(strings.NewReader("foo\nbar"))).To(Succeed())
+
+			other := NewImageCreator()
+			Expect(other.AddContent(strings.NewReader("foo\nbar"))).To(Succeed())
+			Expect(fingerprint(other)).To(Equal(fingerprint(scaffold)))
+
+			blinking := NewImageCreator()
+			Expect(blinking.AddContent(strings.NewReader("foo\n\x1b[5mbar"))).To(Succeed())
+			Expect(fingerprint(blinking)).ToNot(Equal(fingerprint(scaffold)))
+
+			cursor := NewImageCreator()
+			Expect(cursor.AddContent(strings.NewReader("foo\nbar\x1b[1;1H"))).To(Succeed())
+			Expect(fingerprint(cursor)).ToNot(Equal(fingerprint(scaffold)))
+
+			Expect(other.SetStderrStyle("marker")).To(Succeed())
+			Expect(fingerprint(other)).ToNot(Equal(fingerprint(scaffold)))
+
+			timestamped := NewImageCreator()
+			Expect(timestamped.AddContent(strings.NewReader("foo\nbar"))).To(Succeed())
+			timestamped.SetTimestamp("15:04:05")
+			Expect(fingerprint(timestamped)).ToNot(Equal(fingerprint(scaffold)))
+
+			scaffold.AddDecoration(func(_ *gg.Context, _ Layout) error { return nil })
+			_, err := scaffold.Fingerprint()
+			Expect(err).To(MatchError(ErrNoFingerprint))
+		})
+
+		It("should fail to use a screen without rows", func() {
+			scaffold := NewImageCreator()
+			Expect(scaffold.SetScrollback(0, -1)).ToNot(Succeed())
+		})
+
+		It("should wrap lines at whitespace or not at all if configured", func() {
+			scaffold := NewImageCreator()
+			scaffold.SetColumns(10)
+			scaffold.SetWrap(WrapWord)
+			Expect(scaffold.AddContent(strings.NewReader("the quick brown fox jumps\nsupercalifragilistic\n"))).To(Succeed())
+			Expect(scaffold.AddContent(strings.NewReader("over the lazy dog"))).To(Succeed())
+			Expect(scaffold.Lines()).To(Equal([]string{
+				"the quick",
+				"↪ brown",
+				"↪ fox",
+				"↪ jumps",
+				"supercalif",
+				"↪ ragilist",
+				"↪ ic",
+				"over the",
+				"↪ lazy dog",
+			}))
+
+			scaffold = NewImageCreator()
+			scaffold.SetColumns(10)
+			scaffold.SetWrap(WrapNone)
+			Expect(scaffold.AddContent(strings.NewReader("the quick brown fox jumps"))).To(Succeed())
+			Expect(scaffold.Lines()).To(Equal([]string{"the quick brown fox jumps"}))
+		})
+
+		It("should use the configured command indicator and its style", func() {
+			scaffold := NewImageCreator()
+			scaffold.SetCommandIndicator("$")
+			Expect(scaffold.SetCommandIndicatorStyle("bold,#ff8800")).To(Succeed())
+			Expect(scaffold.AddCommand("echo", "foobar")).To(Succeed())
+			Expect(scaffold.Lines()).To(Equal([]string{"$ echo foobar"}))
+			Expect(scaffold.ColorUsage()).To(ContainElement(HaveField("Color", color.RGBA{R: 255, G: 136, A: 255})))
+
+			Expect(scaffold.SetCommandIndicatorStyle("blinking")).To(MatchError(ContainSubstring("invalid command indicator style")))
+		})
+
+		It("should render the command line based on the prompt template", func() {
+			GinkgoT().Setenv("USER", "alice")
+
+			scaffold := NewImageCreator()
+			scaffold.SetCommandIndicator("❯")
+			Expect(scaffold.SetPromptTemplate("{user:bold,#00ff00} in {cwd} {indicator} {command}")).To(Succeed())
+			Expect(scaffold.AddCommand("echo", "foobar")).To(Succeed())
+			Expect(scaffold.Lines()).To(HaveLen(1))
+			Expect(scaffold.Lines()[0]).To(HavePrefix("alice in "))
+			Expect(scaffold.Lines()[0]).To(HaveSuffix(" ❯ echo foobar"))
+			Expect(scaffold.ColorUsage()).To(ContainElement(HaveField("Color", color.RGBA{G: 255, A: 255})))
+
+			Expect(scaffold.SetPromptTemplate("{path}")).To(MatchError(ContainSubstring("unknown placeholder")))
+			Expect(scaffold.SetPromptTemplate("{cwd:blinking}")).To(MatchError(ContainSubstring("unsupported style")))
+		})
+
+		It("should style and mark the output of the standard error", func() {
+			scaffold := NewImageCreator()
+			Expect(scaffold.SetStderrStyle("italic,#ff5555,marker")).To(Succeed())
+			Expect(scaffold.AddContent(strings.NewReader("out\n" + StderrStart + "err\x1b[0m!\n" + StderrEnd + "out\n"))).To(Succeed())
+			Expect(scaffold.Lines()).To(Equal([]string{"out", "err!", "out"}))
+			Expect(scaffold.ColorUsage()).To(ContainElement(And(HaveField("Color", color.RGBA{R: 255, G: 85, B: 85, A: 255}), HaveField("Count", 4))))
+
+			var buf bytes.Buffer
+			Expect(scaffold.WriteSVG(&buf)).To(Succeed())
+			Expect(buf.String()).To(ContainSubstring(`font-style="italic"`))
+			Expect(strings.Count(buf.String(), `fill="#F04747"`)).To(Equal(1))
+
+			Expect(scaffold.SetStderrStyle("blinking")).To(MatchError(ContainSubstring("invalid stderr style")))
+		})
+	})
+
+	Context("Use scaffold to redact content", func() {
+		It("should mask all matches of the patterns, even if wrapped", func() {
+			scaffold := NewImageCreator()
+			scaffold.SetColumns(10)
+			scaffold.Redact(regexp.MustCompile(`ghp_\w+`), RedactBlock)
+			scaffold.Redact(regexp.MustCompile(`\w+@example\.com`), RedactBlur)
+
+			Expect(scaffold.AddContent(strings.NewReader(Sprintf("token=Green{ghp_abcdef}\nfoo@example.com\n")))).To(Succeed())
+			Expect(scaffold.Lines()).To(Equal([]string{"token=████", "██████", "██████████", "█████"}))
+		})
+
+		It("should render blurred text without the original characters", func() {
+			render := func(content string) image.Image {
+				scaffold := NewImageCreator()
+				scaffold.Redact(regexp.MustCompile(`[0-9]+`), RedactBlur)
+				Expect(scaffold.AddContent(strings.NewReader(content))).To(Succeed())
+
+				img, err := scaffold.Image()
+				Expect(err).ToNot(HaveOccurred())
+				return img
+			}
+
+			Expect(render("pin 1234")).To(Equal(render("pin 9876")))
+		})
+
+		It("should keep the input without the redacted text", func() {
+			scaffold := NewImageCreator()
+			scaffold.Redact(regexp.MustCompile(`[0-9]+`), RedactBlock)
+			Expect(scaffold.AddContent(strings.NewReader("pin 1234\n"))).To(Succeed())
+			Expect(string(scaffold.Input())).ToNot(ContainSubstring("1234"))
+		})
+	})
+
+	Context("Use scaffold to keep the input", func() {
+		It("should return the added content as it was read", func() {
+			scaffold := NewImageCreator()
+			Expect(scaffold.AddCommand("ls")).To(Succeed())
+			Expect(scaffold.AddContent(strings.NewReader("\x1b[31mloading\x1b[0m\rdone   \n"))).To(Succeed())
+			Expect(string(scaffold.Input())).To(ContainSubstring("ls"))
+			Expect(string(scaffold.Input())).To(HaveSuffix("\n\x1b[31mloading\x1b[0m\rdone   \n"))
+
+			copied := scaffold
+			Expect(copied.AddContent(strings.NewReader("more\n"))).To(Succeed())
+			Expect(string(scaffold.Input())).ToNot(HaveSuffix("more\n"))
+		})
+	})
+
+	Context("Use scaffold to report color usage", func() {
+		It("should list all distinct colors with their counts", func() {
+			scaffold := NewImageCreator()
+			Expect(scaffold.AddContent(strings.NewReader("\x1b[38;2;255;0;0mfoo\x1b[0m bar\n\x1b[48;2;0;0;255mx\x1b[0m"))).To(Succeed())
+
+			usages := scaffold.ColorUsage()
+			Expect(usages).To(HaveLen(3))
+			Expect(usages[0].Color).To(BeNil())
+			Expect(usages[0].Count).To(Equal(5))
+			Expect(usages[1].Color).To(Equal(color.RGBA{R: 255, A: 255}))
+			Expect(usages[1].Count).To(Equal(3))
+			Expect(usages[1].PaletteIndex).To(Equal(-1))
+			Expect(usages[2].Background).To(BeTrue())
+			Expect(usages[2].Count).To(Equal(1))
+		})
+	})
+
+	Context("Use scaffold with a custom color scheme", func() {
+		var colorscheme = func(content string) string {
+			filename := filepath.Join(GinkgoT().TempDir(), "colorscheme.json")
+			Expect(os.WriteFile(filename, []byte(content), 0644)).To(Succeed())
+			return filename
+		}
+
+		var paletteIndices = func(scaffold *Scaffold, content string) []int {
+			Expect(scaffold.AddContent(strings.NewReader(content))).To(Succeed())
+
+			var indices []int
+			for _, usage := range scaffold.ColorUsage() {
+				if usage.Color != nil {
+					indices = append(indices, usage.PaletteIndex)
+				}
+			}
+
+			return indices
+		}
+
+		It("should remap colors of the 256 color palette", func() {
+			scaffold := NewImageCreator()
+			Expect(scaffold.LoadColorscheme(colorscheme(`{"colors": {"color1": "#ff0000", "color196": "#ff5555", "color244": "#808080"}}`))).To(Succeed())
+			Expect(paletteIndices(&scaffold, "\x1b[38;5;196mfoo\x1b[0m \x1b[38;5;244mbar\x1b[0m")).To(ConsistOf(196, 244))
+		})
+
+		It("should remap colors of the 256 color palette with their exact index", func() {
+			scaffold := NewImageCreator()
+			Expect(scaffold.LoadColorscheme(colorscheme(`{"colors": {"color0": "#000000", "color9": "#ff0000", "color16": "#101010", "color196": "#ff5555"}}`))).To(Succeed())
+			Expect(paletteIndices(&scaffold, "\x1b[38;5;196mfoo\x1b[0m \x1b[91mbar\x1b[0m \x1b[38;5;16mbaz\x1b[0m \x1b[38;5;0mqux\x1b[0m")).To(ConsistOf(196, 9, 16, 0))
+		})
+
+		It("should only remap exact palette colors when configured", func() {
+			scaffold := NewImageCreator()
+			Expect(scaffold.LoadColorscheme(colorscheme(`{"colors": {"color1": "#ff0000"}, "truecolor": "exact"}`))).To(Succeed())
+			Expect(paletteIndices(&scaffold, "\x1b[31mfoo\x1b[0m \x1b[38;2;220;50;40mbar\x1b[0m")).To(ConsistOf(1, -1))
+		})
+
+		It("should remap all colors to the nearest color scheme color when configured", func() {
+			scaffold := NewImageCreator()
+			Expect(scaffold.LoadColorscheme(colorscheme(`[{"colors": {"color1": "#ff0000", "color4": "#0000ff"}, "truecolor": "nearest"}]`))).To(Succeed())
+			Expect(paletteIndices(&scaffold, "\x1b[38;2;10;20;200mfoo\x1b[0m \x1b[38;2;150;0;60mbar\x1b[0m")).To(ConsistOf(4, 1))
+		})
+
+		It("should load iTerm2 color presets", func() {
+			filename := filepath.Join(GinkgoT().TempDir(), "scheme.itermcolors")
+			Expect(os.WriteFile(filename, []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>Ansi 1 Color</key>
+	<dict>
+		<key>Blue Component</key>
+		<real>0.0</real>
+		<key>Green Component</key>
+		<real>0.2</real>
+		<key>Red Component</key>
+		<real>1</real>
+	</dict>
+	<key>Background Color</key>
+	<dict>
+		<key>Alpha Component</key>
+		<real>1</real>
+		<key>Blue Component</key>
+		<real>0.5</real>
+		<key>Color Space</key>
+		<string>sRGB</string>
+		<key>Green Component</key>
+		<real>0.25</real>
+		<key>Red Component</key>
+		<real>0.125</real>
+	</dict>
+</dict>
+</plist>
+`), 0644)).To(Succeed())
+
+			scaffold := NewImageCreator()
+			Expect(scaffold.LoadColorscheme(filename)).To(Succeed())
+			Expect(scaffold.AddContent(strings.NewReader("\x1b[31mfoo\x1b[0m"))).To(Succeed())
+
+			Expect(scaffold.ColorUsage()).To(ContainElement(HaveField("Rendered", color.RGBA{R: 255, G: 51, A: 255})))
+
+			img, err := scaffold.Image()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(img.At(img.Bounds().Dx()/2, img.Bounds().Dy()/2)).To(Equal(color.RGBA{R: 32, G: 64, B: 128, A: 255}))
+		})
+
+		It("should load Alacritty, kitty, Windows Terminal, and Xresources color schemes", func() {
+			schemes := map[string]string{
+				"alacritty.toml": "[colors.primary]\nbackground = '0x204080'\n\n[colors.normal]\nred = \"#ff3300\" # red\n",
+				"alacritty.yml":  "colors:\n  primary:\n    background: '#204080'\n  normal:\n    red: '0xff3300'\n",
+				"theme.conf":     "# kitty theme\nbackground #204080\ncolor1     #ff3300\n",
+				"terminal.json":  `{"schemes": [{"name": "Test", "background": "#204080", "red": "#FF3300"}]}`,
+				".Xresources":    "! urxvt colors\n#define red #ff3300\n*background: rgb:20/40/80\nURxvt*color1: red\n",
+			}
+
+			for name, content := range schemes {
+				filename := filepath.Join(GinkgoT().TempDir(), name)
+				Expect(os.WriteFile(filename, []byte(content), 0644)).To(Succeed())
+
+				scaffold := NewImageCreator()
+				Expect(scaffold.LoadColorscheme(filename)).To(Succeed(), name)
+				Expect(scaffold.AddContent(strings.NewReader("\x1b[31mfoo\x1b[0m"))).To(Succeed())
+				Expect(scaffold.ColorUsage()).To(ContainElement(HaveField("Rendered", color.RGBA{R: 255, G: 51, A: 255})), name)
+
+				img, err := scaffold.Image()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(img.At(img.Bounds().Dx()/2, img.Bounds().Dy()/2)).To(Equal(color.RGBA{R: 32, G: 64, B: 128, A: 255}), name)
+			}
+
+			filename := filepath.Join(GinkgoT().TempDir(), "theme")
+			Expect(os.WriteFile(filename, []byte(schemes["theme.conf"]), 0644)).To(Succeed())
+
+			scaffold := NewImageCreator()
+			Expect(scaffold.LoadColorschemeFormat(filename, ColorschemeKitty)).To(Succeed())
+			Expect(scaffold.LoadColorschemeFormat(filename, "foobar")).To(MatchError(ContainSubstring("unsupported colorscheme format")))
+		})
+
+		It("should use the palette configured in code", func() {
+			scaffold := NewImageCreator()
+			scaffold.SetPalette([16]color.Color{1: color.RGBA{R: 255, G: 51, A: 255}})
+			scaffold.SetBackgroundColor(color.RGBA{R: 32, G: 64, B: 128, A: 255})
+			Expect(scaffold.AddContent(strings.NewReader("\x1b[31mfoo\x1b[0m \x1b[32mbar\x1b[0m"))).To(Succeed())
+
+			Expect(scaffold.ColorUsage()).To(ContainElement(And(HaveField("PaletteIndex", 1), HaveField("Rendered", color.RGBA{R: 255, G: 51, A: 255}))))
+			Expect(scaffold.ColorUsage()).ToNot(ContainElement(HaveField("PaletteIndex", 2)))
+
+			img, err := scaffold.Image()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(img.At(img.Bounds().Dx()/2, img.Bounds().Dy()/2)).To(Equal(color.RGBA{R: 32, G: 64, B: 128, A: 255}))
+		})
+
+		It("should load all built-in themes", func() {
+			Expect(Themes()).To(ContainElements("dracula", "nord", "solarized-dark", "solarized-light", "gruvbox", "catppuccin", "one-dark"))
+			for _, name := range Themes() {
+				scaffold := NewImageCreator()
+				Expect(scaffold.LoadTheme(name)).To(Succeed())
+				Expect(paletteIndices(&scaffold, "\x1b[31mfoo\x1b[0m")).To(ConsistOf(1))
+			}
+
+			scaffold := NewImageCreator()
+			Expect(scaffold.LoadTheme("foobar")).To(MatchError(ContainSubstring("unknown theme")))
+		})
+
+		It("should fail for an unknown truecolor setting", func() {
+			scaffold := NewImageCreator()
+			Expect(scaffold.LoadColorscheme(colorscheme(`{"colors": {}, "truecolor": "foobar"}`))).To(MatchError(ContainSubstring("invalid truecolor setting")))
+		})
+	})
+
+	Context("Use scaffold to create raw output file", func() {
+		var buf bytes.Buffer
+
+		BeforeEach(func() {
+			SetColorSettings(ON, ON)
+			buf.Reset()
+		})
+
+		It("should write an output file with the content as-is", func() {
+			scaffold := NewImageCreator()
+			Expect(scaffold.AddContent(strings.NewReader(Sprintf("MintCream{foobar}")))).To(Succeed())
+			Expect(scaffold.WriteRaw(&buf)).To(Succeed())
+			Expect(buf.String()).To(Equal("\x1b[38;2;245;255;250mfoobar\x1b[0m"))
+		})
+
+		It("should embed metadata into the PNG image", func() {
+			scaffold := NewImageCreator()
+			Expect(scaffold.AddContent(strings.NewReader(Sprintf("MintCream{foobar}")))).To(Succeed())
+			scaffold.SetMetadata("termshot:content", "foobar")
+			scaffold.SetMetadata("termshot:settings", "theme=nord\n")
+			scaffold.SetMetadata("removed", "value")
+			scaffold.SetMetadata("removed", "")
+			Expect(scaffold.WritePNG(&buf)).To(Succeed())
+
+			metadata, err := ReadMetadata(bytes.NewReader(buf.Bytes()))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(metadata).To(Equal(map[string]string{
+				"termshot:content":  "foobar",
+				"termshot:settings": "theme=nord\n",
+			}))
+
+			_, err = png.Decode(bytes.NewReader(buf.Bytes()))
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should write the content as plain text", func() {
+			scaffold := NewImageCreator()
+			Expect(scaffold.AddContent(strings.NewReader(Sprintf("MintCream{foobar}\t\nfoo")))).To(Succeed())
+			Expect(scaffold.WriteText(&buf)).To(Succeed())
+			Expect(buf.String()).To(Equal("foobar\nfoo\n"))
+		})
+
+		It("should write the parsed content as JSON", func() {
+			scaffold := NewImageCreator()
+			Expect(scaffold.AddContent(strings.NewReader("\x1b[1;38;2;255;0;0mf\x1b[0m\n\x1b[48;2;0;0;255mo\x1b[0m\n"))).To(Succeed())
+			Expect(scaffold.WriteCells(&buf)).To(Succeed())
+			Expect(buf.String()).To(MatchJSON(`{
+				"background": "#151515",
+				"lines": [
+					[{"rune": "f", "fg": "#FF0000", "attrs": ["bold"]}],
+					[{"rune": "o", "fg": "#D3D3D3", "bg": "#0000FF"}]
+				]
+			}`))
+		})
+
+		It("should swap foreground and background color of reverse video", func() {
+			scaffold := NewImageCreator()
+			Expect(scaffold.AddContent(strings.NewReader("\x1b[7mf\x1b[0m\n\x1b[7;38;2;255;0;0;48;2;0;0;255mo\x1b[27mo\x1b[0m\n"))).To(Succeed())
+			Expect(scaffold.WriteCells(&buf)).To(Succeed())
+			Expect(buf.String()).To(MatchJSON(`{
+				"background": "#151515",
+				"lines": [
+					[{"rune": "f", "fg": "#151515", "bg": "#D3D3D3", "attrs": ["reverse"]}],
+					[{"rune": "o", "fg": "#0000FF", "bg": "#FF0000", "attrs": ["reverse"]}, {"rune": "o", "fg": "#FF0000", "bg": "#0000FF"}]
+				]
+			}`))
+		})
+
+		It("should blend dim text into its background color", func() {
+			scaffold := NewImageCreator()
+			scaffold.SetForegroundColor(color.White)
+			scaffold.SetBackgroundColor(color.Black)
+			Expect(scaffold.SetDimOpacity(1.5)).ToNot(Succeed())
+			Expect(scaffold.SetDimOpacity(0.25)).To(Succeed())
+			Expect(scaffold.AddContent(strings.NewReader("\x1b[2mf\x1b[0m\x1b[2;7mo\x1b[0m\n"))).To(Succeed())
+			Expect(scaffold.WriteCells(&buf)).To(Succeed())
+			Expect(buf.String()).To(MatchJSON(`{
+				"background": "#000000",
+				"lines": [[
+					{"rune": "f", "fg": "#404040", "attrs": ["dim"]},
+					{"rune": "o", "fg": "#BFBFBF", "bg": "#FFFFFF", "attrs": ["dim", "reverse"]}
+				]]
+			}`))
+		})
+
+		It("should write the style and color of underlines", func() {
+			scaffold := NewImageCreator()
+			Expect(scaffold.AddContent(strings.NewReader("\x1b[4:3;58;2;255;0;0mf\x1b[4:1mo\x1b[0m\n"))).To(Succeed())
+			Expect(scaffold.WriteCells(&buf)).To(Succeed())
+			Expect(buf.String()).To(MatchJSON(`{
+				"background": "#151515",
+				"lines": [[
+					{"rune": "f", "fg": "#D3D3D3", "ul": "#FF0000", "attrs": ["wavy-underline"]},
+					{"rune": "o", "fg": "#D3D3D3", "ul": "#FF0000", "attrs": ["underline"]}
+				]]
+			}`))
+
+			buf.Reset()
+			Expect(scaffold.WriteHTML(&buf)).To(Succeed())
+			Expect(buf.String()).To(ContainSubstring("text-decoration-style: wavy; text-decoration-color: #FF0000"))
+		})
+
+		It("should underline bold and italic text", func() {
+			scaffold := NewImageCreator()
+			Expect(scaffold.AddContent(strings.NewReader("\x1b[1;4:3;58;2;255;0;0mfoo\x1b[22;3mbar\x1b[0m\n"))).To(Succeed())
+			Expect(scaffold.WriteSVG(&buf)).To(Succeed())
+			Expect(strings.Count(buf.String(), `stroke="#FF0000"`)).To(BeNumerically(">=", 2))
+
+			image, err := scaffold.Image()
+			Expect(err).ToNot(HaveOccurred())
+
+			var red int
+			for y := image.Bounds().Min.Y; y < image.Bounds().Max.Y; y++ {
+				for x := image.Bounds().Min.X; x < image.Bounds().Max.X; x++ {
+					if r, g, b, _ := image.At(x, y).RGBA(); r>>8 > 200 && g>>8 < 50 && b>>8 < 50 {
+						red++
+					}
+				}
+			}
+
+			Expect(red).To(BeNumerically(">", 0))
+		})
+
+		It("should write blinking text as an attribute", func() {
+			scaffold := NewImageCreator()
+			Expect(scaffold.AddContent(strings.NewReader("\x1b[1;5mf\x1b[25mo\x1b[0m\n"))).To(Succeed())
+			Expect(scaffold.WriteCells(&buf)).To(Succeed())
+			Expect(buf.String()).To(MatchJSON(`{
+				"background": "#151515",
+				"lines": [[
+					{"rune": "f", "fg": "#D3D3D3", "attrs": ["bold", "blink"]},
+					{"rune": "o", "fg": "#D3D3D3", "attrs": ["bold"]}
+				]]
+			}`))
+		})
+
+		It("should write the content as SVG with text elements", func() {
+			scaffold := NewImageCreator()
+			scaffold.SetTitle("<t>")
+			Expect(scaffold.AddContent(strings.NewReader("\x1b[1;31mfoo\x1b[0m & bar\n"))).To(Succeed())
+			Expect(scaffold.WriteSVG(&buf)).To(Succeed())
+
+			decoder := xml.NewDecoder(&buf)
+			var texts []string
+			for {
+				token, err := decoder.Token()
+				if err == io.EOF {
+					break
+				}
+
+				Expect(err).ToNot(HaveOccurred())
+				if data, ok := token.(xml.CharData); ok && strings.TrimSpace(string(data)) != "" {
+					texts = append(texts, string(data))
+				}
+			}
+
+			Expect(texts).To(Equal([]string{"<t>", "foo", " & bar"}))
+		})
+
+		It("should show the timestamp in the title bar", func() {
+			scaffold := NewImageCreator()
+			scaffold.DrawDecorations(false)
+			scaffold.SetTimestamp("2026-01-02 15:04")
+			Expect(scaffold.AddContent(strings.NewReader("foobar"))).To(Succeed())
+			Expect(scaffold.WriteSVG(&buf)).To(Succeed())
+			Expect(buf.String()).To(MatchRegexp(`<text x="[0-9.]+" y="[0-9.]+" text-anchor="end" fill="#696969">2026-01-02 15:04</text>`))
+
+			buf.Reset()
+			Expect(scaffold.WriteHTML(&buf)).To(Succeed())
+			Expect(buf.String()).To(ContainSubstring(`<div class="titlebar"><div class="timestamp">2026-01-02 15:04</div></div>`))
+		})
+
+		It("should place right-to-left text in display order", func() {
+			scaffold := NewImageCreator()
+			Expect(scaffold.AddContent(strings.NewReader("echo שלום (1) 42\nשלום, world!\n"))).To(Succeed())
+			Expect(scaffold.WriteSVG(&buf)).To(Succeed())
+
+			Expect(buf.String()).To(ContainSubstring(`unicode-bidi="bidi-override">echo 42 (1) םולש</text>`))
+			Expect(buf.String()).To(ContainSubstring(`unicode-bidi="bidi-override">!world ,םולש</text>`))
+		})
+
+		It("should leave out the lines beyond the maximum number of lines", func() {
+			var content strings.Builder
+			for i := 1; i <= 40; i++ {
+				_, _ = Fprintf(&content, "%d\n", i)
+			}
+
+			scaffold := NewImageCreator()
+			scaffold.SetMaxLines(5)
+			Expect(scaffold.AddContent(strings.NewReader(content.String()))).To(Succeed())
+			Expect(scaffold.WriteHTML(&buf)).To(Succeed())
+			Expect(buf.String()).To(ContainSubstring(`<div class="line"><span style="color: #747474">… 35 lines omitted …</span></div><div class="line">36</div>`))
+			Expect(buf.String()).To(ContainSubstring(`<div class="line">40</div>`))
+			Expect(buf.String()).ToNot(ContainSubstring(`<div class="line">35</div>`))
+
+			buf.Reset()
+			scaffold.KeepFirstLines(true)
+			scaffold.SetTruncationMarker(TruncationFade)
+			Expect(scaffold.WriteHTML(&buf)).To(Succeed())
+			Expect(buf.String()).To(ContainSubstring(`<div class="content" style="mask-image: linear-gradient(to top, transparent, black 3.6em);"><div class="line">1</div>`))
+			Expect(buf.String()).To(ContainSubstring(`<div class="line">5</div>`))
+			Expect(buf.String()).ToNot(ContainSubstring(`<div class="line">6</div>`))
+			Expect(buf.String()).ToNot(ContainSubstring("omitted"))
+		})
+
+		It("should write the content as standalone HTML document with styled text", func() {
+			scaffold := NewImageCreator()
+			scaffold.SetTitle("<t>")
+			Expect(scaffold.AddContent(strings.NewReader("\x1b[1;4;38;2;255;0;0mfoo\x1b[0m & bar\n\x1b[48;2;0;0;255mx\x1b[0m\n"))).To(Succeed())
+			Expect(scaffold.WriteHTML(&buf)).To(Succeed())
+
+			Expect(buf.String()).To(HavePrefix("<!DOCTYPE html>"))
+			Expect(buf.String()).To(ContainSubstring(`<div class="title">&lt;t&gt;</div>`))
+			Expect(buf.String()).To(ContainSubstring(`<div class="line"><span style="color: #FF0000; font-weight: bold; text-decoration: underline">foo</span> &amp; bar</div>`))
+			Expect(buf.String()).To(ContainSubstring(`<div class="line"><span style="background: #0000FF">x</span></div>`))
+		})
+
+		It("should write the content as JPEG on a white background", func() {
+			scaffold := NewImageCreator()
+			Expect(scaffold.AddContent(strings.NewReader("foobar"))).To(Succeed())
+			Expect(scaffold.WriteJPEG(&buf, 80)).To(Succeed())
+
+			img, err := jpeg.Decode(&buf)
+			Expect(err).ToNot(HaveOccurred())
+
+			r, g, b, _ := img.At(0, 0).RGBA()
+			Expect([]uint32{r >> 8, g >> 8, b >> 8}).To(HaveEach(BeNumerically(">", 0xF0)))
+		})
+
+		It("should write the content as lossless WebP", func() {
+			scaffold := NewImageCreator()
+			Expect(scaffold.AddContent(strings.NewReader("foobar"))).To(Succeed())
+			Expect(scaffold.WriteWebP(&buf, WebPOptions{})).To(Succeed())
+
+			expected, err := scaffold.Image()
+			Expect(err).ToNot(HaveOccurred())
+
+			img, err := webp.Decode(&buf)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(img.Bounds()).To(Equal(expected.Bounds()))
+			Expect(color.NRGBAModel.Convert(img.At(100, 100))).To(Equal(color.NRGBAModel.Convert(expected.At(100, 100))))
+		})
+
+		It("should write the content as WebP with a reduced number of colors", func() {
+			scaffold := NewImageCreator()
+			Expect(scaffold.AddContent(strings.NewReader(Sprintf("Red{foo} Green{bar} Blue{baz}")))).To(Succeed())
+			Expect(scaffold.WriteWebP(&buf, WebPOptions{Quality: 50})).To(Succeed())
+
+			img, err := webp.Decode(&buf)
+			Expect(err).ToNot(HaveOccurred())
+
+			colors := map[color.Color]struct{}{}
+			for y := img.Bounds().Min.Y; y < img.Bounds().Max.Y; y++ {
+				for x := img.Bounds().Min.X; x < img.Bounds().Max.X; x++ {
+					colors[color.NRGBAModel.Convert(img.At(x, y))] = struct{}{}
+				}
+			}
+
+			Expect(len(colors)).To(BeNumerically("<=", 256))
+		})
+
+		It("should write the content as PDF document with one page per number of lines", func() {
+			scaffold := NewImageCreator()
+			Expect(scaffold.AddContent(strings.NewReader("foo\nbar\nbaz\n"))).To(Succeed())
+
+			Expect(scaffold.WritePDF(&buf, PDFOptions{})).To(Succeed())
+			Expect(buf.String()).To(HavePrefix("%PDF-1.4"))
+			Expect(buf.String()).To(ContainSubstring("/Count 1 "))
+			Expect(buf.String()).To(HaveSuffix("%%EOF\n"))
+
+			buf.Reset()
+			Expect(scaffold.WritePDF(&buf, PDFOptions{LinesPerPage: 2})).To(Succeed())
+			Expect(buf.String()).To(ContainSubstring("/Kids [3 0 R 7 0 R] /Count 2 "))
+		})
+
+		It("should add a footer on a separate line", func() {
+			scaffold := NewImageCreator()
+			Expect(scaffold.AddContent(strings.NewReader("foobar"))).To(Succeed())
+			Expect(scaffold.AddFooter("attempt 2 of 3")).To(Succeed())
+			Expect(scaffold.WriteRaw(&buf)).To(Succeed())
+			Expect(buf.String()).To(Equal("foobar\n\x1b[38;2;105;105;105mattempt 2 of 3\x1b[0m\n"))
+		})
+
+		It("should add the exit code with a success or failure badge on a separate line", func() {
+			scaffold := NewImageCreator()
+			Expect(scaffold.AddContent(strings.NewReader("foobar"))).To(Succeed())
+			Expect(scaffold.AddExitCode(2)).To(Succeed())
+			Expect(scaffold.Lines()).To(Equal([]string{"foobar", "× exit code 2"}))
+
+			success := NewImageCreator()
+			Expect(success.AddExitCode(0)).To(Succeed())
+			Expect(success.Lines()).To(Equal([]string{"√ exit code 0"}))
+		})
+	})
+})
@@ -0,0 +1,212 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package img
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gonvenience/bunt"
+
+	"github.com/homeport/termshot/internal/vt"
+)
+
+// PaletteAuto is the name of the source palette setting that detects the
+// palette based on the colors of the content
+const PaletteAuto = "auto"
+
+// sourcePalette are the colors a terminal emulator uses for the 16 standard
+// colors, programs that write true colors based on them, for example after
+// querying the terminal, use these values for the standard colors
+type sourcePalette struct {
+	name   string
+	colors [16][3]int
+}
+
+// parserPalettes are the colors of the SGR parameters 30-37 and 90-97, and
+// of the 8-bit colors 0-15 as parsed from the content, which are always
+// mapped back to their color index
+var parserPalettes = []sourcePalette{
+	{name: "sgr", colors: [16][3]int{
+		{1, 1, 1}, {222, 56, 43}, {57, 181, 74}, {255, 199, 6},
+		{0, 111, 184}, {118, 38, 113}, {44, 181, 233}, {204, 204, 204},
+		{128, 128, 128}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+		{0, 0, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+	}},
+	{name: "sgr-8bit", colors: [16][3]int{
+		{0, 0, 0}, {170, 0, 0}, {0, 170, 0}, {229, 229, 16},
+		{0, 0, 170}, {170, 0, 170}, {0, 170, 170}, {229, 229, 229},
+		{85, 85, 85}, {255, 85, 85}, {85, 255, 85}, {255, 255, 85},
+		{85, 85, 255}, {255, 85, 255}, {85, 255, 255}, {255, 255, 255},
+	}},
+}
+
+// sourcePalettes are the palettes of common terminal emulators, the first
+// one is used to find close colors unless another one is detected
+var sourcePalettes = []sourcePalette{
+	{name: "vga", colors: [16][3]int{
+		{0, 0, 0}, {128, 0, 0}, {0, 128, 0}, {128, 128, 0},
+		{0, 0, 128}, {128, 0, 128}, {0, 128, 128}, {192, 192, 192},
+		{128, 128, 128}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+		{0, 0, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+	}},
+	{name: "xterm", colors: [16][3]int{
+		{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+		{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+		{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+		{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+	}},
+	{name: "vte", colors: [16][3]int{
+		{46, 52, 54}, {204, 0, 0}, {78, 154, 6}, {196, 160, 0},
+		{52, 101, 164}, {117, 80, 123}, {6, 152, 154}, {211, 215, 207},
+		{85, 87, 83}, {239, 41, 41}, {138, 226, 52}, {252, 233, 79},
+		{114, 159, 207}, {173, 127, 168}, {52, 226, 226}, {238, 238, 236},
+	}},
+	{name: "iterm2", colors: [16][3]int{
+		{0, 0, 0}, {194, 54, 33}, {37, 188, 36}, {173, 173, 39},
+		{73, 46, 225}, {211, 56, 211}, {51, 187, 200}, {203, 204, 205},
+		{129, 131, 131}, {252, 57, 31}, {49, 231, 34}, {234, 236, 35},
+		{88, 51, 255}, {249, 53, 248}, {20, 240, 240}, {233, 235, 235},
+	}},
+	{name: "windows", colors: [16][3]int{
+		{12, 12, 12}, {197, 15, 31}, {19, 161, 14}, {193, 156, 0},
+		{0, 55, 218}, {136, 23, 152}, {58, 150, 221}, {204, 204, 204},
+		{118, 118, 118}, {231, 72, 86}, {22, 198, 12}, {249, 241, 165},
+		{59, 120, 255}, {180, 0, 158}, {97, 214, 214}, {242, 242, 242},
+	}},
+}
+
+// paletteMatcher maps colors of the content back to color indices, where the
+// colors of the palettes take precedence in the order they were added
+type paletteMatcher struct {
+	// name is the name of the source palette
+	name string
+
+	// standard maps the colors of the palettes to the standard colors
+	standard map[[3]int]int
+
+	// closest is the palette used to find the standard color that is close
+	// to a color that is not a palette color
+	closest [16][3]int
+}
+
+// defaultPaletteMatcher is used until a palette is detected or configured
+var defaultPaletteMatcher = newPaletteMatcher(PaletteAuto, sourcePalettes)
+
+func newPaletteMatcher(name string, palettes []sourcePalette) *paletteMatcher {
+	m := &paletteMatcher{
+		name:     name,
+		standard: map[[3]int]int{},
+		closest:  palettes[0].colors,
+	}
+
+	for _, palette := range append(append([]sourcePalette{}, parserPalettes...), palettes...) {
+		for index, rgb := range palette.colors {
+			if _, exists := m.standard[rgb]; !exists {
+				m.standard[rgb] = index
+			}
+		}
+	}
+
+	return m
+}
+
+// SourcePalettes returns the names of the palettes that colors of the content
+// can be mapped back from, see [Scaffold.SetSourcePalette]
+func SourcePalettes() []string {
+	names := []string{PaletteAuto}
+	for _, palette := range sourcePalettes {
+		names = append(names, palette.name)
+	}
+
+	return names
+}
+
+// SetSourcePalette configures the palette of the terminal emulator whose
+// colors the content uses, so that exactly these colors are replaced by the
+// colors of the color scheme; by default, the palette is detected based on
+// the colors of the content, and colors of all palettes are mapped
+func (s *Scaffold) SetSourcePalette(name string) error {
+	if name == PaletteAuto || name == "" {
+		s.sourcePalette = ""
+		s.palette = detectPalette(s.content)
+		return nil
+	}
+
+	for _, palette := range sourcePalettes {
+		if palette.name == name {
+			s.sourcePalette = name
+			s.palette = newPaletteMatcher(name, []sourcePalette{palette})
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown source palette %q, supported are: %s", name, strings.Join(SourcePalettes(), ", "))
+}
+
+// SourcePalette returns the name of the source palette, which is the detected
+// one in case the palette is detected automatically
+func (s *Scaffold) SourcePalette() string {
+	return s.paletteMatcher().name
+}
+
+func (s *Scaffold) paletteMatcher() *paletteMatcher {
+	if s.palette == nil {
+		return defaultPaletteMatcher
+	}
+
+	return s.palette
+}
+
+// detectPalette returns a matcher, that prefers the source palette of which
+// the most colors are used in the content, and then all other palettes
+func detectPalette(content bunt.String) *paletteMatcher {
+	used := map[[3]int]struct{}{}
+	for _, cr := range content {
+		for _, background := range []bool{false, true} {
+			if r, g, b, ok := vt.Color(cr.Settings, background); ok {
+				used[[3]int{int(r), int(g), int(b)}] = struct{}{}
+			}
+		}
+	}
+
+	best, matches := -1, 0
+	for i, palette := range sourcePalettes {
+		var count int
+		for _, rgb := range palette.colors {
+			if _, ok := used[rgb]; ok {
+				count++
+			}
+		}
+
+		if count > matches {
+			best, matches = i, count
+		}
+	}
+
+	if best < 0 {
+		return defaultPaletteMatcher
+	}
+
+	palettes := append([]sourcePalette{sourcePalettes[best]}, sourcePalettes[:best]...)
+	palettes = append(palettes, sourcePalettes[best+1:]...)
+	return newPaletteMatcher(sourcePalettes[best].name, palettes)
+}
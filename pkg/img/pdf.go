@@ -0,0 +1,202 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package img
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"strings"
+
+	"github.com/gonvenience/bunt"
+)
+
+// PDFOptions are the settings of the PDF document
+type PDFOptions struct {
+	// LinesPerPage splits long content into multiple pages with the given
+	// number of lines, each page showing a window with a part of the content,
+	// zero puts the whole content on one page
+	LinesPerPage int
+}
+
+// WritePDF writes the scaffold content as PDF document into the provided
+// writer, where the rendered window is placed as an image on the page. All
+// pages have the same size, which is based on the largest image, where one
+// pixel of the unscaled image corresponds to one point on the page.
+func (s *Scaffold) WritePDF(w io.Writer, opts PDFOptions) error {
+	var pages []image.Image
+	for _, page := range s.pages(opts.LinesPerPage) {
+		img, err := page.Image()
+		if err != nil {
+			return err
+		}
+
+		pages = append(pages, img)
+	}
+
+	var width, height int
+	for _, page := range pages {
+		width = max(width, page.Bounds().Dx())
+		height = max(height, page.Bounds().Dy())
+	}
+
+	pdf := pdfWriter{w: bufio.NewWriter(w)}
+	pdf.printf("%%PDF-1.4\n%%\xE2\xE3\xCF\xD3\n")
+
+	// The catalog and the page tree are objects 1 and 2, and each page
+	// consists of four objects: the page, its content, the image, and the
+	// alpha channel of the image
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 3+4*i)
+	}
+
+	pdf.object("<< /Type /Catalog /Pages 2 0 R >>")
+	pdf.object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d /MediaBox [0 0 %s %s] >>",
+		strings.Join(kids, " "), len(pages), num(float64(width)/s.factor), num(float64(height)/s.factor)))
+
+	for i, page := range pages {
+		id := 3 + 4*i
+		bounds := page.Bounds()
+		imgWidth, imgHeight := float64(bounds.Dx())/s.factor, float64(bounds.Dy())/s.factor
+
+		// Pages are centered horizontally and aligned at the top
+		x := (float64(width)/s.factor - imgWidth) / 2
+		y := float64(height)/s.factor - imgHeight
+
+		pdf.object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /Resources << /XObject << /Im0 %d 0 R >> >> /Contents %d 0 R >>", id+2, id+1))
+		pdf.stream("", []byte(fmt.Sprintf("q %s 0 0 %s %s %s cm /Im0 Do Q", num(imgWidth), num(imgHeight), num(x), num(y))))
+
+		rgb, alpha := pdfImageData(page)
+		pdf.stream(fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceRGB /BitsPerComponent 8 /SMask %d 0 R /Filter /FlateDecode ", bounds.Dx(), bounds.Dy(), id+3), rgb)
+		pdf.stream(fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceGray /BitsPerComponent 8 /Filter /FlateDecode ", bounds.Dx(), bounds.Dy()), alpha)
+	}
+
+	return pdf.close()
+}
+
+// pages returns one scaffold per page, each with its part of the content
+func (s *Scaffold) pages(linesPerPage int) []*Scaffold {
+	if linesPerPage <= 0 {
+		return []*Scaffold{s}
+	}
+
+	// The lines left out due to the line range, trimming, or the maximum
+	// number of lines are not paged
+	content, _ := s.selectedLines()
+	content, _ = s.truncate(content)
+	lines := splitLines(content)
+	if len(lines) <= linesPerPage {
+		return []*Scaffold{s}
+	}
+
+	var pages []*Scaffold
+	for start := 0; start < len(lines); start += linesPerPage {
+		page := *s
+		page.rows = 0
+		page.maxLines = 0
+		page.trimBlankLines = false
+		page.firstLine, page.lastLine = 0, 0
+		page.content = nil
+		for _, line := range lines[start:min(start+linesPerPage, len(lines))] {
+			page.content = append(page.content, line...)
+			page.content = append(page.content, bunt.ColoredRune{Symbol: '\n'})
+		}
+
+		page.highlights = map[int]color.Color{}
+		for line, c := range s.highlights {
+			if line >= start && line < start+linesPerPage {
+				page.highlights[line-start] = c
+			}
+		}
+
+		pages = append(pages, &page)
+	}
+
+	return pages
+}
+
+// pdfImageData returns the compressed color and alpha values of the image,
+// the color values are not premultiplied with the alpha
+func pdfImageData(img image.Image) (rgb []byte, alpha []byte) {
+	bounds := img.Bounds()
+
+	var rgbBuf, alphaBuf bytes.Buffer
+	rgbWriter, alphaWriter := zlib.NewWriter(&rgbBuf), zlib.NewWriter(&alphaBuf)
+	row, alphaRow := make([]byte, 3*bounds.Dx()), make([]byte, bounds.Dx())
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			i := x - bounds.Min.X
+			row[3*i], row[3*i+1], row[3*i+2] = c.R, c.G, c.B
+			alphaRow[i] = c.A
+		}
+
+		_, _ = rgbWriter.Write(row)
+		_, _ = alphaWriter.Write(alphaRow)
+	}
+
+	_ = rgbWriter.Close()
+	_ = alphaWriter.Close()
+
+	return rgbBuf.Bytes(), alphaBuf.Bytes()
+}
+
+// pdfWriter writes the objects of a PDF document and keeps track of their
+// offsets for the cross-reference table
+type pdfWriter struct {
+	w       *bufio.Writer
+	offset  int
+	offsets []int
+}
+
+func (p *pdfWriter) printf(format string, a ...interface{}) {
+	n, _ := fmt.Fprintf(p.w, format, a...)
+	p.offset += n
+}
+
+func (p *pdfWriter) object(content string) {
+	p.offsets = append(p.offsets, p.offset)
+	p.printf("%d 0 obj\n%s\nendobj\n", len(p.offsets), content)
+}
+
+func (p *pdfWriter) stream(dict string, data []byte) {
+	p.offsets = append(p.offsets, p.offset)
+	p.printf("%d 0 obj\n<< %s/Length %d >>\nstream\n", len(p.offsets), dict, len(data))
+	n, _ := p.w.Write(data)
+	p.offset += n
+	p.printf("\nendstream\nendobj\n")
+}
+
+func (p *pdfWriter) close() error {
+	xref := p.offset
+	p.printf("xref\n0 %d\n0000000000 65535 f \n", len(p.offsets)+1)
+	for _, offset := range p.offsets {
+		p.printf("%010d 00000 n \n", offset)
+	}
+
+	p.printf("trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(p.offsets)+1, xref)
+	return p.w.Flush()
+}
@@ -0,0 +1,100 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package img
+
+import (
+	"image"
+	"math"
+
+	"github.com/fogleman/gg"
+	"golang.org/x/image/draw"
+	"golang.org/x/image/math/fixed"
+)
+
+// isPowerlineSeparator returns whether the rune is one of the separators of
+// Powerline or its extra symbols, which are stretched to fill the cell, so
+// that they connect to the background of the neighboring segments
+func isPowerlineSeparator(r rune) bool {
+	return r >= 0xE0B0 && r <= 0xE0D7
+}
+
+// isIconRune returns whether the rune is in one of the private use areas,
+// where Powerline and Nerd Fonts place their symbols
+func isIconRune(r rune) bool {
+	return r >= 0xE000 && r <= 0xF8FF || r >= 0xF0000 && r <= 0xFFFFD
+}
+
+// iconRune returns the symbol of the glyph, if it is a Powerline or Nerd
+// Font symbol
+func iconRune(g glyph) (rune, bool) {
+	if g.shaped {
+		return 0, false
+	}
+
+	runes := []rune(g.text)
+	if len(runes) != 1 || !isIconRune(runes[0]) {
+		return 0, false
+	}
+
+	return runes[0], true
+}
+
+// fitIcon returns the mask of the symbol scaled to its cell and the area on
+// the image to draw it to: separators fill the cell, all other symbols
+// keep their position relative to the baseline and are only scaled down, in
+// case they are wider than the cell
+func (s *Scaffold) fitIcon(dc *gg.Context, g glyph, r rune, cell image.Rectangle) (*image.Alpha, image.Rectangle, bool) {
+	dr, mask, maskp, advance, ok := g.face.Glyph(fixed.Point26_6{}, r)
+	if !ok || mask == nil || dr.Empty() {
+		return nil, image.Rectangle{}, false
+	}
+
+	_, ty := dc.TransformPoint(0, 0)
+
+	// Separators fill the area of the background color of the cell, which
+	// does not include the line spacing
+	area := cell
+	area.Max.Y = int(math.Round(g.y + 12 + ty))
+
+	if !isPowerlineSeparator(r) {
+		width := float64(advance) / 64
+		scale := math.Min(1, float64(cell.Dx())/math.Max(width, 1))
+
+		x := float64(cell.Min.X) + (float64(cell.Dx())-width*scale)/2
+		y := math.Round(g.y + ty)
+
+		area = image.Rect(
+			int(math.Round(x+float64(dr.Min.X)*scale)),
+			int(math.Round(y+float64(dr.Min.Y)*scale)),
+			int(math.Round(x+float64(dr.Max.X)*scale)),
+			int(math.Round(y+float64(dr.Max.Y)*scale)),
+		)
+	}
+
+	if area.Empty() {
+		return nil, image.Rectangle{}, false
+	}
+
+	fitted := image.NewAlpha(image.Rect(0, 0, area.Dx(), area.Dy()))
+	draw.CatmullRom.Scale(fitted, fitted.Bounds(), mask, image.Rectangle{Min: maskp, Max: maskp.Add(dr.Size())}, draw.Src, nil)
+
+	return fitted, area, true
+}
@@ -0,0 +1,193 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package img
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// promptSegment matches the placeholders of a prompt template with their
+// optional style, e.g. {cwd} or {cwd:bold,#00ffff}
+var promptSegment = regexp.MustCompile(`\{(\w+)(?::([^}]*))?\}`)
+
+// SetCommandIndicator sets the string shown in front of the command, which
+// takes precedence over the environment variable TS_COMMAND_INDICATOR
+func (s *Scaffold) SetCommandIndicator(indicator string) { s.indicator = &indicator }
+
+// SetCommandIndicatorStyle sets the style of the command indicator as a comma
+// separated list of a color in hex notation (#rrggbb) and the attributes
+// bold, dim, italic, and underline, e.g. "bold,#ff8800"
+func (s *Scaffold) SetCommandIndicatorStyle(style string) error {
+	settings, err := parseStyle(style)
+	if err != nil {
+		return fmt.Errorf("invalid command indicator style: %w", err)
+	}
+
+	s.indicatorStyle = settings
+	return nil
+}
+
+// SetPromptTemplate sets the template of the command line, in which the
+// placeholders {user}, {host}, {cwd}, {indicator}, and {command} are
+// replaced, each optionally with a style like {cwd:bold,#00ffff}, see
+// [Scaffold.SetCommandIndicatorStyle] for the format of the style
+func (s *Scaffold) SetPromptTemplate(template string) error {
+	for _, match := range promptSegment.FindAllStringSubmatch(template, -1) {
+		switch match[1] {
+		case "user", "host", "cwd", "indicator", "command":
+		default:
+			return fmt.Errorf("unknown placeholder %q in prompt template, supported are: user, host, cwd, indicator, command", match[1])
+		}
+
+		if _, err := parseStyle(match[2]); err != nil {
+			return fmt.Errorf("invalid style of %s in prompt template: %w", match[1], err)
+		}
+	}
+
+	s.promptTemplate = template
+	return nil
+}
+
+// parseStyle converts a comma separated list of a color in hex notation and
+// text attributes to the settings of a colored rune
+func parseStyle(style string) (uint64, error) {
+	var settings uint64
+	for _, part := range strings.Split(style, ",") {
+		switch part = strings.TrimSpace(part); part {
+		case "":
+			continue
+
+		case "bold":
+			settings |= 0x04
+
+		case "dim":
+			settings |= 0x80
+
+		case "italic":
+			settings |= 0x08
+
+		case "underline":
+			settings |= 0x10
+
+		default:
+			c, err := ParseHexColor(part)
+			if err != nil {
+				return 0, fmt.Errorf("unsupported style %q, supported are a color (#rrggbb), bold, dim, italic, and underline", part)
+			}
+
+			r, g, b, _ := c.RGBA()
+			settings = settings&^0xFFFFFF01 | 0x01 | uint64(r>>8)<<8 | uint64(g>>8)<<16 | uint64(b>>8)<<24
+		}
+	}
+
+	return settings, nil
+}
+
+// styled returns the text with the escape sequences of the style settings
+func styled(settings uint64, text string) string {
+	if settings == 0 || text == "" {
+		return text
+	}
+
+	return renderSettings(settings) + text + "\x1b[0m"
+}
+
+// styledCommandIndicator returns the command indicator with the escape
+// sequences of its style, which is lime by default
+func (s *Scaffold) styledCommandIndicator() string {
+	if s.indicatorStyle == 0 {
+		return s.sprintf("Lime{%s}", s.commandIndicator())
+	}
+
+	return styled(s.indicatorStyle, s.commandIndicator())
+}
+
+// CommandLine returns the line of the command including the escape
+// sequences of its colors, the same way as it is added by [Scaffold.AddCommand]
+func (s *Scaffold) CommandLine(args ...string) string {
+	command := strings.Join(args, " ")
+	if s.promptTemplate == "" {
+		return s.styledCommandIndicator() + s.sprintf(" DimGray{%s}", command)
+	}
+
+	return promptSegment.ReplaceAllStringFunc(s.promptTemplate, func(placeholder string) string {
+		match := promptSegment.FindStringSubmatch(placeholder)
+		settings, _ := parseStyle(match[2])
+
+		var value string
+		switch match[1] {
+		case "user":
+			value = os.Getenv("USER")
+			if current, err := user.Current(); value == "" && err == nil {
+				value = current.Username
+			}
+
+		case "host":
+			value, _ = os.Hostname()
+			value, _, _ = strings.Cut(value, ".")
+
+		case "cwd":
+			value = workingDirectory()
+
+		case "indicator":
+			if settings == 0 {
+				return s.styledCommandIndicator()
+			}
+
+			value = s.commandIndicator()
+
+		case "command":
+			if settings == 0 {
+				return s.sprintf("DimGray{%s}", command)
+			}
+
+			value = command
+		}
+
+		return styled(settings, value)
+	})
+}
+
+// workingDirectory returns the current working directory, where the home
+// directory is shortened to a tilde like in most shell prompts
+func workingDirectory() string {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+
+	if home, err := os.UserHomeDir(); err == nil {
+		if rel, err := filepath.Rel(home, cwd); err == nil && !strings.HasPrefix(rel, "..") {
+			if rel == "." {
+				return "~"
+			}
+
+			return filepath.Join("~", rel)
+		}
+	}
+
+	return cwd
+}
@@ -0,0 +1,128 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package img
+
+import (
+	"image/color"
+	"regexp"
+
+	"github.com/gonvenience/bunt"
+)
+
+// RedactStyle defines how redacted text is masked in the screenshot
+type RedactStyle int
+
+const (
+	// RedactBlock replaces each redacted character with a block character
+	RedactBlock RedactStyle = iota
+
+	// RedactBlur renders redacted characters as a blurred bar
+	RedactBlur
+)
+
+// blurredRune replaces characters that are rendered as a blurred bar, the
+// rune is from a private use area, therefore it does not occur in output
+const blurredRune = '\U000FFF00'
+
+// Redact masks all matches of the pattern in the content that is added
+// afterwards, including the command, so that secrets like tokens never end
+// up in the screenshot. The original text of a match is not kept.
+func (s *Scaffold) Redact(pattern *regexp.Regexp, style RedactStyle) {
+	s.redactions = append(s.redactions, redaction{pattern: pattern, style: style})
+}
+
+type redaction struct {
+	pattern *regexp.Regexp
+	style   RedactStyle
+}
+
+// redact replaces all characters of the content that match any of the
+// redaction patterns, matches do not span multiple lines
+func (s *Scaffold) redact(content bunt.String) bunt.String {
+	result := append(bunt.String{}, content...)
+
+	var start int
+	for _, line := range splitLines(content) {
+		text := make([]rune, len(line))
+		for i, cr := range line {
+			text[i] = cr.Symbol
+		}
+
+		// Map byte offsets of the matches to the character indices
+		str := string(text)
+		index := make([]int, len(str)+1)
+		var n int
+		for i := range str {
+			index[i] = n
+			n++
+		}
+		index[len(str)] = n
+
+		for _, redaction := range s.redactions {
+			symbol := '█'
+			if redaction.style == RedactBlur {
+				symbol = blurredRune
+			}
+
+			for _, match := range redaction.pattern.FindAllStringIndex(str, -1) {
+				for i := index[match[0]]; i < index[match[1]]; i++ {
+					result[start+i].Symbol = symbol
+				}
+			}
+		}
+
+		start += len(line) + 1
+	}
+
+	return result
+}
+
+// blurredArea is the area of consecutive redacted characters in one line
+type blurredArea struct {
+	Area
+	color color.Color
+}
+
+// blurredAreas returns the areas of the characters that are rendered as
+// blurred bars, consecutive characters are combined into one area
+func (s *Scaffold) blurredAreas(glyphs []glyph) []blurredArea {
+	var areas []blurredArea
+	for i := 0; i < len(glyphs); i++ {
+		if glyphs[i].cr.Symbol != blurredRune {
+			continue
+		}
+
+		start, width := glyphs[i], glyphs[i].width
+		for i+1 < len(glyphs) && glyphs[i+1].cr.Symbol == blurredRune && glyphs[i+1].y == start.y {
+			i++
+			width += glyphs[i].width
+		}
+
+		// The bar covers the height of lowercase letters of the line
+		height := start.height / 2
+		areas = append(areas, blurredArea{
+			Area:  Area{X: start.x, Y: start.y - height, Width: width, Height: height},
+			color: s.foregroundColor(start.cr),
+		})
+	}
+
+	return areas
+}
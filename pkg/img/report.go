@@ -0,0 +1,117 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package img
+
+import (
+	"image/color"
+	"sort"
+
+	"github.com/homeport/termshot/internal/vt"
+)
+
+// ColorUsage describes how often a color is used in the content and which
+// color is actually used to render it
+type ColorUsage struct {
+	// Background is true in case the color is used as a background color
+	Background bool
+
+	// Color is the color as defined in the content, or nil in case the
+	// default color is used
+	Color color.Color
+
+	// Count is the number of characters using the color
+	Count int
+
+	// PaletteIndex is the index of the color scheme color that is used to
+	// render the color, or -1 in case no color scheme color is used
+	PaletteIndex int
+
+	// Rendered is the color that is used in the screenshot
+	Rendered color.Color
+}
+
+// ColorUsage returns all distinct foreground and background colors of the
+// content, sorted by the number of characters using them
+func (s *Scaffold) ColorUsage() []ColorUsage {
+	// Colors with the same values, but another palette index are counted
+	// separately, since they can be rendered differently
+	type key struct {
+		background bool
+		set        bool
+		rgb        [3]uint8
+		index      int
+	}
+
+	var colorKey = func(settings uint64, background bool) key {
+		r, g, b, ok := vt.Color(settings, background)
+		index, _ := vt.PaletteIndex(settings, background)
+		return key{background: background, set: ok, rgb: [3]uint8{r, g, b}, index: index}
+	}
+
+	var order []key
+	var counts = map[key]int{}
+	var count = func(k key) {
+		if _, ok := counts[k]; !ok {
+			order = append(order, k)
+		}
+
+		counts[k]++
+	}
+
+	for _, cr := range s.content {
+		if cr.Symbol == '\n' {
+			continue
+		}
+
+		count(colorKey(cr.Settings, false))
+		if cr.Settings&0x02 != 0 {
+			count(colorKey(cr.Settings, true))
+		}
+	}
+
+	result := make([]ColorUsage, 0, len(order))
+	for _, k := range order {
+		usage := ColorUsage{
+			Background:   k.background,
+			Count:        counts[k],
+			PaletteIndex: -1,
+			Rendered:     s.defaultForegroundColor,
+		}
+
+		if k.set {
+			usage.Color = color.RGBA{R: k.rgb[0], G: k.rgb[1], B: k.rgb[2], A: 255}
+			usage.Rendered = usage.Color
+
+			if index, found := s.colorIndex(k.rgb, k.index); found {
+				usage.PaletteIndex = index
+				usage.Rendered = s.customColors[index]
+			}
+		}
+
+		result = append(result, usage)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Count > result[j].Count
+	})
+
+	return result
+}
@@ -0,0 +1,80 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package img
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// referenceDPI is the resolution of images with a scale of one, which is the
+// resolution CSS pixels are based on
+const referenceDPI = 96
+
+// SetScale sets the factor all sizes are scaled with, for example 1 for images
+// on the web, or 3 for print assets, the default is 2 for retina displays. The
+// padding, margin, and shadow are recomputed, and the fonts are re-created.
+func (s *Scaffold) SetScale(factor float64) error {
+	if factor <= 0 || math.IsInf(factor, 0) || math.IsNaN(factor) {
+		return fmt.Errorf("invalid scale %v, expected a positive number", factor)
+	}
+
+	ratio := factor / s.factor
+	for _, value := range []*float64{
+		&s.paddingTop, &s.paddingRight, &s.paddingBottom, &s.paddingLeft,
+		&s.marginTop, &s.marginRight, &s.marginBottom, &s.marginLeft,
+		&s.shadowOffsetX, &s.shadowOffsetY,
+		&s.minWidth, &s.minHeight, &s.cornerRadius, &s.borderWidth,
+	} {
+		*value *= ratio
+	}
+
+	s.shadowRadius = uint8(math.Min(math.Round(float64(s.shadowRadius)*ratio), 255))
+	s.factor = factor
+	s.dpi = 0
+
+	return s.loadFaces()
+}
+
+// SetDPI sets the scale based on the resolution in dots per inch, where 96
+// corresponds to a scale of 1, and stores the resolution in PNG images, so
+// that they are printed in the intended size
+func (s *Scaffold) SetDPI(dpi float64) error {
+	if err := s.SetScale(dpi / referenceDPI); err != nil {
+		return fmt.Errorf("invalid resolution %v, expected a positive number", dpi)
+	}
+
+	s.dpi = dpi
+	return nil
+}
+
+// resolutionChunk creates the PNG chunk with the physical pixel dimensions
+func resolutionChunk(dpi float64) pngChunk {
+	pixelsPerMeter := uint32(math.Round(dpi / 0.0254))
+
+	data := make([]byte, 9)
+	binary.BigEndian.PutUint32(data[0:], pixelsPerMeter)
+	binary.BigEndian.PutUint32(data[4:], pixelsPerMeter)
+	data[8] = 1 // unit is meter
+
+	return pngChunk{kind: "pHYs", data: data}
+}
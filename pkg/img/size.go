@@ -0,0 +1,119 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package img
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/gonvenience/bunt"
+	imgfont "golang.org/x/image/font"
+)
+
+// Alignment is the position of the content within the window in case the
+// window is larger than the content, e.g. due to a fixed number of columns
+type Alignment int
+
+// Supported alignments of the content
+const (
+	AlignStart  Alignment = iota // left or top
+	AlignCenter                  // center or middle
+)
+
+// SetMinSize sets the minimum width and height of the window in pixels
+// (before scaling), so that screenshots of different content can have the
+// same size, use zero for no minimum
+func (s *Scaffold) SetMinSize(width, height float64) error {
+	if width < 0 || height < 0 {
+		return fmt.Errorf("invalid minimum size %vx%v, expected positive numbers", width, height)
+	}
+
+	s.minWidth = s.factor * width
+	s.minHeight = s.factor * height
+	return nil
+}
+
+// SetAspectRatio sets the aspect ratio (width divided by height) of the
+// window, which is grown in width or height to match it, use zero to size
+// the window based on its content only
+func (s *Scaffold) SetAspectRatio(ratio float64) error {
+	if ratio < 0 || math.IsInf(ratio, 0) || math.IsNaN(ratio) {
+		return fmt.Errorf("invalid aspect ratio %v, expected a positive number", ratio)
+	}
+
+	s.aspectRatio = ratio
+	return nil
+}
+
+// constrainSize returns the window size grown to the minimum size and the
+// aspect ratio
+func (s *Scaffold) constrainSize(width, height float64) (float64, float64) {
+	width = math.Max(width, s.minWidth)
+	height = math.Max(height, s.minHeight)
+
+	if s.aspectRatio > 0 {
+		if width/height < s.aspectRatio {
+			width = math.Ceil(height * s.aspectRatio)
+		} else {
+			height = math.Ceil(width / s.aspectRatio)
+		}
+	}
+
+	return width, height
+}
+
+// SetAlignment sets the horizontal and vertical alignment of the content in
+// case the window is larger than the content, which is in the top left
+// corner by default like in a terminal
+func (s *Scaffold) SetAlignment(horizontal, vertical Alignment) {
+	s.alignHorizontal, s.alignVertical = horizontal, vertical
+}
+
+// alignedContent returns the area of the content moved within the content
+// area of the window according to the alignment, where width and height are
+// the size the content actually takes
+func (s *Scaffold) alignedContent(area Area, width, height float64) Area {
+	if s.alignHorizontal == AlignCenter && area.Width > width {
+		area.X += math.Round((area.Width - width) / 2)
+		area.Width = width
+	}
+
+	if s.alignVertical == AlignCenter && area.Height > height {
+		area.Y += math.Round((area.Height - height) / 2)
+		area.Height = height
+	}
+
+	return area
+}
+
+// textWidth returns the width of the longest line of the content without
+// trailing whitespace, including the gutter for line numbers
+func (s *Scaffold) textWidth(content bunt.String, gutter float64) float64 {
+	drawer := &imgfont.Drawer{Face: s.regular}
+
+	var width float64
+	for _, line := range measuredLines(content, s.table) {
+		width = math.Max(width, float64(drawer.MeasureString(strings.TrimRight(line, " "))>>6))
+	}
+
+	return width + gutter
+}
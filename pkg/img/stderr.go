@@ -0,0 +1,180 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package img
+
+import (
+	"bytes"
+	"fmt"
+	"image/color"
+	"sort"
+	"strings"
+
+	"github.com/gonvenience/bunt"
+
+	"github.com/homeport/termshot/internal/vt"
+)
+
+// StderrStart and StderrEnd enclose the output of the standard error in the
+// content, so that it can be styled differently, see [Scaffold.SetStderrStyle]
+const (
+	StderrStart = "\x1b]termshot;stderr\x07"
+	StderrEnd   = "\x1b]termshot;stdout\x07"
+)
+
+// stderrMarkerColor is the color of the marker next to lines of the
+// standard error
+var stderrMarkerColor = color.RGBA{R: 0xF0, G: 0x47, B: 0x47, A: 0xFF}
+
+// SetStderrStyle sets the style of the output of the standard error, which
+// is enclosed in [StderrStart] and [StderrEnd] in the content, as a comma
+// separated list of a color in hex notation (#rrggbb), the attributes bold,
+// dim, italic, and underline, and marker for a marker next to the lines,
+// which is left out in case the content is redacted
+func (s *Scaffold) SetStderrStyle(style string) error {
+	var parts []string
+	s.stderrMarker = false
+	for _, part := range strings.Split(style, ",") {
+		if strings.TrimSpace(part) == "marker" {
+			s.stderrMarker = true
+			continue
+		}
+
+		parts = append(parts, part)
+	}
+
+	settings, err := parseStyle(strings.Join(parts, ","))
+	if err != nil {
+		return fmt.Errorf("invalid stderr style: %w", err)
+	}
+
+	s.stderrStyle = settings
+	return nil
+}
+
+// styleStderr applies the style to the output of the standard error, also
+// after each reset of the text attributes within the output
+func (s *Scaffold) styleStderr(data []byte) []byte {
+	if s.stderrStyle == 0 || !bytes.Contains(data, []byte(StderrStart)) {
+		return data
+	}
+
+	style := renderSettings(s.stderrStyle)
+
+	var result bytes.Buffer
+	for i, part := range bytes.Split(data, []byte(StderrStart)) {
+		if i == 0 {
+			result.Write(part)
+			continue
+		}
+
+		stderr, stdout, _ := bytes.Cut(part, []byte(StderrEnd))
+		for _, reset := range []string{"\x1b[0m", "\x1b[m"} {
+			stderr = bytes.ReplaceAll(stderr, []byte(reset), []byte(reset+style))
+		}
+
+		result.WriteString(StderrStart + style)
+		result.Write(stderr)
+		result.WriteString("\x1b[0m" + StderrEnd)
+		result.Write(stdout)
+	}
+
+	return result.Bytes()
+}
+
+// writeContent writes the data to the screen and returns the lines of the
+// screen with output of the standard error
+func writeContent(screen *vt.Screen, data []byte) ([]int, error) {
+	var lines []int
+	for i, part := range bytes.Split(data, []byte(StderrStart)) {
+		stderr, stdout, found := bytes.Cut(part, []byte(StderrEnd))
+		if i == 0 {
+			stderr, stdout = nil, part
+
+		} else if !found {
+			stdout = nil
+		}
+
+		_, start := screen.Cursor()
+		if _, err := screen.Write(stderr); err != nil {
+			return nil, err
+		}
+
+		// A line is not marked if the output only ends with its line feed
+		x, end := screen.Cursor()
+		if x == 0 && end > start {
+			end--
+		}
+
+		for line := start; line <= end && len(stderr) > 0; line++ {
+			if len(lines) == 0 || lines[len(lines)-1] != line {
+				lines = append(lines, line)
+			}
+		}
+
+		if _, err := screen.Write(stdout); err != nil {
+			return nil, err
+		}
+	}
+
+	return lines, nil
+}
+
+// wrappedLines returns the lines of the content wrapped at whitespace, that
+// belong to the given lines of the content before it was wrapped
+func wrappedLines(wrapped bunt.String, lines []int) []int {
+	var result []int
+	line := -1
+	for row, text := range splitLines(wrapped) {
+		// Lines that start with the continuation marker continue the line before
+		continued := len(text) >= len(continuationMarker)
+		for i := 0; continued && i < len(continuationMarker); i++ {
+			continued = text[i] == continuationMarker[i]
+		}
+
+		if !continued || line < 0 {
+			line++
+		}
+
+		if i := sort.SearchInts(lines, line); i < len(lines) && lines[i] == line {
+			result = append(result, row)
+		}
+	}
+
+	return result
+}
+
+// markedLines returns the lines with output of the standard error, in case
+// they are marked
+func (s *Scaffold) markedLines() []int {
+	if !s.stderrMarker {
+		return nil
+	}
+
+	return s.stderrLines
+}
+
+// markerArea returns the area of the marker next to a line of the standard
+// error, which is at the left border of the window
+func (s *Scaffold) markerArea(fr frame, line int) Area {
+	area := s.highlightArea(fr, line)
+	area.X, area.Width = fr.window.X+s.factor, s.factor*3
+	return area
+}
@@ -0,0 +1,351 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package img
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/fogleman/gg"
+)
+
+// WriteSVG writes the scaffold content as SVG into the provided writer, with
+// the content as text elements, so that it stays selectable and scalable
+func (s *Scaffold) WriteSVG(w io.Writer) error {
+	f := func(value float64) float64 { return s.factor * value }
+
+	fr := s.frame()
+	out := bufio.NewWriter(w)
+	p := func(format string, a ...interface{}) { _, _ = fmt.Fprintf(out, format, a...) }
+
+	viewBox := Area{Width: fr.width, Height: fr.height}
+	if s.clipCanvas && s.background == nil {
+		viewBox = s.visibleArea(fr)
+	}
+
+	p(`<svg xmlns="http://www.w3.org/2000/svg" width="%s" height="%s" viewBox="%s %s %s %s">`+"\n",
+		num(viewBox.Width), num(viewBox.Height), num(viewBox.X), num(viewBox.Y), num(viewBox.Width), num(viewBox.Height))
+
+	// Optional: Background behind the window is drawn by the background
+	// function, therefore it is embedded as an image
+	//
+	if s.background != nil {
+		dc := gg.NewContext(int(fr.width), int(fr.height))
+		if err := s.background(dc, float64(dc.Width()), float64(dc.Height())); err != nil {
+			return err
+		}
+
+		if err := embedImage(out, dc); err != nil {
+			return err
+		}
+	}
+
+	// Optional: Blurred rounded rectangle to mimic the window shadow
+	//
+	if s.drawShadow {
+		p(`<defs><filter id="shadow" x="-50%%" y="-50%%" width="200%%" height="200%%"><feGaussianBlur stdDeviation="%s"/></filter></defs>`+"\n", num(float64(s.shadowRadius)/2))
+		p(`<rect x="%s" y="%s" width="%s" height="%s" rx="%s" %s filter="url(#shadow)"/>`+"\n",
+			num(fr.shadow.X), num(fr.shadow.Y), num(fr.shadow.Width), num(fr.shadow.Height), num(fr.corner), fill(parseShadowColor(s.shadowBaseColor)))
+	}
+
+	// Rounded rectangle with outline to produce impression of a window
+	//
+	p(`<rect x="%s" y="%s" width="%s" height="%s" rx="%s" %s/>`+"\n",
+		num(fr.window.X), num(fr.window.Y), num(fr.window.Width), num(fr.window.Height), num(fr.corner), fill(s.windowColor()))
+
+	if s.drawBorder {
+		for _, line := range s.borderLines(fr) {
+			p(`<rect x="%s" y="%s" width="%s" height="%s" rx="%s" fill="none" %s stroke-width="%s"/>`+"\n",
+				num(line.area.X), num(line.area.Y), num(line.area.Width), num(line.area.Height), num(line.radius), stroke(line.color), num(s.borderWidth))
+		}
+	}
+
+	// Optional: Window decorations (i.e. three buttons)
+	//
+	if s.drawDecorations {
+		for i, color := range []string{red, yellow, green} {
+			p(`<circle cx="%s" cy="%s" r="%s" fill="%s"/>`+"\n", num(fr.buttonX+float64(i)*fr.distance), num(fr.buttonY), num(fr.radius), color)
+		}
+	}
+
+	fontSize := s.factor * s.fontSize * defaultFontDPI / 72
+	fontFamily := "monospace"
+	if family := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`'"&<>`, r) {
+			return -1
+		}
+		return r
+	}, s.fontFamily); family != "" {
+		fontFamily = fmt.Sprintf("'%s', monospace", family)
+	}
+
+	p(`<g font-family="%s" font-size="%s" xml:space="preserve">`+"\n", fontFamily, num(fontSize))
+
+	// Optional: Window title
+	//
+	if s.title != "" {
+		title, x, anchor := s.titlePlacement(fr)
+
+		textAnchor := "start"
+		if anchor == 0.5 {
+			textAnchor = "middle"
+		}
+
+		p(`<text x="%s" y="%s" text-anchor="%s" %s>%s</text>`+"\n",
+			num(x), num(fr.buttonY+0.35*float64(fr.regular.Metrics().Height)/64), textAnchor, fill(s.defaultForegroundColor), escape(title))
+	}
+
+	// Optional: Timestamp in the right corner of the title bar
+	//
+	if s.timestamp != "" {
+		p(`<text x="%s" y="%s" text-anchor="end" %s>%s</text>`+"\n",
+			num(fr.window.X+fr.window.Width-s.paddingRight), num(fr.buttonY+0.35*float64(fr.regular.Metrics().Height)/64), fill(lineNumberColor), escape(s.timestamp))
+	}
+
+	// Optional: Highlighted lines
+	//
+	for _, line := range s.highlightedLines() {
+		area := s.highlightArea(fr, line)
+		p(`<rect x="%s" y="%s" width="%s" height="%s" %s/>`+"\n", num(area.X), num(area.Y), num(area.Width), num(area.Height), fill(s.highlights[line]))
+	}
+
+	// Optional: Markers of lines with output of the standard error
+	//
+	for _, line := range s.markedLines() {
+		area := s.markerArea(fr, line)
+		p(`<rect x="%s" y="%s" width="%s" height="%s" %s/>`+"\n", num(area.X), num(area.Y), num(area.Width), num(area.Height), fill(stderrMarkerColor))
+	}
+
+	// Optional: Line numbers in the gutter
+	//
+	for _, g := range s.lineNumberGlyphs(fr) {
+		p(`<text x="%s" y="%s" %s>%s</text>`+"\n", num(g.x), num(g.y), fill(lineNumberColor), g.text)
+	}
+
+	glyphs := s.glyphs(fr)
+
+	// Background colors of the content, adjacent cells with the same color
+	// are merged into one rectangle
+	//
+	for i := 0; i < len(glyphs); i++ {
+		bg, ok := s.backgroundColor(glyphs[i].cr)
+		if !ok {
+			continue
+		}
+
+		start, width := glyphs[i], glyphs[i].width
+		for i+1 < len(glyphs) && glyphs[i+1].y == start.y && glyphs[i+1].height == start.height {
+			if next, ok := s.backgroundColor(glyphs[i+1].cr); !ok || next != bg || glyphs[i+1].x != start.x+width {
+				break
+			}
+
+			i++
+			width += glyphs[i].width
+		}
+
+		start.width = width
+		cell := s.cell(start)
+		p(`<rect x="%s" y="%s" width="%s" height="%s" %s/>`+"\n", num(cell.X), num(cell.Y), num(cell.Width), num(cell.Height), fill(bg))
+	}
+
+	// Optional: Blurred bars for redacted characters
+	//
+	if areas := s.blurredAreas(glyphs); len(areas) > 0 {
+		p(`<defs><filter id="redacted"><feGaussianBlur stdDeviation="%s"/></filter></defs>`+"\n", num(f(3)/2))
+		for _, area := range areas {
+			p(`<rect x="%s" y="%s" width="%s" height="%s" rx="%s" %s filter="url(#redacted)"/>`+"\n",
+				num(area.X), num(area.Y), num(area.Width), num(area.Height), num(f(2)), fill(area.color))
+		}
+	}
+
+	// Text of the content, consecutive characters with the same style are
+	// combined into one text element, which is stretched to the exact width
+	// the characters have in the raster image to keep the columns aligned
+	// even if the viewer uses a different font
+	//
+	for i := 0; i < len(glyphs); i++ {
+		if glyphs[i].text == "" {
+			continue
+		}
+
+		start := glyphs[i]
+		var text strings.Builder
+		text.WriteString(start.text)
+		width := start.width
+
+		for i+1 < len(glyphs) && glyphs[i+1].text != "" && glyphs[i+1].y == start.y &&
+			glyphs[i+1].cr.Settings&^0x02 == start.cr.Settings&^0x02 &&
+			s.foregroundColor(glyphs[i+1].cr) == s.foregroundColor(start.cr) {
+			i++
+			text.WriteString(glyphs[i].text)
+			width += glyphs[i].width
+		}
+
+		var style string
+		if start.cr.Settings&0x04 != 0 {
+			style += ` font-weight="bold"`
+		}
+
+		if start.cr.Settings&0x08 != 0 {
+			style += ` font-style="italic"`
+		}
+
+		// The characters are already in display order, the viewer must not
+		// reorder right-to-left text a second time
+		if strings.ContainsFunc(text.String(), isRightToLeft) {
+			style += ` unicode-bidi="bidi-override"`
+		}
+
+		p(`<text x="%s" y="%s" textLength="%s" lengthAdjust="spacingAndGlyphs" %s%s>%s</text>`+"\n",
+			num(start.x), num(start.y), num(width), fill(s.foregroundColor(start.cr)), style, escape(text.String()))
+
+		if start.cr.Settings&0x10 != 0 {
+			stroke := s.foregroundColor(start.cr)
+			if c, ok := s.underlineColor(start.cr); ok {
+				stroke = c
+			}
+
+			for _, path := range s.underlinePaths(s.table.Underline(start.cr.Settings), start.x, start.x+width, start.y) {
+				if len(path) == 2 {
+					p(`<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="%s" stroke-width="%s"/>`+"\n",
+						num(path[0][0]), num(path[0][1]), num(path[1][0]), num(path[1][1]), HexColor(stroke), num(f(1)))
+					continue
+				}
+
+				points := make([]string, len(path))
+				for j, point := range path {
+					points[j] = num(point[0]) + "," + num(point[1])
+				}
+
+				p(`<polyline points="%s" fill="none" stroke="%s" stroke-width="%s"/>`+"\n",
+					strings.Join(points, " "), HexColor(stroke), num(f(1)))
+			}
+		}
+
+		if start.cr.Settings&0x40 != 0 {
+			y := start.y - strikethroughOffset(start.face)
+			p(`<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="%s" stroke-width="%s"/>`+"\n",
+				num(start.x), num(y), num(start.x+width), num(y), HexColor(s.foregroundColor(start.cr)), num(f(1)))
+		}
+	}
+
+	p("</g>\n")
+
+	// Optional: Fade out the content towards the lines that are left out
+	//
+	if fr.faded {
+		area, opaque, transparent := s.fadeArea(fr)
+		p(`<defs><linearGradient id="fade" gradientUnits="userSpaceOnUse" x1="0" y1="%s" x2="0" y2="%s"><stop offset="0" stop-color="%s"/><stop offset="1" stop-color="%s" stop-opacity="0"/></linearGradient></defs>`+"\n",
+			num(opaque), num(transparent), HexColor(s.defaultBackgroundColor), HexColor(s.defaultBackgroundColor))
+		p(`<rect x="%s" y="%s" width="%s" height="%s" fill="url(#fade)"/>`+"\n", num(area.X), num(area.Y), num(area.Width), num(area.Height))
+	}
+
+	// Optional: Custom decorations are drawn by the decoration functions,
+	// therefore they are embedded as an image on top of the window
+	//
+	if len(s.decorations) > 0 {
+		dc := gg.NewContext(int(fr.width), int(fr.height))
+		if err := s.drawDecorationsOn(dc, fr); err != nil {
+			return err
+		}
+
+		if err := embedImage(out, dc); err != nil {
+			return err
+		}
+	}
+
+	// Optional: Watermark on top of everything
+	//
+	if s.watermark != nil {
+		layer, err := s.watermarkLayer(fr)
+		if err != nil {
+			return err
+		}
+
+		if err := embedImage(out, gg.NewContextForRGBA(layer)); err != nil {
+			return err
+		}
+	}
+
+	p("</svg>\n")
+
+	return out.Flush()
+}
+
+// embedImage writes the image of the drawing context as SVG image element
+func embedImage(w io.Writer, dc *gg.Context) error {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, dc.Image()); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, `<image x="0" y="0" width="%d" height="%d" href="data:image/png;base64,%s"/>`+"\n",
+		dc.Width(), dc.Height(), base64.StdEncoding.EncodeToString(buf.Bytes()))
+	return err
+}
+
+func num(value float64) string {
+	return strconv.FormatFloat(math.Round(value*100)/100, 'f', -1, 64)
+}
+
+func escape(text string) string {
+	var buf bytes.Buffer
+	_ = xml.EscapeText(&buf, []byte(text))
+	return buf.String()
+}
+
+// fill returns the SVG fill attributes for the color
+func fill(c color.Color) string {
+	nrgba := color.NRGBAModel.Convert(c).(color.NRGBA)
+	if nrgba.A == 0xFF {
+		return fmt.Sprintf(`fill="%s"`, HexColor(c))
+	}
+
+	return fmt.Sprintf(`fill="#%02X%02X%02X" fill-opacity="%s"`, nrgba.R, nrgba.G, nrgba.B, num(float64(nrgba.A)/255))
+}
+
+func stroke(c color.Color) string {
+	nrgba := color.NRGBAModel.Convert(c).(color.NRGBA)
+	if nrgba.A == 0xFF {
+		return fmt.Sprintf(`stroke="%s"`, HexColor(c))
+	}
+
+	return fmt.Sprintf(`stroke="#%02X%02X%02X" stroke-opacity="%s"`, nrgba.R, nrgba.G, nrgba.B, num(float64(nrgba.A)/255))
+}
+
+// parseShadowColor parses the shadow color in #rrggbbaa notation
+func parseShadowColor(hex string) color.Color {
+	value, err := strconv.ParseUint(strings.TrimPrefix(hex, "#"), 16, 32)
+	if err != nil {
+		return color.NRGBA{A: 0x66}
+	}
+
+	return color.NRGBA{R: uint8(value >> 24), G: uint8(value >> 16), B: uint8(value >> 8), A: uint8(value)} // #nosec G115
+}
@@ -0,0 +1,209 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package img
+
+import (
+	"github.com/gonvenience/bunt"
+)
+
+// TrimWhitespace configures whether trailing whitespace and the common
+// leading indentation of all lines are ignored for the size of the window
+func (s *Scaffold) TrimWhitespace(value bool) { s.trimWhitespace = value }
+
+// SetLineRange limits the content to the lines from first to last, which are
+// counted from one and both included, where zero leaves the end of the range
+// open, e.g. only the first line limits the content to the lines after it
+func (s *Scaffold) SetLineRange(first, last int) { s.firstLine, s.lastLine = first, last }
+
+// TrimBlankLines configures whether empty lines at the start and the end of
+// the content are left out, e.g. the trailing line feeds of a command
+func (s *Scaffold) TrimBlankLines(value bool) { s.trimBlankLines = value }
+
+// visibleContent returns the content as it is rendered, i.e. only the kept
+// lines if the number of rows or lines is limited, and without trailing
+// whitespace and common indentation if trimming is configured
+func (s *Scaffold) visibleContent() bunt.String {
+	content, _, _ := s.visibleContentOffset()
+	return content
+}
+
+// visibleContentOffset returns the visible content, see visibleContent, and
+// the number of lines and columns that are cut off at the top and the left
+func (s *Scaffold) visibleContentOffset() (content bunt.String, lines int, columns int) {
+	content, lines = s.selectedLines()
+	if s.maxLines > 0 {
+		var cut int
+		content, cut = s.truncate(content)
+		lines += cut
+	}
+
+	if s.rows > 0 {
+		lines += max(0, len(splitLines(content))-s.rows)
+		content = lastLines(content, s.rows)
+	}
+
+	if !s.trimWhitespace {
+		return content, lines, 0
+	}
+
+	trimmed := splitLines(content)
+
+	indent := -1
+	for i := range trimmed {
+		end := len(trimmed[i])
+		for end > 0 && isBlank(trimmed[i][end-1]) {
+			end--
+		}
+
+		trimmed[i] = trimmed[i][:end]
+		if len(trimmed[i]) == 0 {
+			continue
+		}
+
+		var n int
+		for n < len(trimmed[i]) && trimmed[i][n].Symbol == ' ' && isBlank(trimmed[i][n]) {
+			n++
+		}
+
+		if indent < 0 || n < indent {
+			indent = n
+		}
+	}
+
+	var result bunt.String
+	for _, line := range trimmed {
+		if len(line) >= indent && indent > 0 {
+			line = line[indent:]
+		}
+
+		result = append(result, line...)
+		result = append(result, bunt.ColoredRune{Symbol: '\n'})
+	}
+
+	return result, lines, max(0, indent)
+}
+
+// isBlank reports whether the character is invisible whitespace, since
+// whitespace with a background color, also the one of reverse video, is
+// visible and therefore kept
+func isBlank(cr bunt.ColoredRune) bool {
+	return cr.Symbol == ' ' && cr.Settings&0x22 == 0
+}
+
+// selectedLines returns the lines of the content in the line range, without
+// blank lines at the start and end if configured, and the number of lines
+// that are left out before them
+func (s *Scaffold) selectedLines() (content bunt.String, lines int) {
+	content = s.content
+	if s.firstLine > 0 || s.lastLine > 0 {
+		content, lines = lineRange(content, s.firstLine, s.lastLine)
+	}
+
+	if s.trimBlankLines {
+		var removed int
+		content, removed = trimBlankLines(content)
+		lines += removed
+	}
+
+	return content, lines
+}
+
+// lineRange returns the lines of the content from first to last, counting
+// from one, and the number of lines before them
+func lineRange(content bunt.String, first, last int) (bunt.String, int) {
+	lines := splitLines(content)
+
+	start, end := max(first-1, 0), len(lines)
+	if last > 0 {
+		end = min(last, end)
+	}
+
+	var result bunt.String
+	for i := start; i < end; i++ {
+		result = append(result, lines[i]...)
+		result = append(result, bunt.ColoredRune{Symbol: '\n'})
+	}
+
+	return result, start
+}
+
+// trimBlankLines returns the content without the lines at the start and the
+// end that only consist of blanks, and the number of lines removed at the start
+func trimBlankLines(content bunt.String) (bunt.String, int) {
+	lines := splitLines(content)
+	blank := func(line bunt.String) bool {
+		for _, cr := range line {
+			if !isBlank(cr) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	start, end := 0, len(lines)
+	for start < end && blank(lines[start]) {
+		start++
+	}
+
+	for end > start && blank(lines[end-1]) {
+		end--
+	}
+
+	var result bunt.String
+	for _, line := range lines[start:end] {
+		result = append(result, line...)
+		result = append(result, bunt.ColoredRune{Symbol: '\n'})
+	}
+
+	return result, start
+}
+
+// lastLines returns the last lines of the content, padded with empty lines
+// in case there are not enough lines
+func lastLines(content bunt.String, rows int) bunt.String {
+	var starts []int
+	for i, cr := range content {
+		if cr.Symbol == '\n' && i < len(content)-1 {
+			starts = append(starts, i+1)
+		}
+	}
+
+	if len(content) > 0 {
+		starts = append([]int{0}, starts...)
+	}
+
+	if len(starts) > rows {
+		content = content[starts[len(starts)-rows]:]
+		starts = starts[:rows]
+	}
+
+	result := append(bunt.String{}, content...)
+	if len(result) > 0 && result[len(result)-1].Symbol != '\n' {
+		result = append(result, bunt.ColoredRune{Symbol: '\n'})
+	}
+
+	for i := len(starts); i < rows; i++ {
+		result = append(result, bunt.ColoredRune{Symbol: '\n'})
+	}
+
+	return result
+}
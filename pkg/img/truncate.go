@@ -0,0 +1,144 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package img
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/fogleman/gg"
+	"github.com/gonvenience/bunt"
+)
+
+// fadeLines is the number of lines over which the content fades out
+const fadeLines = 3
+
+// TruncationMarker defines how the lines that are left out due to the
+// maximum number of lines are marked
+type TruncationMarker int
+
+const (
+	// TruncationSeparator adds a dimmed line with the number of omitted lines
+	TruncationSeparator TruncationMarker = iota
+
+	// TruncationFade fades out the content towards the omitted lines
+	TruncationFade
+
+	// TruncationNone leaves out the lines without any marker
+	TruncationNone
+)
+
+// SetMaxLines limits the content to the provided number of lines, where the
+// last lines are kept unless configured otherwise, see [Scaffold.KeepFirstLines]
+func (s *Scaffold) SetMaxLines(lines int) { s.maxLines = lines }
+
+// KeepFirstLines configures whether the first instead of the last lines are
+// kept in case the content has more lines than the maximum
+func (s *Scaffold) KeepFirstLines(value bool) { s.keepFirstLines = value }
+
+// SetTruncationMarker sets how the lines left out due to the maximum number
+// of lines are marked
+func (s *Scaffold) SetTruncationMarker(marker TruncationMarker) { s.truncationMarker = marker }
+
+// truncated reports whether lines of the content are left out
+func (s *Scaffold) truncated() bool {
+	if s.maxLines <= 0 {
+		return false
+	}
+
+	content, _ := s.selectedLines()
+	return len(splitLines(content)) > s.maxLines
+}
+
+// truncate returns the content limited to the maximum number of lines, and
+// the number of lines that are cut off at the top
+func (s *Scaffold) truncate(content bunt.String) (bunt.String, int) {
+	lines := splitLines(content)
+	omitted := len(lines) - s.maxLines
+	if s.maxLines <= 0 || omitted <= 0 {
+		return content, 0
+	}
+
+	var separator []bunt.String
+	if s.truncationMarker == TruncationSeparator {
+		text := fmt.Sprintf("… %d lines omitted …", omitted)
+		if omitted == 1 {
+			text = "… 1 line omitted …"
+		}
+
+		var line bunt.String
+		for _, r := range text {
+			line = append(line, bunt.ColoredRune{Symbol: r, Settings: 0x80})
+		}
+
+		separator = append(separator, line)
+	}
+
+	kept, cut := append(separator, lines[omitted:]...), omitted-len(separator)
+	if s.keepFirstLines {
+		kept, cut = append(lines[:s.maxLines:s.maxLines], separator...), 0
+	}
+
+	var result bunt.String
+	for _, line := range kept {
+		result = append(result, line...)
+		result = append(result, bunt.ColoredRune{Symbol: '\n'})
+	}
+
+	return result, cut
+}
+
+// fadeArea returns the area over the lines at the edge where lines are left
+// out, and the vertical positions where the fade is opaque and transparent
+func (s *Scaffold) fadeArea(fr frame) (area Area, opaque float64, transparent float64) {
+	height := math.Min(fadeLines*s.fontHeight()*s.lineSpacing, fr.content.Height)
+	// The fade spans the width of the window without its border
+	area = Area{X: fr.window.X + s.factor, Y: fr.content.Y, Width: fr.window.Width - 2*s.factor, Height: height}
+	if s.keepFirstLines {
+		area.Y = fr.content.Y + fr.content.Height - height
+		return area, area.Y + height, area.Y
+	}
+
+	return area, area.Y, area.Y + height
+}
+
+// drawFade fades out the content towards the edge where lines are left out
+// by drawing a gradient from the window background to transparent
+func (s *Scaffold) drawFade(dc *gg.Context, fr frame) {
+	area, opaque, transparent := s.fadeArea(fr)
+
+	// Gradients use the coordinates of the image, which differ from the ones
+	// of the context when only a band of the image is drawn
+	x0, y0 := dc.TransformPoint(0, opaque)
+	x1, y1 := dc.TransformPoint(0, transparent)
+
+	r, g, b, _ := s.defaultBackgroundColor.RGBA()
+	background := color.NRGBA{R: uint8(r >> 8), G: uint8(g >> 8), B: uint8(b >> 8), A: 255}
+
+	gradient := gg.NewLinearGradient(x0, y0, x1, y1)
+	gradient.AddColorStop(0, background)
+	gradient.AddColorStop(1, color.NRGBA{R: background.R, G: background.G, B: background.B})
+
+	dc.SetFillStyle(gradient)
+	dc.DrawRectangle(area.X, area.Y, area.Width, area.Height)
+	dc.Fill()
+}
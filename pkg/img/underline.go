@@ -0,0 +1,122 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package img
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/fogleman/gg"
+	"github.com/gonvenience/bunt"
+
+	"github.com/homeport/termshot/internal/vt"
+)
+
+// underlineNames are the names of the underline styles used in the JSON
+// cells and the CSS styles
+var underlineNames = map[vt.UnderlineStyle]string{
+	vt.UnderlineDouble: "double",
+	vt.UnderlineCurly:  "wavy",
+	vt.UnderlineDotted: "dotted",
+	vt.UnderlineDashed: "dashed",
+}
+
+// underlineColor returns the color of the underline of the character, if it
+// has a color other than the color of the text
+func (s *Scaffold) underlineColor(cr bunt.ColoredRune) (color.Color, bool) {
+	c, ok := s.table.UnderlineColor(cr.Settings)
+	if !ok {
+		return nil, false
+	}
+
+	return s.settingsColor(c, false)
+}
+
+// underlinePaths returns the lines to draw under the text from x1 to x2 on
+// the baseline y, where each line is a list of points, dots, dashes, and
+// waves are aligned to the canvas so that they continue across characters
+func (s *Scaffold) underlinePaths(style vt.UnderlineStyle, x1, x2, y float64) [][][2]float64 {
+	f := func(value float64) float64 { return s.factor * value }
+
+	segments := func(period, length float64) [][][2]float64 {
+		var paths [][][2]float64
+		for start := math.Floor(x1/period) * period; start < x2; start += period {
+			from, to := math.Max(start, x1), math.Min(start+length, x2)
+			if from < to {
+				paths = append(paths, [][2]float64{{from, y + f(4)}, {to, y + f(4)}})
+			}
+		}
+
+		return paths
+	}
+
+	switch style {
+	case vt.UnderlineDouble:
+		return [][][2]float64{
+			{{x1, y + f(2.5)}, {x2, y + f(2.5)}},
+			{{x1, y + f(4.5)}, {x2, y + f(4.5)}},
+		}
+
+	case vt.UnderlineCurly:
+		var path [][2]float64
+		for x := x1; ; x = math.Min(x+f(0.5), x2) {
+			path = append(path, [2]float64{x, y + f(4) + f(1)*math.Sin(x/f(4)*2*math.Pi)})
+			if x >= x2 {
+				break
+			}
+		}
+
+		return [][][2]float64{path}
+
+	case vt.UnderlineDotted:
+		return segments(f(2), f(1))
+
+	case vt.UnderlineDashed:
+		return segments(f(6), f(4))
+
+	default:
+		return [][][2]float64{{{x1, y + f(4)}, {x2, y + f(4)}}}
+	}
+}
+
+// drawUnderline draws the underline of the glyph in its style and color
+func (s *Scaffold) drawUnderline(dc *gg.Context, g glyph, fg color.Color) {
+	if c, ok := s.underlineColor(g.cr); ok {
+		fg = c
+	}
+
+	style := s.table.Underline(g.cr.Settings)
+	if style == vt.UnderlineDotted || style == vt.UnderlineDashed {
+		dc.SetLineCapButt()
+		defer dc.SetLineCapRound()
+	}
+
+	dc.SetColor(fg)
+	for _, path := range s.underlinePaths(style, g.x, g.x+g.width, g.y) {
+		dc.MoveTo(path[0][0], path[0][1])
+		for _, point := range path[1:] {
+			dc.LineTo(point[0], point[1])
+		}
+
+		dc.SetLineWidth(s.factor)
+		dc.Stroke()
+	}
+}
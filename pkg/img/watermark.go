@@ -0,0 +1,123 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package img
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/fogleman/gg"
+	"golang.org/x/image/draw"
+	imgfont "golang.org/x/image/font"
+)
+
+// WatermarkPosition defines where the watermark is placed in the window
+type WatermarkPosition string
+
+// Supported positions of the watermark
+const (
+	WatermarkTopLeft     WatermarkPosition = "top-left"
+	WatermarkTopRight    WatermarkPosition = "top-right"
+	WatermarkBottomLeft  WatermarkPosition = "bottom-left"
+	WatermarkBottomRight WatermarkPosition = "bottom-right"
+	WatermarkCenter      WatermarkPosition = "center"
+)
+
+// Watermark is an image or text stamped on top of the finished window
+type Watermark struct {
+	// Image is the logo to stamp, it is scaled down to fit into a quarter of
+	// the window width and half of the window height if needed
+	Image image.Image
+
+	// Text is stamped in the default foreground color if no image is set
+	Text string
+
+	Position WatermarkPosition
+
+	// Opacity of the watermark between 0 (invisible) and 1 (opaque)
+	Opacity float64
+}
+
+// SetWatermark configures a watermark that is drawn on top of everything
+// else, use nil to remove the watermark again
+func (s *Scaffold) SetWatermark(watermark *Watermark) { s.watermark = watermark }
+
+// watermarkLayer renders the watermark with its opacity onto a transparent
+// layer the size of the canvas
+func (s *Scaffold) watermarkLayer(fr frame) (*image.RGBA, error) {
+	wm := s.watermark
+	inset := s.factor * 8
+
+	var width, height float64
+	var logo image.Image
+	switch {
+	case wm.Image != nil:
+		bounds := wm.Image.Bounds()
+		scale := math.Min(1, math.Min(fr.window.Width/4/float64(bounds.Dx()), fr.window.Height/2/float64(bounds.Dy())))
+		width, height = math.Round(float64(bounds.Dx())*scale), math.Round(float64(bounds.Dy())*scale)
+
+		scaled := image.NewRGBA(image.Rect(0, 0, int(width), int(height)))
+		draw.CatmullRom.Scale(scaled, scaled.Bounds(), wm.Image, bounds, draw.Src, nil)
+		logo = scaled
+
+	default:
+		width = float64(imgfont.MeasureString(fr.regular, wm.Text) >> 6)
+		height = s.fontHeight()
+	}
+
+	var x, y float64
+	switch wm.Position {
+	case WatermarkTopLeft:
+		x, y = fr.window.X+inset, fr.window.Y+inset
+
+	case WatermarkTopRight:
+		x, y = fr.window.X+fr.window.Width-inset-width, fr.window.Y+inset
+
+	case WatermarkBottomLeft:
+		x, y = fr.window.X+inset, fr.window.Y+fr.window.Height-inset-height
+
+	case WatermarkBottomRight, "":
+		x, y = fr.window.X+fr.window.Width-inset-width, fr.window.Y+fr.window.Height-inset-height
+
+	case WatermarkCenter:
+		x, y = fr.window.X+(fr.window.Width-width)/2, fr.window.Y+(fr.window.Height-height)/2
+
+	default:
+		return nil, fmt.Errorf("unsupported watermark position %q", wm.Position)
+	}
+
+	dc := gg.NewContext(int(fr.width), int(fr.height))
+	if logo != nil {
+		dc.DrawImage(logo, int(math.Round(x)), int(math.Round(y)))
+	} else {
+		dc.SetFontFace(fr.regular)
+		dc.SetColor(s.defaultForegroundColor)
+		dc.DrawString(wm.Text, x, y+float64(fr.regular.Metrics().Ascent>>6))
+	}
+
+	opacity := math.Max(0, math.Min(1, wm.Opacity))
+	layer := image.NewRGBA(image.Rect(0, 0, dc.Width(), dc.Height()))
+	draw.DrawMask(layer, layer.Bounds(), dc.Image(), image.Point{}, image.NewUniform(color.Alpha{A: uint8(math.Round(opacity * 255))}), image.Point{}, draw.Src)
+
+	return layer, nil
+}
@@ -0,0 +1,140 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package img
+
+import (
+	"github.com/gonvenience/bunt"
+
+	"github.com/homeport/termshot/internal/vt"
+)
+
+// WrapMode defines how lines that are longer than the number of columns are
+// wrapped
+type WrapMode int
+
+const (
+	// WrapCharacter wraps lines after the last column like a terminal
+	WrapCharacter WrapMode = iota
+
+	// WrapWord wraps lines at whitespace if possible, and marks the lines
+	// that continue the previous line
+	WrapWord
+
+	// WrapNone does not wrap lines at all
+	WrapNone
+)
+
+// continuationMarker starts the lines that continue the previous line in
+// case lines are wrapped at whitespace
+var continuationMarker = bunt.String{
+	{Symbol: '↪', Settings: 0x80},
+	{Symbol: ' ', Settings: 0x80},
+}
+
+// SetWrap sets how lines longer than the number of columns are wrapped
+func (s *Scaffold) SetWrap(mode WrapMode) { s.wrap = mode }
+
+// wrapWords wraps the lines of the content at whitespace, so that they fit
+// into the number of columns, words longer than a line are wrapped after the
+// last column, the cursor position is moved along with its character
+func wrapWords(content bunt.String, table *vt.Table, columns int, x, y int) (bunt.String, int, int) {
+	if columns <= len(continuationMarker) {
+		return content, x, y
+	}
+
+	var lines []bunt.String
+	for start := 0; start <= len(content); start++ {
+		end := start
+		for end < len(content) && content[end].Symbol != '\n' {
+			end++
+		}
+
+		lines, start = append(lines, content[start:end]), end
+	}
+
+	var result bunt.String
+	var cursorX, cursorY, row int
+	for i, line := range lines {
+		if i > 0 {
+			result = append(result, bunt.ColoredRune{Symbol: '\n'})
+			row++
+		}
+
+		width, offset, marker := columns, 0, 0
+		placed := i != y
+		for {
+			end, next := wrapPoint(line, table, width)
+			if !placed && (x < offset+next || next == len(line)) {
+				cursorX, cursorY, placed = marker+min(x-offset, end), row, true
+			}
+
+			result = append(result, line[:end]...)
+			if next == len(line) {
+				break
+			}
+
+			result = append(append(result, bunt.ColoredRune{Symbol: '\n'}), continuationMarker...)
+			line, offset, row = line[next:], offset+next, row+1
+			width, marker = columns-len(continuationMarker), len(continuationMarker)
+		}
+	}
+
+	return result, cursorX, cursorY
+}
+
+// wrapPoint returns the end of the part of the line that fits into the width,
+// which is the last whitespace if there is one, and the start of the rest
+func wrapPoint(line bunt.String, table *vt.Table, width int) (end int, next int) {
+	var cells int
+	for end < len(line) && cells+table.Width(line[end].Symbol) <= width {
+		cells += table.Width(line[end].Symbol)
+		end++
+	}
+
+	// A wide character is kept in a line even if it does not fit
+	end = max(end, 1)
+	if end >= len(line) {
+		return len(line), len(line)
+	}
+
+	for i := end; i > 0; i-- {
+		if line[i].Symbol != ' ' {
+			continue
+		}
+
+		next = i
+		for next < len(line) && line[next].Symbol == ' ' {
+			next++
+		}
+
+		for i > 0 && line[i-1].Symbol == ' ' {
+			i--
+		}
+
+		if i > 0 {
+			return i, next
+		}
+
+		break
+	}
+
+	return end, end
+}